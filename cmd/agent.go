@@ -10,7 +10,6 @@ import (
 	"github.com/charlietran/linctl/pkg/api"
 	"github.com/charlietran/linctl/pkg/auth"
 	"github.com/charlietran/linctl/pkg/output"
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -65,14 +64,14 @@ Examples:
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 		issue, err := client.GetIssueAgentSession(context.Background(), args[0])
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to fetch issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Find agent session from comments
@@ -149,53 +148,53 @@ Examples:
 
 		// Rich display
 		fmt.Printf("%s %s\n",
-			color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier),
-			color.New(color.FgWhite, color.Bold).Sprint(issue.Title))
+			output.CyanBold.Sprint(issue.Identifier),
+			output.WhiteBold.Sprint(issue.Title))
 
 		// Delegate info
 		if issue.Delegate != nil {
 			fmt.Printf("\n%s %s\n",
-				color.New(color.FgYellow).Sprint("Delegate:"),
-				color.New(color.FgCyan).Sprint(issue.Delegate.DisplayName))
+				output.Yellow.Sprint("Delegate:"),
+				output.Cyan.Sprint(issue.Delegate.DisplayName))
 		}
 
 		if session == nil {
-			fmt.Printf("\n%s\n", color.New(color.FgWhite, color.Faint).Sprint("Delegated but no session started yet"))
+			fmt.Printf("\n%s\n", output.Faint.Sprint("Delegated but no session started yet"))
 			return
 		}
 
 		// Status with color
-		statusColor := color.New(color.FgWhite)
+		statusColor := output.White
 		switch session.Status {
 		case "active":
-			statusColor = color.New(color.FgGreen)
+			statusColor = output.Green
 		case "complete":
-			statusColor = color.New(color.FgBlue)
+			statusColor = output.Blue
 		case "awaitingInput":
-			statusColor = color.New(color.FgYellow)
+			statusColor = output.Yellow
 		case "error":
-			statusColor = color.New(color.FgRed)
+			statusColor = output.Red
 		case "pending":
-			statusColor = color.New(color.FgMagenta)
+			statusColor = output.Magenta
 		}
 
 		fmt.Printf("%s %s\n",
-			color.New(color.FgYellow).Sprint("Status:"),
+			output.Yellow.Sprint("Status:"),
 			statusColor.Sprint(session.Status))
 
 		if session.AppUser != nil {
 			fmt.Printf("%s %s\n",
-				color.New(color.FgYellow).Sprint("Agent:"),
-				color.New(color.FgCyan).Sprint(session.AppUser.DisplayName))
+				output.Yellow.Sprint("Agent:"),
+				output.Cyan.Sprint(session.AppUser.DisplayName))
 		}
 
 		fmt.Printf("%s %s\n",
-			color.New(color.FgYellow).Sprint("Started:"),
+			output.Yellow.Sprint("Started:"),
 			session.CreatedAt.Format("2006-01-02 15:04:05"))
 
 		// Activity stream
 		if session.Activities != nil && len(session.Activities.Nodes) > 0 {
-			fmt.Printf("\n%s\n", color.New(color.FgYellow, color.Bold).Sprint("Activity Stream:"))
+			fmt.Printf("\n%s\n", output.YellowBold.Sprint("Activity Stream:"))
 
 			for _, activity := range session.Activities.Nodes {
 				activityType := "unknown"
@@ -212,19 +211,19 @@ Examples:
 				}
 
 				// Color by type
-				typeColor := color.New(color.FgWhite)
+				typeColor := output.White
 				switch activityType {
 				case "thought":
-					typeColor = color.New(color.FgMagenta)
+					typeColor = output.Magenta
 				case "response":
-					typeColor = color.New(color.FgGreen)
+					typeColor = output.Green
 				case "action":
-					typeColor = color.New(color.FgBlue)
+					typeColor = output.Blue
 				case "error":
-					typeColor = color.New(color.FgRed)
+					typeColor = output.Red
 				}
 
-				timestamp := color.New(color.FgWhite, color.Faint).Sprint(activity.CreatedAt.Format("15:04:05"))
+				timestamp := output.Faint.Sprint(activity.CreatedAt.Format("15:04:05"))
 				fmt.Printf("\n  %s [%s]\n", timestamp, typeColor.Sprint(activityType))
 
 				if body != "" {
@@ -240,10 +239,10 @@ Examples:
 
 			if session.Activities.PageInfo.HasNextPage {
 				fmt.Printf("\n%s More activities available\n",
-					color.New(color.FgYellow).Sprint("ℹ️"))
+					output.Yellow.Sprint(output.IconInfo))
 			}
 		} else {
-			fmt.Printf("\n%s\n", color.New(color.FgWhite, color.Faint).Sprint("No activities yet"))
+			fmt.Printf("\n%s\n", output.Faint.Sprint("No activities yet"))
 		}
 	},
 }
@@ -264,10 +263,10 @@ Examples:
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 		issueID := args[0]
 		message := args[1]
 
@@ -275,7 +274,7 @@ Examples:
 		issue, err := client.GetIssueAgentSession(context.Background(), issueID)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to fetch issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Find agent display name from delegate or existing session
@@ -293,14 +292,14 @@ Examples:
 
 		if agentDisplayName == "" {
 			output.Error(fmt.Sprintf("No agent found for %s", issueID), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitNotFound)
 		}
 
 		// @mention the agent to trigger them
 		commentID, err := client.MentionAgent(context.Background(), issue.ID, agentDisplayName, message)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to mention agent: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		if jsonOut {
@@ -314,7 +313,7 @@ Examples:
 			return
 		}
 
-		fmt.Printf("✓ @%s mentioned on %s\n", agentDisplayName, issue.Identifier)
+		fmt.Printf("%s @%s mentioned on %s\n", output.IconCheck, agentDisplayName, issue.Identifier)
 	},
 }
 
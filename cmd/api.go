@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charlietran/linctl/pkg/auth"
+	"github.com/charlietran/linctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var apiCmd = &cobra.Command{
+	Use:   "api [query | @file | -]",
+	Short: "Run a raw GraphQL query against the Linear API",
+	Long: `Run an arbitrary GraphQL query or mutation and print the response data as JSON.
+
+The query is read from the argument, from a file when the argument starts
+with @, or from stdin when the argument is - or omitted.
+
+Variables:
+  -f key=value    Add a string variable
+  -F key=value    Add a typed variable: true, false, null, integers, floats
+                  and JSON objects/arrays are decoded; @file reads the value
+                  from a file and @- from stdin
+
+With --paginate the query must declare $after: String and select
+pageInfo { hasNextPage endCursor } on one connection. linctl follows
+endCursor until the last page and merges the nodes into a single response.
+
+Examples:
+  linctl api '{ viewer { id name email } }'
+  linctl api @initiatives.graphql --paginate
+  linctl api 'query($id: String!) { document(id: $id) { title content } }' -f id=abc123
+  linctl api 'query($f: IssueFilter) { issues(filter: $f) { nodes { identifier } } }' -F f='{"priority":{"eq":1}}'
+  echo '{ organization { name } }' | linctl api`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		rawFields, _ := cmd.Flags().GetStringArray("raw-field")
+		typedFields, _ := cmd.Flags().GetStringArray("field")
+		paginate, _ := cmd.Flags().GetBool("paginate")
+
+		source := "-"
+		if len(args) == 1 {
+			source = args[0]
+		}
+		for _, field := range typedFields {
+			if source == "-" && strings.HasSuffix(field, "=@-") {
+				output.Error("stdin cannot supply both the query and a variable", plaintext, jsonOut)
+				os.Exit(exitValidation)
+			}
+		}
+
+		variables, err := parseAPIVariables(rawFields, typedFields, os.Stdin)
+		if err != nil {
+			output.Error(err.Error(), plaintext, jsonOut)
+			os.Exit(exitValidation)
+		}
+		query, err := readAPIQuery(source, os.Stdin)
+		if err != nil {
+			output.Error(err.Error(), plaintext, jsonOut)
+			os.Exit(exitValidation)
+		}
+
+		// Get auth header
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
+			os.Exit(exitAuth)
+		}
+
+		// Create API client
+		client := newClient(authHeader)
+
+		var data json.RawMessage
+		if paginate {
+			data, err = client.ExecutePaginated(context.Background(), query, variables)
+		} else {
+			data, err = client.ExecuteRaw(context.Background(), query, variables)
+		}
+		if err != nil {
+			output.Error(fmt.Sprintf("Query failed: %v", err), plaintext, jsonOut)
+			os.Exit(exitCodeForError(err))
+		}
+
+		output.RawJSON(data)
+	},
+}
+
+// readAPIQuery returns the GraphQL document named by source: inline text,
+// @path for a file, or - for stdin.
+func readAPIQuery(source string, stdin io.Reader) (string, error) {
+	var query string
+	switch {
+	case source == "-":
+		if f, ok := stdin.(*os.File); ok {
+			if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+				return "", fmt.Errorf("no query given; pass it as an argument, @file or on stdin")
+			}
+		}
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read query from stdin: %w", err)
+		}
+		query = string(data)
+	case strings.HasPrefix(source, "@"):
+		data, err := os.ReadFile(source[1:])
+		if err != nil {
+			return "", fmt.Errorf("failed to read query file: %w", err)
+		}
+		query = string(data)
+	default:
+		query = source
+	}
+
+	if strings.TrimSpace(query) == "" {
+		return "", fmt.Errorf("query is empty")
+	}
+	return query, nil
+}
+
+// parseAPIVariables builds the variables map from -f (string) and -F (typed) flags.
+func parseAPIVariables(rawFields, typedFields []string, stdin io.Reader) (map[string]interface{}, error) {
+	variables := map[string]interface{}{}
+
+	for _, field := range rawFields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid field %q: expected key=value", field)
+		}
+		variables[key] = value
+	}
+
+	for _, field := range typedFields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid field %q: expected key=value", field)
+		}
+		typed, err := parseTypedValue(value, stdin)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %q: %w", key, err)
+		}
+		variables[key] = typed
+	}
+
+	return variables, nil
+}
+
+// parseTypedValue converts a -F value to the JSON type it spells.
+func parseTypedValue(value string, stdin io.Reader) (interface{}, error) {
+	if path, ok := strings.CutPrefix(value, "@"); ok {
+		var data []byte
+		var err error
+		if path == "-" {
+			data, err = io.ReadAll(stdin)
+		} else {
+			data, err = os.ReadFile(path)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return strings.TrimRight(string(data), "\n"), nil
+	}
+
+	switch value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f, nil
+	}
+	if trimmed := strings.TrimSpace(value); strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(trimmed), &decoded); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		return decoded, nil
+	}
+	return value, nil
+}
+
+func init() {
+	rootCmd.AddCommand(apiCmd)
+
+	apiCmd.Flags().StringArrayP("raw-field", "f", nil, "Add a string variable in key=value format")
+	apiCmd.Flags().StringArrayP("field", "F", nil, "Add a typed variable in key=value format (@file reads a file)")
+	apiCmd.Flags().Bool("paginate", false, "Follow pageInfo.endCursor through $after and merge all pages")
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAPIVariables(t *testing.T) {
+	dir := t.TempDir()
+	bodyFile := filepath.Join(dir, "body.md")
+	if err := os.WriteFile(bodyFile, []byte("# Notes\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	vars, err := parseAPIVariables(
+		[]string{"id=123", "title=a=b"},
+		[]string{"first=50", "archived=true", "parent=null", "ratio=0.5", "filter={\"priority\":{\"eq\":1}}", "body=@" + bodyFile, "team=ENG", "text=@-"},
+		strings.NewReader("from stdin\n"),
+	)
+	if err != nil {
+		t.Fatalf("parseAPIVariables failed: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"id":       "123",
+		"title":    "a=b",
+		"first":    int64(50),
+		"archived": true,
+		"parent":   nil,
+		"ratio":    0.5,
+		"filter":   map[string]interface{}{"priority": map[string]interface{}{"eq": float64(1)}},
+		"body":     "# Notes",
+		"team":     "ENG",
+		"text":     "from stdin",
+	}
+	if !reflect.DeepEqual(vars, want) {
+		t.Errorf("Unexpected variables:\n got %#v\nwant %#v", vars, want)
+	}
+
+	for _, bad := range [][]string{{"novalue"}, {"=x"}} {
+		if _, err := parseAPIVariables(bad, nil, nil); err == nil {
+			t.Errorf("Expected error for -f %v", bad)
+		}
+	}
+	if _, err := parseAPIVariables(nil, []string{"filter={broken"}, nil); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestReadAPIQuery(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "q.graphql")
+	if err := os.WriteFile(file, []byte("{ viewer { id } }"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		source string
+		stdin  string
+		want   string
+	}{
+		{"{ organization { name } }", "", "{ organization { name } }"},
+		{"@" + file, "", "{ viewer { id } }"},
+		{"-", "{ teams { nodes { id } } }", "{ teams { nodes { id } } }"},
+	}
+	for _, tc := range cases {
+		got, err := readAPIQuery(tc.source, strings.NewReader(tc.stdin))
+		if err != nil {
+			t.Errorf("readAPIQuery(%q) failed: %v", tc.source, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("readAPIQuery(%q) = %q, want %q", tc.source, got, tc.want)
+		}
+	}
+
+	if _, err := readAPIQuery("-", strings.NewReader("  \n")); err == nil {
+		t.Error("Expected error for empty query")
+	}
+}
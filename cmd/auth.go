@@ -1,12 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/charlietran/linctl/pkg/auth"
 	"github.com/charlietran/linctl/pkg/output"
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -15,13 +15,17 @@ import (
 var authCmd = &cobra.Command{
 	Use:   "auth",
 	Short: "Authenticate with Linear",
-	Long: `Authenticate with Linear using Personal API Key.
+	Long: `Authenticate with Linear using a Personal API Key or OAuth2.
 
 Examples:
   linctl auth              # Interactive authentication
   linctl auth login        # Same as above
+  linctl auth login --oauth --client-id <id>  # Browser-based OAuth2 login
   linctl auth status       # Check authentication status
-  linctl auth logout       # Clear stored credentials`,
+  linctl auth logout       # Clear stored credentials
+  linctl auth login --profile acme  # Log in to another workspace
+  linctl auth switch acme  # Make a profile the default
+  linctl auth list         # Show stored profiles`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Default behavior is to run login
 		loginCmd.Run(cmd, args)
@@ -31,35 +35,101 @@ Examples:
 var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Login to Linear",
-	Long:  `Authenticate with Linear using Personal API Key.`,
+	Long: `Authenticate with Linear using a Personal API Key, or with OAuth2 when --oauth is set.
+
+The OAuth2 flow opens your browser, listens for the redirect on a loopback
+address (http://127.0.0.1:<port>/callback, which must be an allowed callback
+URL of your OAuth application) and uses PKCE. Access tokens are refreshed
+automatically. Client settings can also come from the config file
+(oauth.client_id, oauth.client_secret, oauth.authorize_url, oauth.token_url,
+oauth.scopes) or the LINCTL_OAUTH_CLIENT_ID and LINCTL_OAUTH_CLIENT_SECRET
+environment variables.
+
+With --credential-helper the key is handed to an external program instead of
+being written to ~/.linctl-auth.json, following git's credential-helper model:
+the helper is run with "get", "store" or "erase" as its last argument and
+receives protocol, host, profile (and password for store) as key=value lines
+on stdin. For "get" it prints either "password=<key>" or just the key. A
+helper named "foo" runs linctl-credential-foo from PATH; a path runs that
+program; a value starting with "!" runs through the shell. The helper can also
+be set with credential_helper in the config file or LINCTL_CREDENTIAL_HELPER.
+
+Examples:
+  linctl auth login                                  # Paste a Personal API Key
+  linctl auth login --credential-helper '!pass-linear'  # Keep the key in a secret store
+  linctl auth login --oauth --client-id abc123       # OAuth2 in the browser
+  linctl auth login --oauth --port 8765 --no-browser # Fixed callback port, print the URL`,
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
 
 		if !plaintext && !jsonOut {
-			fmt.Println(color.New(color.FgCyan, color.Bold).Sprint("🔐 Linear Authentication"))
+			fmt.Println(output.CyanBold.Sprint(output.IconKey.Prefix("Linear Authentication")))
 			fmt.Println()
 		}
 
-		err := auth.Login(plaintext, jsonOut)
+		var err error
+		if useOAuth, _ := cmd.Flags().GetBool("oauth"); useOAuth {
+			err = auth.LoginOAuth(context.Background(), oauthConfigFromFlags(cmd))
+		} else {
+			helper, _ := cmd.Flags().GetString("credential-helper")
+			if helper == "" {
+				helper = viper.GetString("credential_helper")
+			}
+			err = auth.Login(plaintext, jsonOut, helper, newClient)
+		}
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
+		profile, _ := auth.ActiveProfile()
 		if !plaintext && !jsonOut {
-			fmt.Println(color.New(color.FgGreen).Sprint("✅ Successfully authenticated with Linear!"))
+			fmt.Println(output.Green.Sprint(output.IconSuccess.Prefix("Successfully authenticated with Linear!")))
+			fmt.Printf("Profile: %s\n", output.Cyan.Sprint(profile))
 		} else if jsonOut {
 			output.JSON(map[string]interface{}{
 				"status":  "success",
 				"message": "Successfully authenticated with Linear",
+				"profile": profile,
 			})
 		} else {
-			fmt.Println("Successfully authenticated with Linear")
+			fmt.Printf("Successfully authenticated with Linear (profile: %s)\n", profile)
 		}
 	},
 }
 
+// oauthConfigFromFlags resolves OAuth settings: flags first, then the
+// oauth section of the config file, then environment variables.
+func oauthConfigFromFlags(cmd *cobra.Command) auth.OAuthConfig {
+	setting := func(flag, key, env string) string {
+		if value, _ := cmd.Flags().GetString(flag); value != "" {
+			return value
+		}
+		if value := viper.GetString(key); value != "" {
+			return value
+		}
+		return os.Getenv(env)
+	}
+
+	port, _ := cmd.Flags().GetInt("port")
+	noBrowser, _ := cmd.Flags().GetBool("no-browser")
+
+	cfg := auth.OAuthConfig{
+		ClientID:     setting("client-id", "oauth.client_id", "LINCTL_OAUTH_CLIENT_ID"),
+		ClientSecret: setting("client-secret", "oauth.client_secret", "LINCTL_OAUTH_CLIENT_SECRET"),
+		AuthorizeURL: setting("authorize-url", "oauth.authorize_url", "LINCTL_OAUTH_AUTHORIZE_URL"),
+		TokenURL:     setting("token-url", "oauth.token_url", "LINCTL_OAUTH_TOKEN_URL"),
+		Scopes:       setting("scopes", "oauth.scopes", "LINCTL_OAUTH_SCOPES"),
+		Port:         port,
+		Out:          os.Stderr,
+	}
+	if !noBrowser {
+		cfg.OpenBrowser = auth.OpenBrowser
+	}
+	return cfg
+}
+
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Check authentication status",
@@ -68,32 +138,43 @@ var statusCmd = &cobra.Command{
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
 
-		user, err := auth.GetCurrentUser()
+		profile, err := auth.ActiveProfile()
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to read auth profiles: %v", err), plaintext, jsonOut)
+			os.Exit(exitInternal)
+		}
+
+		user, err := auth.GetCurrentUser(newClient)
 		if err != nil {
 			if !plaintext && !jsonOut {
-				fmt.Println(color.New(color.FgRed).Sprint("❌ Not authenticated"))
+				fmt.Println(output.Red.Sprint(output.IconError.Prefix("Not authenticated")))
+				fmt.Printf("Profile: %s\n", output.Cyan.Sprint(profile))
 			} else if jsonOut {
 				output.JSON(map[string]interface{}{
 					"authenticated": false,
+					"profile":       profile,
 					"error":         err.Error(),
 				})
 			} else {
-				fmt.Println("Not authenticated")
+				fmt.Printf("Not authenticated (profile: %s)\n", profile)
 			}
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		if jsonOut {
 			output.JSON(map[string]interface{}{
 				"authenticated": true,
+				"profile":       profile,
 				"user":          user,
 			})
 		} else if plaintext {
 			fmt.Printf("Authenticated as: %s (%s)\n", user.Name, user.Email)
+			fmt.Printf("Profile: %s\n", profile)
 		} else {
-			fmt.Println(color.New(color.FgGreen).Sprint("✅ Authenticated"))
-			fmt.Printf("User: %s\n", color.New(color.FgCyan).Sprint(user.Name))
-			fmt.Printf("Email: %s\n", color.New(color.FgCyan).Sprint(user.Email))
+			fmt.Println(output.Green.Sprint(output.IconSuccess.Prefix("Authenticated")))
+			fmt.Printf("Profile: %s\n", output.Cyan.Sprint(profile))
+			fmt.Printf("User: %s\n", output.Cyan.Sprint(user.Name))
+			fmt.Printf("Email: %s\n", output.Cyan.Sprint(user.Email))
 		}
 	},
 }
@@ -101,7 +182,7 @@ var statusCmd = &cobra.Command{
 var logoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Logout from Linear",
-	Long:  `Clear stored Linear credentials.`,
+	Long:  `Clear the stored Linear credentials of the active profile.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
@@ -109,7 +190,7 @@ var logoutCmd = &cobra.Command{
 		err := auth.Logout()
 		if err != nil {
 			output.Error(fmt.Sprintf("Logout failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitInternal)
 		}
 
 		if jsonOut {
@@ -120,8 +201,86 @@ var logoutCmd = &cobra.Command{
 		} else if plaintext {
 			fmt.Println("Successfully logged out")
 		} else {
-			fmt.Println(color.New(color.FgGreen).Sprint("✅ Successfully logged out"))
+			fmt.Println(output.Green.Sprint(output.IconSuccess.Prefix("Successfully logged out")))
+		}
+	},
+}
+
+var switchCmd = &cobra.Command{
+	Use:   "switch PROFILE",
+	Short: "Switch the default auth profile",
+	Long: `Make PROFILE the profile used when neither --profile nor LINCTL_PROFILE is set.
+
+Examples:
+  linctl auth switch acme     # Use the acme workspace from now on
+  linctl auth switch default  # Go back to the original credentials`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+		profile := args[0]
+
+		if err := auth.SwitchProfile(profile); err != nil {
+			output.Error(fmt.Sprintf("Failed to switch profile: %v. Use 'linctl auth list' to see stored profiles.", err), plaintext, jsonOut)
+			os.Exit(exitNotFound)
 		}
+
+		output.Success(fmt.Sprintf("Switched to profile %s", profile), plaintext, jsonOut)
+	},
+}
+
+var listProfilesCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List auth profiles",
+	Long:    `List stored auth profiles and show which one is active.`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		profiles, err := auth.ListProfiles()
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to list profiles: %v", err), plaintext, jsonOut)
+			os.Exit(exitInternal)
+		}
+
+		if jsonOut {
+			output.JSON(profiles)
+			return
+		}
+
+		if len(profiles) == 0 {
+			if plaintext {
+				fmt.Println("No profiles found")
+			} else {
+				fmt.Printf("\n%s No profiles found. Run 'linctl auth login' to create one.\n", output.Yellow.Sprint(output.IconInfo))
+			}
+			return
+		}
+
+		if plaintext {
+			fmt.Println("Name\tMethod\tCurrent")
+			for _, p := range profiles {
+				fmt.Printf("%s\t%s\t%v\n", p.Name, p.Method, p.Current)
+			}
+			return
+		}
+
+		rows := [][]string{}
+		for _, p := range profiles {
+			name := p.Name
+			marker := ""
+			if p.Current {
+				name = output.CyanBold.Sprint(p.Name)
+				marker = output.Green.Sprint(output.IconCheck.Prefix("Active"))
+			}
+			rows = append(rows, []string{name, p.Method, marker})
+		}
+		output.Table(output.TableData{
+			Headers: []string{"Profile", "Method", "Status"},
+			Rows:    rows,
+		}, plaintext, jsonOut)
 	},
 }
 
@@ -139,6 +298,18 @@ func init() {
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(statusCmd)
 	authCmd.AddCommand(logoutCmd)
+	authCmd.AddCommand(switchCmd)
+	authCmd.AddCommand(listProfilesCmd)
+
+	loginCmd.Flags().String("credential-helper", "", "Store the API key with an external helper instead of the auth file")
+	loginCmd.Flags().Bool("oauth", false, "Authenticate with OAuth2 in the browser instead of an API key")
+	loginCmd.Flags().String("client-id", "", "OAuth client ID")
+	loginCmd.Flags().String("client-secret", "", "OAuth client secret (optional with PKCE)")
+	loginCmd.Flags().String("authorize-url", "", "OAuth authorize endpoint (default "+auth.DefaultAuthorizeURL+")")
+	loginCmd.Flags().String("token-url", "", "OAuth token endpoint (default "+auth.DefaultTokenURL+")")
+	loginCmd.Flags().String("scopes", "", "Comma-separated OAuth scopes (default "+auth.DefaultOAuthScopes+")")
+	loginCmd.Flags().Int("port", 0, "Port for the local OAuth callback server (default: random free port)")
+	loginCmd.Flags().Bool("no-browser", false, "Print the authorization URL instead of opening a browser")
 
 	// Add whoami as a top-level command too
 	rootCmd.AddCommand(whoamiCmd)
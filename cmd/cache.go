@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charlietran/linctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local lookup cache",
+	Long: `Manage the on-disk cache of teams, workflow states, labels, users and
+the organization.
+
+These lookups rarely change, so linctl keeps them for cache.ttl (default 1h)
+instead of fetching them on every run. Use --no-cache on any command to
+bypass the cache, or set cache.ttl to 0 to disable it.
+
+Examples:
+  linctl cache status    # Show cache location and entry counts
+  linctl cache clear     # Remove all cached lookups`,
+}
+
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show cache location and entry counts",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		cache, err := newCache()
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to open cache: %v", err), plaintext, jsonOut)
+			os.Exit(exitInternal)
+		}
+
+		status, err := cache.Status()
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to read cache: %v", err), plaintext, jsonOut)
+			os.Exit(exitInternal)
+		}
+
+		enabled := !viper.GetBool("no-cache") && status.TTL > 0
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{
+				"enabled": enabled,
+				"dir":     status.Dir,
+				"ttl":     status.TTL.String(),
+				"entries": status.Entries,
+				"fresh":   status.Fresh,
+				"expired": status.Expired,
+				"bytes":   status.Bytes,
+			})
+		} else if plaintext {
+			fmt.Printf("Enabled: %t\n", enabled)
+			fmt.Printf("Directory: %s\n", status.Dir)
+			fmt.Printf("TTL: %s\n", status.TTL)
+			fmt.Printf("Entries: %d (%d fresh, %d expired)\n", status.Entries, status.Fresh, status.Expired)
+			fmt.Printf("Size: %d bytes\n", status.Bytes)
+		} else {
+			fmt.Println()
+			fmt.Println(output.CyanBold.Sprint(output.IconCache.Prefix("Lookup Cache")))
+			fmt.Println(output.Rule(50))
+
+			state := output.Green.Sprint("enabled")
+			if !enabled {
+				state = output.Yellow.Sprint("disabled")
+			}
+			fmt.Printf("%s %s\n", output.Bold.Sprint("Status:"), state)
+			fmt.Printf("%s %s\n", output.Bold.Sprint("Directory:"), status.Dir)
+			fmt.Printf("%s %s\n", output.Bold.Sprint("TTL:"), status.TTL)
+			fmt.Printf("%s %d (%s, %s)\n",
+				output.Bold.Sprint("Entries:"),
+				status.Entries,
+				output.Green.Sprintf("%d fresh", status.Fresh),
+				output.Faint.Sprintf("%d expired", status.Expired))
+			fmt.Printf("%s %s\n", output.Bold.Sprint("Size:"), formatBytes(status.Bytes))
+			fmt.Println()
+		}
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached lookups",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		cache, err := newCache()
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to open cache: %v", err), plaintext, jsonOut)
+			os.Exit(exitInternal)
+		}
+
+		removed, err := cache.Clear()
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to clear cache: %v", err), plaintext, jsonOut)
+			os.Exit(exitInternal)
+		}
+
+		if jsonOut {
+			output.JSON(map[string]interface{}{
+				"status":  "success",
+				"removed": removed,
+			})
+		} else if plaintext {
+			fmt.Printf("Removed %d cache entries\n", removed)
+		} else {
+			fmt.Printf("%s Removed %d cache entries from %s\n",
+				output.Green.Sprint(output.IconSuccess), removed, cache.Dir())
+		}
+	},
+}
+
+// formatBytes renders a byte count with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheStatusCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charlietran/linctl/pkg/api"
+	"github.com/spf13/viper"
+)
+
+func TestCacheConfig(t *testing.T) {
+	dir := t.TempDir()
+	viper.Set("cache.dir", dir)
+	t.Cleanup(func() {
+		viper.Set("cache.dir", "")
+		viper.Set("cache.ttl", api.DefaultCacheTTL)
+	})
+
+	if got := cacheTTL(); got != api.DefaultCacheTTL {
+		t.Errorf("Expected default TTL %s, got %s", api.DefaultCacheTTL, got)
+	}
+
+	viper.Set("cache.ttl", "15m")
+	if got := cacheTTL(); got != 15*time.Minute {
+		t.Errorf("Expected configured TTL of 15m, got %s", got)
+	}
+
+	cache, err := newCache()
+	if err != nil {
+		t.Fatalf("newCache failed: %v", err)
+	}
+	if cache.Dir() != dir {
+		t.Errorf("Expected cache dir %s, got %s", dir, cache.Dir())
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := map[int64]string{
+		0:       "0 B",
+		1023:    "1023 B",
+		1024:    "1.0 KiB",
+		1536:    "1.5 KiB",
+		1048576: "1.0 MiB",
+	}
+	for n, want := range cases {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestCacheCommandExists(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"cache", "clear"})
+	if err != nil || cmd.Name() != "clear" {
+		t.Fatalf("Expected cache clear command, got %v (%v)", cmd, err)
+	}
+	if rootCmd.PersistentFlags().Lookup("no-cache") == nil {
+		t.Error("Expected global --no-cache flag")
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/charlietran/linctl/pkg/api"
+	"github.com/charlietran/linctl/pkg/output"
+	"github.com/spf13/viper"
+)
+
+// newClient creates an API client configured from the api section of the config file.
+func newClient(authHeader string) *api.Client {
+	endpoint := api.BaseURL
+	if configured := viper.GetString("api.endpoint"); configured != "" {
+		endpoint = configured
+	}
+	client := api.NewClientWithURL(endpoint, authHeader)
+
+	if viper.IsSet("api.timeout") {
+		if timeout := viper.GetDuration("api.timeout"); timeout > 0 {
+			client.SetTimeout(timeout)
+		}
+	}
+
+	policy := api.DefaultRetryPolicy()
+	if viper.IsSet("api.retries") {
+		policy.MaxRetries = viper.GetInt("api.retries")
+	}
+	if viper.IsSet("api.retry_base_delay") {
+		policy.BaseDelay = viper.GetDuration("api.retry_base_delay")
+	}
+	if viper.IsSet("api.retry_max_delay") {
+		policy.MaxDelay = viper.GetDuration("api.retry_max_delay")
+	}
+	client.SetRetryPolicy(policy)
+
+	cassette, err := newCassette()
+	if err != nil {
+		output.Error(err.Error(), viper.GetBool("plaintext"), viper.GetBool("json"))
+		os.Exit(exitValidation)
+	}
+	if cassette != nil {
+		client.SetTransport(cassette)
+	}
+
+	// Cached lookups never reach the transport, so cassettes bypass the cache
+	if !viper.GetBool("no-cache") && cassette == nil {
+		if cache, err := newCache(); err == nil && cacheTTL() > 0 {
+			client.SetCache(cache)
+		}
+	}
+
+	if tracer := newTracer(); tracer != nil {
+		client.SetTracer(tracer)
+	}
+
+	return client
+}
+
+var (
+	cassetteOnce   sync.Once
+	sharedCassette *api.Cassette
+	cassetteErr    error
+)
+
+// newCassette returns the cassette for --record or --replay, or nil when
+// neither is set. It is shared so replay consumes interactions in order
+// across every client in the process.
+func newCassette() (*api.Cassette, error) {
+	cassetteOnce.Do(func() {
+		record, replay := viper.GetString("record"), viper.GetString("replay")
+		switch {
+		case record != "" && replay != "":
+			cassetteErr = fmt.Errorf("--record and --replay cannot be used together")
+		case record != "":
+			sharedCassette, cassetteErr = api.NewCassette(record, api.CassetteRecord)
+		case replay != "":
+			sharedCassette, cassetteErr = api.NewCassette(replay, api.CassetteReplay)
+		}
+	})
+	return sharedCassette, cassetteErr
+}
+
+var (
+	tracerOnce   sync.Once
+	sharedTracer *api.Tracer
+)
+
+// newTracer returns the tracer for --debug and --trace-file, or nil when
+// neither is set. Every client in the process shares it so the trace file is
+// opened once.
+func newTracer() *api.Tracer {
+	tracerOnce.Do(func() {
+		var log, file io.Writer
+		if viper.GetBool("debug") {
+			log = os.Stderr
+		}
+		if path := viper.GetString("trace-file"); path != "" {
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not open trace file: %v\n", err)
+			} else {
+				file = f
+			}
+		}
+		if log != nil || file != nil {
+			sharedTracer = api.NewTracer(log, file)
+		}
+	})
+	return sharedTracer
+}
+
+// cacheTTL returns how long cached lookups stay fresh; zero disables the cache.
+func cacheTTL() time.Duration {
+	if viper.IsSet("cache.ttl") {
+		return viper.GetDuration("cache.ttl")
+	}
+	return api.DefaultCacheTTL
+}
+
+// newCache opens the lookup cache in cache.dir, or the user cache directory.
+func newCache() (*api.Cache, error) {
+	dir := viper.GetString("cache.dir")
+	if dir == "" {
+		var err error
+		if dir, err = api.DefaultCacheDir(); err != nil {
+			return nil, err
+		}
+	}
+	return api.NewCache(dir, cacheTTL()), nil
+}
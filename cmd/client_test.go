@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charlietran/linctl/pkg/api"
+	"github.com/spf13/viper"
+)
+
+func TestNewClientRetryConfig(t *testing.T) {
+	if got := newClient("test-key").RetryPolicy(); got != api.DefaultRetryPolicy() {
+		t.Errorf("Expected the default retry policy without config, got %+v", got)
+	}
+
+	viper.Set("api.retries", 7)
+	viper.Set("api.retry_base_delay", "250ms")
+	viper.Set("api.retry_max_delay", "1m")
+	t.Cleanup(func() {
+		for _, key := range []string{"api.retries", "api.retry_base_delay", "api.retry_max_delay"} {
+			viper.Set(key, nil)
+		}
+	})
+
+	want := api.RetryPolicy{MaxRetries: 7, BaseDelay: 250 * time.Millisecond, MaxDelay: time.Minute}
+	if got := newClient("test-key").RetryPolicy(); got != want {
+		t.Errorf("Expected retry policy %+v from config, got %+v", want, got)
+	}
+}
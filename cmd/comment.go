@@ -4,13 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/charlietran/linctl/pkg/api"
 	"github.com/charlietran/linctl/pkg/auth"
 	"github.com/charlietran/linctl/pkg/output"
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -47,14 +45,14 @@ var commentListCmd = &cobra.Command{
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		// Get limit
-		limit, _ := cmd.Flags().GetInt("limit")
+		limit := listLimit(cmd)
 
 		// Get sort option
 		sortBy, _ := cmd.Flags().GetString("sort")
@@ -70,15 +68,15 @@ var commentListCmd = &cobra.Command{
 				orderBy = ""
 			default:
 				output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 		}
 
 		// Get comments
-		comments, err := client.GetIssueComments(context.Background(), issueID, limit, "", orderBy)
+		comments, err := client.GetIssueCommentsPaginated(context.Background(), issueID, limit, orderBy)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to list comments: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Filter by resolution status if requested.
@@ -88,7 +86,7 @@ var commentListCmd = &cobra.Command{
 			comments.Nodes = filterCommentsByResolution(comments.Nodes, resolvedFilter)
 		default:
 			output.Error(fmt.Sprintf("Invalid resolved option: %s. Valid options are: all, resolved, unresolved", resolvedFilter), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 
 		// Filter out child comments if --no-children flag is set.
@@ -106,6 +104,7 @@ var commentListCmd = &cobra.Command{
 			}
 			comments.Nodes = rootComments
 		}
+		startPager()
 
 		// Handle output
 		if jsonOut {
@@ -127,38 +126,38 @@ var commentListCmd = &cobra.Command{
 			// Rich display
 			if len(comments.Nodes) == 0 {
 				fmt.Printf("\n%s No comments on issue %s\n",
-					color.New(color.FgYellow).Sprint("ℹ️"),
-					color.New(color.FgCyan).Sprint(issueID))
+					output.Yellow.Sprint(output.IconInfo),
+					output.Cyan.Sprint(issueID))
 				return
 			}
 
 			fmt.Printf("\n%s Comments on %s (%d)\n\n",
-				color.New(color.FgCyan, color.Bold).Sprint("💬"),
-				color.New(color.FgCyan).Sprint(issueID),
+				output.CyanBold.Sprint(output.IconComment),
+				output.Cyan.Sprint(issueID),
 				len(comments.Nodes))
 
 			for i, comment := range comments.Nodes {
 				if i > 0 {
-					fmt.Println(strings.Repeat("─", 50))
+					fmt.Println(output.Rule(50))
 				}
 
 				// Header with author and time
 				timeAgo := formatTimeAgo(comment.CreatedAt)
 				if isCommentResolved(&comment) {
 					fmt.Printf("%s %s %s %s\n",
-						color.New(color.FgCyan, color.Bold).Sprint(commentAuthorName(&comment)),
-						color.New(color.FgWhite, color.Faint).Sprint("•"),
-						color.New(color.FgWhite, color.Faint).Sprint(timeAgo),
-						color.New(color.FgYellow, color.Bold).Sprint("[RESOLVED]"))
+						output.CyanBold.Sprint(commentAuthorName(&comment)),
+						output.Faint.Sprint(output.IconBullet),
+						output.Faint.Sprint(timeAgo),
+						output.YellowBold.Sprint("[RESOLVED]"))
 				} else {
 					fmt.Printf("%s %s %s\n",
-						color.New(color.FgCyan, color.Bold).Sprint(commentAuthorName(&comment)),
-						color.New(color.FgWhite, color.Faint).Sprint("•"),
-						color.New(color.FgWhite, color.Faint).Sprint(timeAgo))
+						output.CyanBold.Sprint(commentAuthorName(&comment)),
+						output.Faint.Sprint(output.IconBullet),
+						output.Faint.Sprint(timeAgo))
 				}
 
 				// Comment body
-				fmt.Printf("\n%s\n\n", comment.Body)
+				fmt.Printf("\n%s\n\n", output.Markdown(comment.Body))
 			}
 		}
 	},
@@ -185,17 +184,17 @@ Examples:
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		// Get comment body
 		body, _ := cmd.Flags().GetString("body")
 		if body == "" {
 			output.Error("Comment body is required (--body)", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 
 		// Get optional parent ID for threaded replies
@@ -205,7 +204,7 @@ Examples:
 		comment, err := client.CreateComment(context.Background(), issueID, body, parentID)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to create comment: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Handle output
@@ -222,14 +221,14 @@ Examples:
 		} else {
 			if comment.Parent != nil && comment.Parent.ID != "" {
 				fmt.Printf("%s Added reply to comment on %s\n",
-					color.New(color.FgGreen).Sprint("✓"),
-					color.New(color.FgCyan, color.Bold).Sprint(issueID))
+					output.Green.Sprint(output.IconCheck),
+					output.CyanBold.Sprint(issueID))
 			} else {
 				fmt.Printf("%s Added comment to %s\n",
-					color.New(color.FgGreen).Sprint("✓"),
-					color.New(color.FgCyan, color.Bold).Sprint(issueID))
+					output.Green.Sprint(output.IconCheck),
+					output.CyanBold.Sprint(issueID))
 			}
-			fmt.Printf("ID: %s\n", color.New(color.FgWhite, color.Faint).Sprint(comment.ID))
+			fmt.Printf("ID: %s\n", output.Faint.Sprint(comment.ID))
 			fmt.Printf("\n%s\n", comment.Body)
 		}
 	},
@@ -250,16 +249,16 @@ var commentDeleteCmd = &cobra.Command{
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		err = client.DeleteComment(context.Background(), commentID)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to delete comment: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Handle output
@@ -273,8 +272,8 @@ var commentDeleteCmd = &cobra.Command{
 			fmt.Printf("Deleted comment %s\n", commentID)
 		} else {
 			fmt.Printf("%s Deleted comment %s\n",
-				color.New(color.FgGreen).Sprint("✓"),
-				color.New(color.FgCyan).Sprint(commentID))
+				output.Green.Sprint(output.IconCheck),
+				output.Cyan.Sprint(commentID))
 		}
 	},
 }
@@ -293,16 +292,16 @@ var commentResolveCmd = &cobra.Command{
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		comment, err := client.ResolveComment(context.Background(), commentID)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to resolve comment: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Handle output
@@ -315,11 +314,11 @@ var commentResolveCmd = &cobra.Command{
 			}
 		} else {
 			fmt.Printf("%s Resolved comment %s\n",
-				color.New(color.FgGreen).Sprint("✓"),
-				color.New(color.FgCyan).Sprint(commentID))
+				output.Green.Sprint(output.IconCheck),
+				output.Cyan.Sprint(commentID))
 			if comment.ResolvingUser != nil {
 				fmt.Printf("Resolved by: %s\n",
-					color.New(color.FgWhite, color.Faint).Sprint(comment.ResolvingUser.Name))
+					output.Faint.Sprint(comment.ResolvingUser.Name))
 			}
 		}
 	},
@@ -339,16 +338,16 @@ var commentUnresolveCmd = &cobra.Command{
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		comment, err := client.UnresolveComment(context.Background(), commentID)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to unresolve comment: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Handle output
@@ -358,8 +357,8 @@ var commentUnresolveCmd = &cobra.Command{
 			fmt.Printf("Unresolved comment %s\n", commentID)
 		} else {
 			fmt.Printf("%s Unresolved comment %s\n",
-				color.New(color.FgGreen).Sprint("✓"),
-				color.New(color.FgCyan).Sprint(commentID))
+				output.Green.Sprint(output.IconCheck),
+				output.Cyan.Sprint(commentID))
 		}
 	},
 }
@@ -435,6 +434,7 @@ func init() {
 
 	// List command flags
 	commentListCmd.Flags().IntP("limit", "l", 50, "Maximum number of comments to return")
+	commentListCmd.Flags().Bool("all", false, "Fetch every comment (ignores --limit)")
 	commentListCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
 	commentListCmd.Flags().String("resolved", "all", "Resolution filter: all (default), resolved, unresolved")
 	commentListCmd.Flags().Bool("no-children", false, "Only show root comments (skip comments that have a parent)")
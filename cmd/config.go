@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charlietran/linctl/pkg/output"
+	"github.com/charlietran/linctl/pkg/query"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileName is the name of both the user config in $HOME and the
+// project configs found by walking up from the current directory.
+const configFileName = ".linctl.yaml"
+
+// defaultFlags are the command flags that the "defaults" section of a config
+// file can set. "format" is not listed: it is a global flag (see initOutputFormat).
+var defaultFlags = []string{"team", "project", "assignee", "newer-than", "sort"}
+
+// defaultOpposites are flags that contradict a default, such as --unassigned
+// for "assignee"; giving one drops the default instead of failing.
+var defaultOpposites = map[string]string{"assignee": "unassigned", "project": "no-project"}
+
+// defaultFilterFields are the --filter fields that take the place of a
+// default, so "team:WEB" searches WEB instead of WEB and the default team.
+var defaultFilterFields = map[string]string{
+	"team":       "team",
+	"project":    "project",
+	"assignee":   "assignee",
+	"newer-than": "created",
+}
+
+// configFiles lists the config files that were read, in the order they were
+// merged, for the "Using config file" notice.
+var configFiles []string
+
+// findProjectConfigs returns the .linctl.yaml files in dir and its parents,
+// outermost first so that the closest file wins when they are merged. The
+// search stops before stop (the home directory, whose config is the user
+// config) and at the filesystem root.
+func findProjectConfigs(dir, stop string) []string {
+	var found []string
+	for {
+		if stop != "" && dir == stop {
+			break
+		}
+		path := filepath.Join(dir, configFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			found = append([]string{path}, found...)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return found
+}
+
+// mergeProjectConfig merges the "defaults" section of a project config into
+// viper. Anything else in the file is ignored, so a repository can never
+// change where requests and credentials go.
+func mergeProjectConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return err
+	}
+	defaults, ok := config["defaults"]
+	if !ok {
+		return nil
+	}
+	if _, ok := defaults.(map[string]interface{}); !ok {
+		return fmt.Errorf("defaults must be a mapping")
+	}
+	return viper.MergeConfigMap(map[string]interface{}{"defaults": defaults})
+}
+
+// applyConfigDefaults sets the flags of cmd that were not given on the command
+// line from the "defaults" config section. For issue create, "assignee: me"
+// turns on --assign-me. Passing a flag explicitly, even as --team "", always
+// overrides the default, as do its opposite flag and a --filter term on the
+// same field.
+func applyConfigDefaults(cmd *cobra.Command) error {
+	// An invalid filter is left for the command to report
+	var expr *query.Query
+	if f := cmd.Flags().Lookup("filter"); f != nil && strings.TrimSpace(f.Value.String()) != "" {
+		expr, _ = query.Parse(f.Value.String())
+	}
+
+	for _, name := range defaultFlags {
+		value := strings.TrimSpace(viper.GetString("defaults." + name))
+		if value == "" {
+			continue
+		}
+		if opposite, ok := defaultOpposites[name]; ok && cmd.Flags().Changed(opposite) {
+			continue
+		}
+		if field, ok := defaultFilterFields[name]; ok && expr != nil && expr.Uses(field) {
+			continue
+		}
+		if name == "assignee" && cmd.Flags().Lookup(name) == nil {
+			if value == "me" && cmd.Flags().Lookup("assign-me") != nil && !cmd.Flags().Changed("assign-me") {
+				_ = cmd.Flags().Set("assign-me", "true")
+			}
+			continue
+		}
+		if cmd.Flags().Lookup(name) == nil || cmd.Flags().Changed(name) {
+			continue
+		}
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("invalid defaults.%s %q in config: %v", name, value, err)
+		}
+	}
+	return nil
+}
+
+// mustApplyConfigDefaults applies the config defaults or exits with a
+// validation error.
+func mustApplyConfigDefaults(cmd *cobra.Command, plaintext, jsonOut bool) {
+	if err := applyConfigDefaults(cmd); err != nil {
+		output.Error(err.Error(), plaintext, jsonOut)
+		os.Exit(exitValidation)
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func writeConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, configFileName)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFindProjectConfigs(t *testing.T) {
+	root := t.TempDir()
+	home := filepath.Join(root, "home")
+	repo := filepath.Join(home, "src", "monorepo")
+	service := filepath.Join(repo, "services", "billing")
+
+	writeConfig(t, home, "defaults: {team: HOME}")
+	repoConfig := writeConfig(t, repo, "defaults: {team: ENG}")
+	serviceConfig := writeConfig(t, service, "defaults: {team: BILL}")
+
+	got := findProjectConfigs(filepath.Join(service, "internal"), home)
+	want := []string{repoConfig, serviceConfig}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findProjectConfigs = %v, want %v", got, want)
+	}
+
+	if got := findProjectConfigs(home, home); len(got) != 0 {
+		t.Errorf("Expected the home config to be left to viper, got %v", got)
+	}
+}
+
+func TestMergeProjectConfigOnlyReadsDefaults(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	dir := t.TempDir()
+	outer := writeConfig(t, dir, "defaults:\n  team: ENG\n  sort: updated\n")
+	inner := writeConfig(t, filepath.Join(dir, "web"), "defaults:\n  team: WEB\napi:\n  endpoint: https://evil.example.com/graphql\n")
+
+	for _, path := range []string{outer, inner} {
+		if err := mergeProjectConfig(path); err != nil {
+			t.Fatalf("mergeProjectConfig(%s) failed: %v", path, err)
+		}
+	}
+	if got := viper.GetString("defaults.team"); got != "WEB" {
+		t.Errorf("defaults.team = %q, want the closest file to win", got)
+	}
+	if got := viper.GetString("defaults.sort"); got != "updated" {
+		t.Errorf("defaults.sort = %q, want it kept from the outer file", got)
+	}
+	if viper.IsSet("api.endpoint") {
+		t.Error("Project configs must not set the API endpoint")
+	}
+
+	bad := writeConfig(t, filepath.Join(dir, "bad"), "defaults: [team]\n")
+	if err := mergeProjectConfig(bad); err == nil {
+		t.Error("Expected an error for a defaults list")
+	}
+}
+
+func TestApplyConfigDefaults(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("defaults.team", "ENG")
+	viper.Set("defaults.sort", "updated")
+	viper.Set("defaults.assignee", "me")
+
+	list := &cobra.Command{Use: "list"}
+	list.Flags().String("team", "", "")
+	list.Flags().String("sort", "linear", "")
+	list.Flags().String("assignee", "", "")
+	_ = list.Flags().Parse([]string{"--sort", "created"})
+
+	if err := applyConfigDefaults(list); err != nil {
+		t.Fatalf("applyConfigDefaults failed: %v", err)
+	}
+	for flag, want := range map[string]string{"team": "ENG", "sort": "created", "assignee": "me"} {
+		if got, _ := list.Flags().GetString(flag); got != want {
+			t.Errorf("--%s = %q, want %q", flag, got, want)
+		}
+	}
+
+	create := &cobra.Command{Use: "create"}
+	create.Flags().String("team", "", "")
+	create.Flags().Bool("assign-me", false, "")
+	if err := applyConfigDefaults(create); err != nil {
+		t.Fatalf("applyConfigDefaults failed: %v", err)
+	}
+	if assignMe, _ := create.Flags().GetBool("assign-me"); !assignMe {
+		t.Error("Expected assignee: me to turn on --assign-me")
+	}
+
+	explicit := &cobra.Command{Use: "list"}
+	explicit.Flags().String("team", "", "")
+	_ = explicit.Flags().Parse([]string{"--team", ""})
+	_ = applyConfigDefaults(explicit)
+	if got, _ := explicit.Flags().GetString("team"); got != "" {
+		t.Errorf("Expected an explicit empty --team to override the default, got %q", got)
+	}
+}
+
+func TestApplyConfigDefaultsYieldsToOppositeFlagsAndFilter(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("defaults.assignee", "me")
+	viper.Set("defaults.project", "Q3 Launch")
+	viper.Set("defaults.team", "ENG")
+	viper.Set("defaults.newer-than", "3_months_ago")
+
+	tests := []struct {
+		args    []string
+		dropped []string
+	}{
+		{[]string{"--unassigned"}, []string{"assignee"}},
+		{[]string{"--no-project"}, []string{"project"}},
+		{[]string{"--filter", "team:WEB"}, []string{"team"}},
+		{[]string{"--filter", "assignee:none OR project:Billing"}, []string{"assignee", "project"}},
+		{[]string{"--filter", "created>2024-01-01"}, []string{"newer-than"}},
+		{[]string{"--filter", "label:bug"}, nil},
+	}
+	for _, tt := range tests {
+		list := newIssueFilterCmd(t, tt.args...)
+		if err := applyConfigDefaults(list); err != nil {
+			t.Fatalf("%v: applyConfigDefaults failed: %v", tt.args, err)
+		}
+		for _, name := range []string{"assignee", "project", "team", "newer-than"} {
+			dropped := false
+			for _, d := range tt.dropped {
+				dropped = dropped || d == name
+			}
+			if list.Flags().Changed(name) == dropped {
+				t.Errorf("%v: --%s set = %v, want %v", tt.args, name, list.Flags().Changed(name), !dropped)
+			}
+		}
+	}
+}
+
+func TestIssueCreateUsesTeamDefault(t *testing.T) {
+	var created map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch {
+		case strings.Contains(req.Query, "mutation CreateIssue"):
+			created, _ = req.Variables["input"].(map[string]interface{})
+			_, _ = w.Write([]byte(`{"data":{"issueCreate":{"issue":{"id":"issue-1","identifier":"ENG-1","title":"hi"}}}}`))
+		case strings.Contains(req.Query, "query Team("):
+			if req.Variables["key"] != "ENG" {
+				t.Errorf("Expected the default team ENG, got %v", req.Variables["key"])
+			}
+			_, _ = w.Write([]byte(`{"data":{"team":{"id":"team-eng","key":"ENG"}}}`))
+		default:
+			t.Errorf("Unexpected request: %s", req.Query)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	writeConfig(t, dir, "defaults:\n  team: ENG\n")
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("LINEAR_API_KEY", "test-key")
+	viper.Reset()
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+		viper.Reset()
+		rootCmd.SetArgs(nil)
+		for _, name := range []string{"title", "json", "no-cache"} {
+			if flag := issueCreateCmd.Flags().Lookup(name); flag != nil {
+				_ = flag.Value.Set(flag.DefValue)
+				flag.Changed = false
+			}
+		}
+	})
+	viper.Set("api.endpoint", srv.URL)
+
+	rootCmd.SetArgs([]string{"issue", "create", "--title", "hi", "--json", "--no-cache"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("issue create failed: %v", err)
+	}
+	if created["teamId"] != "team-eng" {
+		t.Errorf("Expected the issue to be created in the default team, got %v", created)
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charlietran/linctl/pkg/output"
+)
+
+// Injection points for testing
+var confirmInput io.Reader = os.Stdin
+var inputIsTerminal = output.IsInputTerminal
+
+// errNoConfirmation is returned by confirm when there is nobody to ask.
+var errNoConfirmation = errors.New("stdin is not a terminal; pass --yes to confirm")
+
+// confirm asks a yes/no question on stderr and reads the answer from stdin.
+// Anything but "y" or "yes" is a no. When stdin is not a terminal it returns
+// errNoConfirmation instead of guessing, so scripts must pass --yes.
+func confirm(question string) (bool, error) {
+	if !inputIsTerminal() {
+		return false, errNoConfirmation
+	}
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(confirmInput).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(os.Stderr)
+		return false, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
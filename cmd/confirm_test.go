@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func withConfirmInput(t *testing.T, input string, terminal bool, fn func()) {
+	t.Helper()
+	oldInput, oldTerminal := confirmInput, inputIsTerminal
+	confirmInput = strings.NewReader(input)
+	inputIsTerminal = func() bool { return terminal }
+	defer func() { confirmInput, inputIsTerminal = oldInput, oldTerminal }()
+	fn()
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{" yes \n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"yep\n", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		withConfirmInput(t, tt.input, true, func() {
+			got, err := confirm("Archive ENG-1?")
+			if err != nil {
+				t.Fatalf("confirm(%q) failed: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("confirm(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmWithoutTerminal(t *testing.T) {
+	withConfirmInput(t, "y\n", false, func() {
+		ok, err := confirm("Archive ENG-1?")
+		if ok || !errors.Is(err, errNoConfirmation) {
+			t.Errorf("confirm() = %v, %v; want errNoConfirmation", ok, err)
+		}
+	})
+}
@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/charlietran/linctl/pkg/output"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var readmeContents string
@@ -19,15 +21,24 @@ var docsCmd = &cobra.Command{
 	Short: "Display the linctl documentation",
 	Long: `Display the complete linctl documentation from README.md.
 
-This command outputs the full documentation in markdown format,
-which can be piped to other tools or saved to a file.
+In a terminal the markdown is rendered with headings, lists and code
+blocks styled and wrapped to the terminal width. When piped, or with
+--plaintext, the raw markdown is printed so it can be saved or processed.
 
 Examples:
   linctl docs                    # Display documentation
-  linctl docs | less            # View with pager
+  linctl docs --no-pager        # Print without the pager
   linctl docs > linctl-docs.md  # Save to file`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Print(readmeContents)
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		if plaintext || jsonOut || !output.IsTerminal() {
+			fmt.Print(readmeContents)
+			return
+		}
+		startPager()
+		fmt.Println(output.Markdown(readmeContents))
 	},
 }
 
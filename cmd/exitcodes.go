@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/charlietran/linctl/pkg/api"
+	"github.com/charlietran/linctl/pkg/auth"
+)
+
+// Exit codes returned by every command, so scripts can branch on the kind of
+// failure without parsing stderr. They are documented in the README.
+const (
+	exitOK         = 0
+	exitInternal   = 1 // unexpected failure, or an API error that fits no other category
+	exitValidation = 2 // invalid arguments, flags or input rejected by the API
+	exitAuth       = 3 // missing, invalid or insufficient credentials
+	exitNotFound   = 4 // the requested issue, project, team, user, ... does not exist
+	exitRateLimit  = 5 // Linear's rate limit was exceeded
+	exitNetwork    = 6 // the API could not be reached
+)
+
+// exitCodeError is an error raised by a command itself that carries the exit
+// code it should end with.
+type exitCodeError struct {
+	code int
+	msg  string
+}
+
+func (e *exitCodeError) Error() string { return e.msg }
+
+// exitCodeForError maps an error returned by the API or auth packages to an exit code.
+func exitCodeForError(err error) int {
+	var coded *exitCodeError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &coded):
+		return coded.code
+	case errors.Is(err, auth.ErrNotAuthenticated), api.IsAuthentication(err), api.IsForbidden(err):
+		return exitAuth
+	case api.IsRateLimited(err):
+		return exitRateLimit
+	case api.IsNotFound(err):
+		return exitNotFound
+	case api.IsValidation(err):
+		return exitValidation
+	case api.IsNetwork(err):
+		return exitNetwork
+	}
+	return exitInternal
+}
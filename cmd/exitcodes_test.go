@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charlietran/linctl/pkg/api"
+	"github.com/charlietran/linctl/pkg/auth"
+	"github.com/charlietran/linctl/pkg/schema"
+)
+
+func TestExitCodeForError(t *testing.T) {
+	gqlErr := func(code, message string) error {
+		return &api.GraphQLErrors{Errors: []api.GraphQLError{{
+			Message:    message,
+			Extensions: api.GraphQLErrorExtensions{Code: code},
+		}}}
+	}
+
+	cases := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, exitOK},
+		{"not authenticated", auth.ErrNotAuthenticated, exitAuth},
+		{"wrapped not authenticated", fmt.Errorf("%w: no valid authentication found", auth.ErrNotAuthenticated), exitAuth},
+		{"unauthorized status", &api.HTTPError{StatusCode: 401}, exitAuth},
+		{"forbidden", gqlErr("FORBIDDEN", "Forbidden"), exitAuth},
+		{"rate limited", gqlErr("RATELIMITED", "Rate limit exceeded"), exitRateLimit},
+		{"rate limited status", &api.HTTPError{StatusCode: 429}, exitRateLimit},
+		{"not found", gqlErr("INPUT_ERROR", "Entity not found: Issue"), exitNotFound},
+		{"validation", gqlErr("INVALID_INPUT", "Argument Validation Error"), exitValidation},
+		{"server error", &api.HTTPError{StatusCode: 500}, exitInternal},
+		{"unknown", errors.New("boom"), exitInternal},
+	}
+	for _, c := range cases {
+		if got := exitCodeForError(c.err); got != c.want {
+			t.Errorf("%s: exitCodeForError() = %d, want %d", c.name, got, c.want)
+		}
+	}
+}
+
+func TestSchemaLoadExitCode(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(corrupt, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"missing", filepath.Join(dir, "missing.json"), exitNotFound},
+		{"unreadable", dir, exitInternal},
+		{"corrupt", corrupt, exitValidation},
+	}
+	for _, c := range cases {
+		_, err := schema.Load(c.path)
+		if err == nil {
+			t.Fatalf("%s: expected schema.Load to fail", c.name)
+		}
+		if got := schemaLoadExitCode(err); got != c.want {
+			t.Errorf("%s: schemaLoadExitCode(%v) = %d, want %d", c.name, err, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charlietran/linctl/pkg/api"
+	"github.com/charlietran/linctl/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// selectedFields returns the fields requested with --fields, or nil when the
+// flag is not set and the command should fetch and print everything.
+func selectedFields(cmd *cobra.Command, set api.FieldSet) ([]string, error) {
+	spec, _ := cmd.Flags().GetString("fields")
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	return set.Parse(spec)
+}
+
+// renderFieldRecord prints one record fetched with --fields, a field per line
+// in the order they were requested.
+func renderFieldRecord(record map[string]interface{}, fields []string, plaintext, jsonOut bool) {
+	if jsonOut {
+		output.JSON(output.Record{Keys: fields, Values: record})
+		return
+	}
+	for _, field := range fields {
+		label := field + ":"
+		if !plaintext {
+			label = output.CyanBold.Sprint(label)
+		}
+		fmt.Printf("%s %s\n", label, output.DisplayValue(record[field]))
+	}
+}
+
+// renderFieldTable prints records fetched with --fields as a table with one
+// column per requested field.
+func renderFieldTable(records []map[string]interface{}, fields []string, plaintext, jsonOut bool) {
+	if jsonOut {
+		ordered := make([]output.Record, len(records))
+		for i, record := range records {
+			ordered[i] = output.Record{Keys: fields, Values: record}
+		}
+		output.JSON(ordered)
+		return
+	}
+	rows := make([][]string, len(records))
+	for i, record := range records {
+		row := make([]string, len(fields))
+		for j, field := range fields {
+			row[j] = output.DisplayValue(record[field])
+		}
+		rows[i] = row
+	}
+	output.Table(output.TableData{Headers: fields, Rows: rows}, plaintext, false)
+}
@@ -2,18 +2,22 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/charlietran/linctl/pkg/api"
 	"github.com/charlietran/linctl/pkg/auth"
 	"github.com/charlietran/linctl/pkg/output"
+	"github.com/charlietran/linctl/pkg/query"
 	"github.com/charlietran/linctl/pkg/utils"
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -26,11 +30,30 @@ func isProjectNotFoundErr(err error) bool {
 	if err == nil {
 		return false
 	}
+
+	var gqlErr *api.GraphQLErrors
+	if errors.As(err, &gqlErr) {
+		if !gqlErr.IsNotFound() {
+			return false
+		}
+		for _, e := range gqlErr.Errors {
+			if mentionsProject(e.Message) || mentionsProject(fmt.Sprint(e.Path...)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Errors that did not come from the API still have to be matched by message
 	e := strings.ToLower(err.Error())
 	if !strings.Contains(e, "not found") {
 		return false
 	}
-	return strings.Contains(e, "project") || strings.Contains(e, "projectid")
+	return mentionsProject(e)
+}
+
+func mentionsProject(s string) bool {
+	return strings.Contains(strings.ToLower(s), "project")
 }
 
 // buildProjectInput normalizes a --project flag value to a GraphQL input value.
@@ -53,6 +76,23 @@ func buildProjectInput(projectFlag string) (interface{}, bool, error) {
 	}
 }
 
+// resolveProjectID returns the ID of the project called name, ignoring case.
+// It fails when no project or more than one project has that name.
+func resolveProjectID(ctx context.Context, client *api.Client, name string) (string, error) {
+	filter := map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": name}}
+	projects, err := client.GetProjects(ctx, filter, 2, "", "")
+	if err != nil {
+		return "", err
+	}
+	switch len(projects.Nodes) {
+	case 0:
+		return "", &exitCodeError{code: exitNotFound, msg: fmt.Sprintf("project '%s' not found", name)}
+	case 1:
+		return projects.Nodes[0].ID, nil
+	}
+	return "", &exitCodeError{code: exitValidation, msg: fmt.Sprintf("several projects are named '%s'; use the project ID", name)}
+}
+
 // issueCmd represents the issue command
 var issueCmd = &cobra.Command{
 	Use:   "issue",
@@ -82,26 +122,35 @@ Examples:
   linctl issue list --state "In Progress" --team ENG
   linctl issue list --cycle current  # Filter by current active cycle
   linctl issue list --cycle 42  # Filter by specific cycle number
-  linctl issue list --priority 1 --cycle current  # Urgent issues in current cycle`,
+  linctl issue list --priority 1 --cycle current  # Urgent issues in current cycle
+  linctl issue list --team ENG --all  # Fetch every page of results
+  linctl issue list --state Todo --state "In Progress" --label bug  # Any of several values
+  linctl issue list --unassigned --no-project  # Issues nobody has picked up
+  linctl issue list --filter 'label:bug priority<=2 -state:Backlog'  # Combine conditions
+  linctl issue list --fields identifier,state,assignee --json  # Fetch only these fields`,
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
+		mustApplyConfigDefaults(cmd, plaintext, jsonOut)
+
+		fields, err := selectedFields(cmd, api.IssueFields)
+		if err != nil {
+			output.Error(err.Error(), plaintext, jsonOut)
+			os.Exit(exitValidation)
+		}
 
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		// Build filter from flags
 		filter := buildIssueFilter(cmd)
 
-		limit, _ := cmd.Flags().GetInt("limit")
-		if limit == 0 {
-			limit = 50
-		}
+		limit := listLimit(cmd)
 
 		// Get sort option
 		sortBy, _ := cmd.Flags().GetString("sort")
@@ -117,14 +166,29 @@ Examples:
 				orderBy = ""
 			default:
 				output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 		}
 
-		issues, err := client.GetIssues(context.Background(), filter, limit, "", orderBy)
+		if fields != nil {
+			records, _, err := client.GetIssuesFieldsPaginated(context.Background(), filter, limit, orderBy, fields)
+			if err != nil {
+				output.Error(fmt.Sprintf("Failed to fetch issues: %v", err), plaintext, jsonOut)
+				os.Exit(exitCodeForError(err))
+			}
+			if len(records) == 0 {
+				output.Info("No issues found", plaintext, jsonOut)
+				return
+			}
+			startPager()
+			renderFieldTable(records, fields, plaintext, jsonOut)
+			return
+		}
+
+		issues, err := client.GetIssuesPaginated(context.Background(), filter, limit, orderBy)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to fetch issues: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		renderIssueCollection(issues, plaintext, jsonOut, "No issues found", "issues", "# Issues")
@@ -136,6 +200,7 @@ func renderIssueCollection(issues *api.Issues, plaintext, jsonOut bool, emptyMes
 		output.Info(emptyMessage, plaintext, jsonOut)
 		return
 	}
+	startPager()
 
 	if jsonOut {
 		output.JSON(issues.Nodes)
@@ -195,7 +260,7 @@ func renderIssueCollection(issues *api.Issues, plaintext, jsonOut bool, emptyMes
 
 		project := ""
 		if issue.Project != nil {
-			project = truncateString(issue.Project.Name, 25)
+			project = output.Truncate(issue.Project.Name, 25)
 		}
 
 		cycle := "-"
@@ -210,32 +275,32 @@ func renderIssueCollection(issues *api.Issues, plaintext, jsonOut bool, emptyMes
 		state := ""
 		if issue.State != nil {
 			state = issue.State.Name
-			var stateColor *color.Color
+			var stateColor output.Style
 			switch issue.State.Type {
 			case "triage":
-				stateColor = color.New(color.FgMagenta)
+				stateColor = output.Magenta
 			case "backlog":
-				stateColor = color.New(color.FgCyan)
+				stateColor = output.Cyan
 			case "unstarted":
-				stateColor = color.New(color.FgWhite)
+				stateColor = output.White
 			case "started":
-				stateColor = color.New(color.FgBlue)
+				stateColor = output.Blue
 			case "completed":
-				stateColor = color.New(color.FgGreen)
+				stateColor = output.Green
 			case "canceled":
-				stateColor = color.New(color.FgRed)
+				stateColor = output.Red
 			default:
-				stateColor = color.New(color.FgWhite)
+				stateColor = output.White
 			}
 			state = stateColor.Sprint(state)
 		}
 
 		if issue.Assignee == nil {
-			assignee = color.New(color.FgYellow).Sprint(assignee)
+			assignee = output.Yellow.Sprint(assignee)
 		}
 
 		rows[i] = []string{
-			truncateString(issue.Title, 40),
+			issue.Title,
 			state,
 			assignee,
 			team,
@@ -249,18 +314,19 @@ func renderIssueCollection(issues *api.Issues, plaintext, jsonOut bool, emptyMes
 	tableData := output.TableData{
 		Headers: headers,
 		Rows:    rows,
+		Shrink:  "Title",
 	}
 
 	output.Table(tableData, false, false)
 
 	fmt.Printf("\n%s %d %s\n",
-		color.New(color.FgGreen).Sprint("✓"),
+		output.Green.Sprint(output.IconCheck),
 		len(issues.Nodes),
 		summaryLabel)
 
 	if issues.PageInfo.HasNextPage {
-		fmt.Printf("%s Use --limit to see more results\n",
-			color.New(color.FgYellow).Sprint("ℹ️"))
+		fmt.Printf("%s Use --limit or --all to see more results\n",
+			output.Yellow.Sprint(output.IconInfo))
 	}
 }
 
@@ -280,27 +346,25 @@ Examples:
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
+		mustApplyConfigDefaults(cmd, plaintext, jsonOut)
 
 		query := strings.TrimSpace(strings.Join(args, " "))
 		if query == "" {
 			output.Error("Search query is required", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		filter := buildIssueFilter(cmd)
 
-		limit, _ := cmd.Flags().GetInt("limit")
-		if limit == 0 {
-			limit = 50
-		}
+		limit := listLimit(cmd)
 
 		sortBy, _ := cmd.Flags().GetString("sort")
 		orderBy := ""
@@ -314,16 +378,16 @@ Examples:
 				orderBy = ""
 			default:
 				output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 		}
 
 		includeArchived, _ := cmd.Flags().GetBool("include-archived")
 
-		issues, err := client.IssueSearch(context.Background(), query, filter, limit, "", orderBy, includeArchived)
+		issues, err := client.IssueSearchPaginated(context.Background(), query, filter, limit, orderBy, includeArchived)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to search issues: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		emptyMsg := fmt.Sprintf("No matches found for %q", query)
@@ -335,24 +399,48 @@ var issueGetCmd = &cobra.Command{
 	Use:     "get [issue-id]",
 	Aliases: []string{"show"},
 	Short:   "Get issue details",
-	Long:    `Get detailed information about a specific issue.`,
-	Args:    cobra.ExactArgs(1),
+	Long: `Get detailed information about a specific issue.
+
+Use --fields to fetch and print only some fields, which makes the request
+much cheaper than fetching the full issue.
+
+Examples:
+  linctl issue get LIN-123
+  linctl issue get LIN-123 --fields identifier,state,assignee --json`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
 
+		fields, err := selectedFields(cmd, api.IssueFields)
+		if err != nil {
+			output.Error(err.Error(), plaintext, jsonOut)
+			os.Exit(exitValidation)
+		}
+
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
+
+		if fields != nil {
+			record, err := client.GetIssueFields(context.Background(), args[0], fields)
+			if err != nil {
+				output.Error(fmt.Sprintf("Failed to fetch issue: %v", err), plaintext, jsonOut)
+				os.Exit(exitCodeForError(err))
+			}
+			renderFieldRecord(record, fields, plaintext, jsonOut)
+			return
+		}
 		issue, err := client.GetIssue(context.Background(), args[0])
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to fetch issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
+		startPager()
 
 		if jsonOut {
 			output.JSON(issue)
@@ -595,26 +683,26 @@ var issueGetCmd = &cobra.Command{
 					changes := []string{}
 
 					if entry.FromState != nil && entry.ToState != nil {
-						changes = append(changes, fmt.Sprintf("State: %s → %s", entry.FromState.Name, entry.ToState.Name))
+						changes = append(changes, fmt.Sprintf("State: %s %s %s", entry.FromState.Name, output.IconArrow, entry.ToState.Name))
 					}
 					if entry.FromAssignee != nil && entry.ToAssignee != nil {
-						changes = append(changes, fmt.Sprintf("Assignee: %s → %s", entry.FromAssignee.Name, entry.ToAssignee.Name))
+						changes = append(changes, fmt.Sprintf("Assignee: %s %s %s", entry.FromAssignee.Name, output.IconArrow, entry.ToAssignee.Name))
 					} else if entry.FromAssignee != nil && entry.ToAssignee == nil {
 						changes = append(changes, fmt.Sprintf("Unassigned from %s", entry.FromAssignee.Name))
 					} else if entry.FromAssignee == nil && entry.ToAssignee != nil {
 						changes = append(changes, fmt.Sprintf("Assigned to %s", entry.ToAssignee.Name))
 					}
 					if entry.FromPriority != nil && entry.ToPriority != nil {
-						changes = append(changes, fmt.Sprintf("Priority: %s → %s", priorityToString(*entry.FromPriority), priorityToString(*entry.ToPriority)))
+						changes = append(changes, fmt.Sprintf("Priority: %s %s %s", priorityToString(*entry.FromPriority), output.IconArrow, priorityToString(*entry.ToPriority)))
 					}
 					if entry.FromTitle != nil && entry.ToTitle != nil {
-						changes = append(changes, fmt.Sprintf("Title: \"%s\" → \"%s\"", *entry.FromTitle, *entry.ToTitle))
+						changes = append(changes, fmt.Sprintf("Title: \"%s\" %s \"%s\"", *entry.FromTitle, output.IconArrow, *entry.ToTitle))
 					}
 					if entry.FromCycle != nil && entry.ToCycle != nil {
-						changes = append(changes, fmt.Sprintf("Cycle: %s → %s", entry.FromCycle.Name, entry.ToCycle.Name))
+						changes = append(changes, fmt.Sprintf("Cycle: %s %s %s", entry.FromCycle.Name, output.IconArrow, entry.ToCycle.Name))
 					}
 					if entry.FromProject != nil && entry.ToProject != nil {
-						changes = append(changes, fmt.Sprintf("Project: %s → %s", entry.FromProject.Name, entry.ToProject.Name))
+						changes = append(changes, fmt.Sprintf("Project: %s %s %s", entry.FromProject.Name, output.IconArrow, entry.ToProject.Name))
 					}
 					if len(entry.AddedLabelIds) > 0 {
 						changes = append(changes, fmt.Sprintf("Added %d label(s)", len(entry.AddedLabelIds)))
@@ -635,14 +723,14 @@ var issueGetCmd = &cobra.Command{
 
 		// Rich display
 		fmt.Printf("%s %s\n",
-			color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier),
-			color.New(color.FgWhite, color.Bold).Sprint(issue.Title))
+			output.CyanBold.Sprint(issue.Identifier),
+			output.WhiteBold.Sprint(issue.Title))
 
 		if issue.Description != "" {
-			fmt.Printf("\n%s\n", issue.Description)
+			fmt.Printf("\n%s\n", output.Markdown(issue.Description))
 		}
 
-		fmt.Printf("\n%s\n", color.New(color.FgYellow).Sprint("Details:"))
+		fmt.Printf("\n%s\n", output.Yellow.Sprint("Details:"))
 
 		if issue.State != nil {
 			stateStr := issue.State.Name
@@ -650,20 +738,20 @@ var issueGetCmd = &cobra.Command{
 				stateStr += fmt.Sprintf(" (%s)", issue.CompletedAt.Format("2006-01-02"))
 			}
 			fmt.Printf("State: %s\n",
-				color.New(color.FgGreen).Sprint(stateStr))
+				output.Green.Sprint(stateStr))
 		}
 
 		if issue.Assignee != nil {
 			fmt.Printf("Assignee: %s\n",
-				color.New(color.FgCyan).Sprint(issue.Assignee.Name))
+				output.Cyan.Sprint(issue.Assignee.Name))
 		} else {
 			fmt.Printf("Assignee: %s\n",
-				color.New(color.FgRed).Sprint("Unassigned"))
+				output.Red.Sprint("Unassigned"))
 		}
 
 		if issue.Team != nil {
 			fmt.Printf("Team: %s\n",
-				color.New(color.FgMagenta).Sprint(issue.Team.Name))
+				output.Magenta.Sprint(issue.Team.Name))
 		}
 
 		fmt.Printf("Priority: %s\n", priorityToString(issue.Priority))
@@ -671,13 +759,13 @@ var issueGetCmd = &cobra.Command{
 		// Show project and cycle info
 		if issue.Project != nil {
 			fmt.Printf("Project: %s (%s)\n",
-				color.New(color.FgBlue).Sprint(issue.Project.Name),
-				color.New(color.FgWhite, color.Faint).Sprintf("%.0f%%", issue.Project.Progress*100))
+				output.Blue.Sprint(issue.Project.Name),
+				output.Faint.Sprintf("%.0f%%", issue.Project.Progress*100))
 		}
 
 		if issue.Cycle != nil {
 			fmt.Printf("Cycle: %s\n",
-				color.New(color.FgMagenta).Sprint(issue.Cycle.Name))
+				output.Magenta.Sprint(issue.Cycle.Name))
 		}
 
 		fmt.Printf("Created: %s\n", issue.CreatedAt.Format("2006-01-02 15:04:05"))
@@ -685,47 +773,47 @@ var issueGetCmd = &cobra.Command{
 
 		if issue.DueDate != nil && *issue.DueDate != "" {
 			fmt.Printf("Due Date: %s\n",
-				color.New(color.FgYellow).Sprint(*issue.DueDate))
+				output.Yellow.Sprint(*issue.DueDate))
 		}
 
 		if issue.SnoozedUntilAt != nil {
 			fmt.Printf("Snoozed Until: %s\n",
-				color.New(color.FgYellow).Sprint(issue.SnoozedUntilAt.Format("2006-01-02 15:04:05")))
+				output.Yellow.Sprint(issue.SnoozedUntilAt.Format("2006-01-02 15:04:05")))
 		}
 
 		// Show git branch if available
 		if issue.BranchName != "" {
 			fmt.Printf("Git Branch: %s\n",
-				color.New(color.FgGreen).Sprint(issue.BranchName))
+				output.Green.Sprint(issue.BranchName))
 		}
 
 		// Show URL
 		if issue.URL != "" {
 			fmt.Printf("URL: %s\n",
-				color.New(color.FgBlue, color.Underline).Sprint(issue.URL))
+				output.Link.Sprint(issue.URL))
 		}
 
 		// Show parent issue if this is a sub-issue
 		if issue.Parent != nil {
-			fmt.Printf("\n%s\n", color.New(color.FgYellow).Sprint("Parent Issue:"))
+			fmt.Printf("\n%s\n", output.Yellow.Sprint("Parent Issue:"))
 			fmt.Printf("  %s %s\n",
-				color.New(color.FgCyan).Sprint(issue.Parent.Identifier),
+				output.Cyan.Sprint(issue.Parent.Identifier),
 				issue.Parent.Title)
 		}
 
 		// Show sub-issues if any
 		if issue.Children != nil && len(issue.Children.Nodes) > 0 {
-			fmt.Printf("\n%s\n", color.New(color.FgYellow).Sprint("Sub-issues:"))
+			fmt.Printf("\n%s\n", output.Yellow.Sprint("Sub-issues:"))
 			for _, child := range issue.Children.Nodes {
-				stateIcon := "○"
+				stateIcon := output.IconPending.String()
 				if child.State != nil {
 					switch child.State.Type {
 					case "completed", "done":
-						stateIcon = color.New(color.FgGreen).Sprint("✓")
+						stateIcon = output.Green.Sprint(output.IconCheck)
 					case "started", "in_progress":
-						stateIcon = color.New(color.FgBlue).Sprint("◐")
+						stateIcon = output.Blue.Sprint(output.IconInProgress)
 					case "canceled":
-						stateIcon = color.New(color.FgRed).Sprint("✗")
+						stateIcon = output.Red.Sprint(output.IconCross)
 					}
 				}
 
@@ -736,41 +824,39 @@ var issueGetCmd = &cobra.Command{
 
 				fmt.Printf("  %s %s %s (%s)\n",
 					stateIcon,
-					color.New(color.FgCyan).Sprint(child.Identifier),
+					output.Cyan.Sprint(child.Identifier),
 					child.Title,
-					color.New(color.FgWhite, color.Faint).Sprint(assignee))
+					output.Faint.Sprint(assignee))
 			}
 		}
 
 		// Show attachments if any
 		if issue.Attachments != nil && len(issue.Attachments.Nodes) > 0 {
-			fmt.Printf("\n%s\n", color.New(color.FgYellow).Sprint("Attachments:"))
+			fmt.Printf("\n%s\n", output.Yellow.Sprint("Attachments:"))
 			for _, attachment := range issue.Attachments.Nodes {
-				fmt.Printf("  📎 %s - %s\n",
+				fmt.Printf("  %s %s - %s\n",
+					output.IconAttachment,
 					attachment.Title,
-					color.New(color.FgBlue, color.Underline).Sprint(attachment.URL))
+					output.Link.Sprint(attachment.URL))
 			}
 		}
 
 		// Show recent comments if any
 		if issue.Comments != nil && len(issue.Comments.Nodes) > 0 {
-			fmt.Printf("\n%s\n", color.New(color.FgYellow).Sprint("Recent Comments:"))
+			fmt.Printf("\n%s\n", output.Yellow.Sprint("Recent Comments:"))
 			for _, comment := range issue.Comments.Nodes {
-				fmt.Printf("  💬 %s - %s\n",
-					color.New(color.FgCyan).Sprint(commentAuthorName(&comment)),
-					color.New(color.FgWhite, color.Faint).Sprint(comment.CreatedAt.Format("2006-01-02 15:04")))
+				fmt.Printf("  %s %s - %s\n",
+					output.IconComment,
+					output.Cyan.Sprint(commentAuthorName(&comment)),
+					output.Faint.Sprint(comment.CreatedAt.Format("2006-01-02 15:04")))
 				// Show first line of comment
 				lines := strings.Split(comment.Body, "\n")
 				if len(lines) > 0 && lines[0] != "" {
-					preview := lines[0]
-					if len(preview) > 60 {
-						preview = preview[:57] + "..."
-					}
-					fmt.Printf("     %s\n", preview)
+					fmt.Printf("     %s\n", output.Truncate(lines[0], 60))
 				}
 			}
 			fmt.Printf("\n  %s Use 'linctl comment list %s' to see all comments\n",
-				color.New(color.FgWhite, color.Faint).Sprint("→"),
+				output.Faint.Sprint(output.IconArrow),
 				issue.Identifier)
 		}
 	},
@@ -779,7 +865,29 @@ var issueGetCmd = &cobra.Command{
 func buildIssueFilter(cmd *cobra.Command) map[string]interface{} {
 	filter := make(map[string]interface{})
 
-	if assignee, _ := cmd.Flags().GetString("assignee"); assignee != "" {
+	var expr *query.Query
+	if src, _ := cmd.Flags().GetString("filter"); strings.TrimSpace(src) != "" {
+		var err error
+		expr, err = query.Parse(src)
+		if err != nil {
+			output.Error(fmt.Sprintf("Invalid --filter: %v", err), viper.GetBool("plaintext"), viper.GetBool("json"))
+			os.Exit(exitValidation)
+		}
+	}
+
+	// Conditions that don't fit under a single field key, such as "created by
+	// me or by ada@example.com", are ANDed in at the end.
+	var extra []interface{}
+
+	assignee, _ := cmd.Flags().GetString("assignee")
+	unassigned, _ := cmd.Flags().GetBool("unassigned")
+	if assignee != "" && unassigned {
+		output.Error("--assignee and --unassigned cannot be used together", viper.GetBool("plaintext"), viper.GetBool("json"))
+		os.Exit(exitValidation)
+	}
+	if unassigned {
+		filter["assignee"] = map[string]interface{}{"null": true}
+	} else if assignee != "" {
 		if assignee == "me" {
 			// We'll need to get the current user's ID
 			// For now, we'll use a special marker
@@ -789,10 +897,10 @@ func buildIssueFilter(cmd *cobra.Command) map[string]interface{} {
 		}
 	}
 
-	state, _ := cmd.Flags().GetString("state")
-	if state != "" {
-		filter["state"] = map[string]interface{}{"name": map[string]interface{}{"eq": state}}
-	} else {
+	states, _ := cmd.Flags().GetStringArray("state")
+	if len(states) > 0 {
+		filter["state"] = nameFilter(states)
+	} else if expr == nil || !expr.Uses("state") {
 		// Only filter out completed issues if no specific state is requested
 		includeCompleted, _ := cmd.Flags().GetBool("include-completed")
 		if !includeCompleted {
@@ -805,8 +913,80 @@ func buildIssueFilter(cmd *cobra.Command) map[string]interface{} {
 		}
 	}
 
-	if team, _ := cmd.Flags().GetString("team"); team != "" {
-		filter["team"] = map[string]interface{}{"key": map[string]interface{}{"eq": team}}
+	if teams, _ := cmd.Flags().GetStringArray("team"); len(teams) > 0 {
+		filter["team"] = map[string]interface{}{"key": eqOrIn(teams)}
+	}
+
+	if labels, _ := cmd.Flags().GetStringArray("label"); len(labels) > 0 {
+		// Matches issues with any of the labels
+		filter["labels"] = map[string]interface{}{"some": nameFilter(labels)}
+	}
+
+	projects, _ := cmd.Flags().GetStringArray("project")
+	noProject, _ := cmd.Flags().GetBool("no-project")
+	if len(projects) > 0 && noProject {
+		output.Error("--project and --no-project cannot be used together", viper.GetBool("plaintext"), viper.GetBool("json"))
+		os.Exit(exitValidation)
+	}
+	if noProject {
+		filter["project"] = map[string]interface{}{"null": true}
+	} else if len(projects) > 0 {
+		var ids, names []string
+		for _, project := range projects {
+			if isValidUUID(project) {
+				ids = append(ids, project)
+			} else {
+				names = append(names, project)
+			}
+		}
+		switch {
+		case len(names) == 0:
+			filter["project"] = map[string]interface{}{"id": eqOrIn(ids)}
+		case len(ids) == 0:
+			filter["project"] = nameFilter(names)
+		default:
+			filter["project"] = map[string]interface{}{"or": []interface{}{
+				map[string]interface{}{"id": eqOrIn(ids)},
+				nameFilter(names),
+			}}
+		}
+	}
+
+	if creators, _ := cmd.Flags().GetStringArray("creator"); len(creators) > 0 {
+		var emails []string
+		var matches []interface{}
+		for _, creator := range creators {
+			if creator == "me" {
+				matches = append(matches, map[string]interface{}{"creator": map[string]interface{}{"isMe": map[string]interface{}{"eq": true}}})
+			} else {
+				emails = append(emails, creator)
+			}
+		}
+		if len(emails) > 0 {
+			matches = append(matches, map[string]interface{}{"creator": map[string]interface{}{"email": eqOrIn(emails)}})
+		}
+		if len(matches) == 1 {
+			filter["creator"] = matches[0].(map[string]interface{})["creator"]
+		} else {
+			extra = append(extra, map[string]interface{}{"or": matches})
+		}
+	}
+
+	if hasParent, _ := cmd.Flags().GetBool("has-parent"); hasParent {
+		filter["parent"] = map[string]interface{}{"null": false}
+	}
+
+	if dueBefore, _ := cmd.Flags().GetString("due-before"); dueBefore != "" {
+		due, err := utils.ParseTimeExpression(dueBefore)
+		if err == nil && due == "" {
+			err = fmt.Errorf("all_time is not a date")
+		}
+		if err != nil {
+			output.Error(fmt.Sprintf("Invalid due-before value: %v", err), viper.GetBool("plaintext"), viper.GetBool("json"))
+			os.Exit(exitValidation)
+		}
+		// dueDate has no time of day
+		filter["dueDate"] = map[string]interface{}{"lt": due[:len("2006-01-02")]}
 	}
 
 	if priority, _ := cmd.Flags().GetInt("priority"); priority != -1 {
@@ -825,28 +1005,64 @@ func buildIssueFilter(cmd *cobra.Command) map[string]interface{} {
 				plaintext := viper.GetBool("plaintext")
 				jsonOut := viper.GetBool("json")
 				output.Error(fmt.Sprintf("Invalid cycle value '%s': must be 'current' or a number", cycle), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 			filter["cycle"] = map[string]interface{}{"number": map[string]interface{}{"eq": cycleNum}}
 		}
 	}
 
-	// Handle newer-than filter
+	// Handle newer-than filter; a created term in --filter replaces the default
 	newerThan, _ := cmd.Flags().GetString("newer-than")
+	if newerThan == "" && expr != nil && expr.Uses("created") {
+		newerThan = "all_time"
+	}
 	createdAt, err := utils.ParseTimeExpression(newerThan)
 	if err != nil {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
 		output.Error(fmt.Sprintf("Invalid newer-than value: %v", err), plaintext, jsonOut)
-		os.Exit(1)
+		os.Exit(exitValidation)
 	}
 	if createdAt != "" {
 		filter["createdAt"] = map[string]interface{}{"gte": createdAt}
 	}
 
+	if len(extra) > 0 {
+		filter["and"] = extra
+	}
+
+	if expr != nil {
+		if len(filter) == 0 {
+			return expr.Filter()
+		}
+		return map[string]interface{}{"and": []interface{}{filter, expr.Filter()}}
+	}
+
 	return filter
 }
 
+// nameFilter matches a name case-insensitively against one value, or any of
+// several. Linear's "in" comparator is case-sensitive, so several values
+// become an "or" of eqIgnoreCase terms.
+func nameFilter(values []string) map[string]interface{} {
+	if len(values) == 1 {
+		return map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": values[0]}}
+	}
+	terms := make([]interface{}, len(values))
+	for i, value := range values {
+		terms[i] = map[string]interface{}{"name": map[string]interface{}{"eqIgnoreCase": value}}
+	}
+	return map[string]interface{}{"or": terms}
+}
+
+// eqOrIn compares with a single value, or with any of several.
+func eqOrIn(values []string) map[string]interface{} {
+	if len(values) == 1 {
+		return map[string]interface{}{"eq": values[0]}
+	}
+	return map[string]interface{}{"in": values}
+}
+
 func priorityToString(priority int) string {
 	switch priority {
 	case 0:
@@ -864,13 +1080,6 @@ func priorityToString(priority int) string {
 	}
 }
 
-func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
-}
-
 var issueAssignCmd = &cobra.Command{
 	Use:   "assign [issue-id]",
 	Short: "Assign issue to yourself",
@@ -883,16 +1092,16 @@ var issueAssignCmd = &cobra.Command{
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		// Get current user
 		viewer, err := client.GetViewer(context.Background())
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to get current user: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Update issue with assignee
@@ -903,7 +1112,7 @@ var issueAssignCmd = &cobra.Command{
 		issue, err := client.UpdateIssue(context.Background(), args[0], input)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to assign issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		if jsonOut {
@@ -912,9 +1121,9 @@ var issueAssignCmd = &cobra.Command{
 			fmt.Printf("Assigned %s to %s\n", issue.Identifier, viewer.Name)
 		} else {
 			fmt.Printf("%s Assigned %s to %s\n",
-				color.New(color.FgGreen).Sprint("✓"),
-				color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier),
-				color.New(color.FgCyan).Sprint(viewer.Name))
+				output.Green.Sprint(output.IconCheck),
+				output.CyanBold.Sprint(issue.Identifier),
+				output.Cyan.Sprint(viewer.Name))
 		}
 	},
 }
@@ -930,18 +1139,20 @@ Examples:
   linctl issue create --title "Feature request" --team ENG --description "Add dark mode"
   linctl issue create --title "Task" --team ENG --priority 1 --assign-me
   linctl issue create --title "Task" --team ENG --project <PROJECT-ID>
+  linctl issue create --title "Task" --team ENG --project "Q3 Launch"
   linctl issue create --title "Bug" --team ENG --labels "bug,urgent"`,
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
+		mustApplyConfigDefaults(cmd, plaintext, jsonOut)
 
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		// Get flags
 		title, _ := cmd.Flags().GetString("title")
@@ -953,19 +1164,19 @@ Examples:
 
 		if title == "" {
 			output.Error("Title is required (--title)", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 
 		if teamKey == "" {
 			output.Error("Team is required (--team)", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 
 		// Get team ID from key
 		team, err := client.GetTeam(context.Background(), teamKey)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to find team '%s': %v", teamKey, err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Build input
@@ -986,7 +1197,7 @@ Examples:
 			viewer, err := client.GetViewer(context.Background())
 			if err != nil {
 				output.Error(fmt.Sprintf("Failed to get current user: %v", err), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitCodeForError(err))
 			}
 			input["assigneeId"] = viewer.ID
 		}
@@ -994,9 +1205,15 @@ Examples:
 		// Handle project assignment
 		if cmd.Flags().Changed("project") {
 			projectID, _ := cmd.Flags().GetString("project")
+			if trimmed := strings.TrimSpace(projectID); trimmed != "" && trimmed != "unassigned" && !isValidUUID(trimmed) {
+				if projectID, err = resolveProjectID(context.Background(), client, trimmed); err != nil {
+					output.Error(fmt.Sprintf("Failed to find project: %v", err), plaintext, jsonOut)
+					os.Exit(exitCodeForError(err))
+				}
+			}
 			if val, ok, err := buildProjectInput(projectID); err != nil {
 				output.Error(err.Error(), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			} else if ok {
 				// For create, "unassigned" is equivalent to not setting project
 				if val != nil {
@@ -1015,7 +1232,7 @@ Examples:
 			delegateUser, err := client.FindUserByIdentifier(context.Background(), delegate)
 			if err != nil {
 				output.Error(fmt.Sprintf("Failed to find delegate user: %v", err), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitCodeForError(err))
 			}
 			input["delegateId"] = delegateUser.ID
 		}
@@ -1027,7 +1244,7 @@ Examples:
 			teamLabels, err := client.GetTeamLabels(context.Background(), teamKey)
 			if err != nil {
 				output.Error(fmt.Sprintf("Failed to get team labels: %v", err), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitCodeForError(err))
 			}
 
 			// Build map for case-insensitive lookup
@@ -1042,7 +1259,7 @@ Examples:
 				id, ok := labelMap[strings.ToLower(name)]
 				if !ok {
 					output.Error(fmt.Sprintf("Label not found: %s", name), plaintext, jsonOut)
-					os.Exit(1)
+					os.Exit(exitNotFound)
 				}
 				labelIds = append(labelIds, id)
 			}
@@ -1057,11 +1274,11 @@ Examples:
 				projectID, _ := cmd.Flags().GetString("project")
 				if projectID != "" && projectID != "unassigned" && isProjectNotFoundErr(err) {
 					output.Error(fmt.Sprintf("Project '%s' not found", projectID), plaintext, jsonOut)
-					os.Exit(1)
+					os.Exit(exitNotFound)
 				}
 			}
 			output.Error(fmt.Sprintf("Failed to create issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		if jsonOut {
@@ -1073,30 +1290,35 @@ Examples:
 			}
 		} else {
 			fmt.Printf("%s Created issue %s: %s\n",
-				color.New(color.FgGreen).Sprint("✓"),
-				color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier),
+				output.Green.Sprint(output.IconCheck),
+				output.CyanBold.Sprint(issue.Identifier),
 				issue.Title)
 			if issue.Assignee != nil {
-				fmt.Printf("  Assigned to: %s\n", color.New(color.FgCyan).Sprint(issue.Assignee.Name))
+				fmt.Printf("  Assigned to: %s\n", output.Cyan.Sprint(issue.Assignee.Name))
 			}
 			if issue.Project != nil {
-				fmt.Printf("  Project: %s\n", color.New(color.FgBlue).Sprint(issue.Project.Name))
+				fmt.Printf("  Project: %s\n", output.Blue.Sprint(issue.Project.Name))
 			}
 			if issue.Labels != nil && len(issue.Labels.Nodes) > 0 {
 				labelNames := []string{}
 				for _, label := range issue.Labels.Nodes {
 					labelNames = append(labelNames, label.Name)
 				}
-				fmt.Printf("  Labels: %s\n", color.New(color.FgCyan).Sprint(strings.Join(labelNames, ", ")))
+				fmt.Printf("  Labels: %s\n", output.Cyan.Sprint(strings.Join(labelNames, ", ")))
 			}
 		}
 	},
 }
 
 var issueUpdateCmd = &cobra.Command{
-	Use:   "update [issue-id]",
-	Short: "Update an issue",
-	Long: `Update various fields of an issue.
+	Use:   "update ISSUE-ID... | -",
+	Short: "Update one or more issues",
+	Long: `Update various fields of one or more issues.
+
+Pass several issue IDs, or "-" to read whitespace-separated IDs from stdin, to
+apply the same changes to each of them. Bulk updates run a few at a time
+(--concurrency) and end with a per-issue summary; one failure does not stop
+the others. Use --dry-run to check what would change without changing it.
 
 Examples:
   linctl issue update LIN-123 --title "New title"
@@ -1110,47 +1332,51 @@ Examples:
   linctl issue update LIN-123 --parent LIN-100     # Make sub-issue of LIN-100
   linctl issue update LIN-123 --parent none        # Remove parent (promote to top-level)
   linctl issue update LIN-123 --delegate agent-name
-  linctl issue update LIN-123 --title "New title" --assignee me --priority 2`,
-	Args: cobra.ExactArgs(1),
+  linctl issue update LIN-123 --title "New title" --assignee me --priority 2
+  linctl issue update LIN-123 LIN-124 LIN-125 --state Done
+  linctl issue list --state "In Review" --jq '.[].identifier' | linctl issue update - --state Done --dry-run`,
+	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+		ids, err := readIssueIDs(args, issueIDInput)
+		if err != nil {
+			output.Error(err.Error(), plaintext, jsonOut)
+			os.Exit(exitValidation)
+		}
+		if concurrency < 1 {
+			output.Error("--concurrency must be at least 1", plaintext, jsonOut)
+			os.Exit(exitValidation)
+		}
 
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
-		client := api.NewClient(authHeader)
-
-		// Lazy-fetch current issue to avoid redundant API calls across flag handlers
-		var cachedIssue *api.Issue
-		getCurrentIssue := func() (*api.Issue, error) {
-			if cachedIssue != nil {
-				return cachedIssue, nil
-			}
-			issue, err := client.GetIssue(context.Background(), args[0])
-			if err != nil {
-				return nil, err
-			}
-			cachedIssue = issue
-			return cachedIssue, nil
-		}
+		client := newClient(authHeader)
 
-		// Build update input
-		input := make(map[string]interface{})
+		// Changes shared by every issue are resolved once; the state and the
+		// parent check depend on each issue and are resolved by update.apply.
+		update := newIssueUpdate(client)
+		input, changes := update.input, update.changes
 
 		// Handle title update
 		if cmd.Flags().Changed("title") {
 			title, _ := cmd.Flags().GetString("title")
 			input["title"] = title
+			changes["title"] = title
 		}
 
 		// Handle description update
 		if cmd.Flags().Changed("description") {
 			description, _ := cmd.Flags().GetString("description")
 			input["description"] = description
+			changes["description"] = description
 		}
 
 		// Handle assignee update
@@ -1162,17 +1388,19 @@ Examples:
 				viewer, err := client.GetViewer(context.Background())
 				if err != nil {
 					output.Error(fmt.Sprintf("Failed to get current user: %v", err), plaintext, jsonOut)
-					os.Exit(1)
+					os.Exit(exitCodeForError(err))
 				}
 				input["assigneeId"] = viewer.ID
+				changes["assignee"] = viewer.Name
 			case "unassigned", "":
 				input["assigneeId"] = nil
+				changes["assignee"] = "unassigned"
 			default:
 				// Look up user by email
 				users, err := client.GetUsers(context.Background(), 100, "", "")
 				if err != nil {
 					output.Error(fmt.Sprintf("Failed to get users: %v", err), plaintext, jsonOut)
-					os.Exit(1)
+					os.Exit(exitCodeForError(err))
 				}
 
 				var foundUser *api.User
@@ -1185,10 +1413,11 @@ Examples:
 
 				if foundUser == nil {
 					output.Error(fmt.Sprintf("User not found: %s", assignee), plaintext, jsonOut)
-					os.Exit(1)
+					os.Exit(exitNotFound)
 				}
 
 				input["assigneeId"] = foundUser.ID
+				changes["assignee"] = foundUser.Name
 			}
 		}
 
@@ -1197,60 +1426,28 @@ Examples:
 			delegate, _ := cmd.Flags().GetString("delegate")
 			if delegate == "" || delegate == "none" {
 				input["delegateId"] = nil
+				changes["delegate"] = "none"
 			} else {
 				delegateUser, err := client.FindUserByIdentifier(context.Background(), delegate)
 				if err != nil {
 					output.Error(fmt.Sprintf("Failed to find delegate user: %v", err), plaintext, jsonOut)
-					os.Exit(1)
+					os.Exit(exitCodeForError(err))
 				}
 				input["delegateId"] = delegateUser.ID
+				changes["delegate"] = delegateUser.Name
 			}
 		}
 
-		// Handle state update
+		// Handle state update; states belong to teams, so the name is looked up per issue
 		if cmd.Flags().Changed("state") {
-			stateName, _ := cmd.Flags().GetString("state")
-
-			// Get the issue to know which team it belongs to
-			issue, err := getCurrentIssue()
-			if err != nil {
-				output.Error(fmt.Sprintf("Failed to get issue: %v", err), plaintext, jsonOut)
-				os.Exit(1)
-			}
-
-			// Get available states for the team
-			states, err := client.GetTeamStates(context.Background(), issue.Team.Key)
-			if err != nil {
-				output.Error(fmt.Sprintf("Failed to get team states: %v", err), plaintext, jsonOut)
-				os.Exit(1)
-			}
-
-			// Find the state by name (case-insensitive)
-			var stateID string
-			for _, state := range states {
-				if strings.EqualFold(state.Name, stateName) {
-					stateID = state.ID
-					break
-				}
-			}
-
-			if stateID == "" {
-				// Show available states
-				var stateNames []string
-				for _, state := range states {
-					stateNames = append(stateNames, state.Name)
-				}
-				output.Error(fmt.Sprintf("State '%s' not found. Available states: %s", stateName, strings.Join(stateNames, ", ")), plaintext, jsonOut)
-				os.Exit(1)
-			}
-
-			input["stateId"] = stateID
+			update.state, _ = cmd.Flags().GetString("state")
 		}
 
 		// Handle priority update
 		if cmd.Flags().Changed("priority") {
 			priority, _ := cmd.Flags().GetInt("priority")
 			input["priority"] = priority
+			changes["priority"] = priorityToString(priority)
 		}
 
 		// Handle due date update
@@ -1258,8 +1455,10 @@ Examples:
 			dueDate, _ := cmd.Flags().GetString("due-date")
 			if dueDate == "" {
 				input["dueDate"] = nil
+				changes["due-date"] = "none"
 			} else {
 				input["dueDate"] = dueDate
+				changes["due-date"] = dueDate
 			}
 		}
 
@@ -1268,9 +1467,11 @@ Examples:
 			projectID, _ := cmd.Flags().GetString("project")
 			if val, ok, err := buildProjectInput(projectID); err != nil {
 				output.Error(err.Error(), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			} else if ok {
 				input["projectId"] = val
+				update.project = projectID
+				changes["project"] = strings.TrimSpace(projectID)
 			}
 		}
 
@@ -1286,6 +1487,10 @@ Examples:
 			}
 			// Use empty slice to clear labels, or the filtered list to set labels
 			input["labelIds"] = filteredLabelIDs
+			changes["labels"] = "none"
+			if len(filteredLabelIDs) > 0 {
+				changes["labels"] = strings.Join(filteredLabelIDs, ", ")
+			}
 		}
 
 		// Handle parent update
@@ -1298,6 +1503,7 @@ Examples:
 			case "none", "null", "":
 				// Remove parent relationship (promote to top-level issue)
 				input["parentId"] = nil
+				changes["parent"] = "none"
 			default:
 				// Validate that the parent issue exists
 				// TODO: Consider using a lightweight API query that only fetches the parent's
@@ -1305,66 +1511,290 @@ Examples:
 				parentIssue, err := client.GetIssue(context.Background(), trimmedValue)
 				if err != nil {
 					output.Error(fmt.Sprintf("Failed to find parent issue '%s': %v", trimmedValue, err), plaintext, jsonOut)
-					os.Exit(1)
-				}
-
-				// Prevent self-referencing by comparing canonical IDs
-				// We fetch the current issue to get both its UUID and identifier for proper comparison,
-				// since the user might pass either format for args[0]
-				currentIssue, err := getCurrentIssue()
-				if err != nil {
-					output.Error(fmt.Sprintf("Failed to get current issue: %v", err), plaintext, jsonOut)
-					os.Exit(1)
-				}
-				if parentIssue.ID == currentIssue.ID {
-					output.Error("An issue cannot be its own parent", plaintext, jsonOut)
-					os.Exit(1)
+					os.Exit(exitCodeForError(err))
 				}
 
 				input["parentId"] = parentIssue.ID
+				update.parent = parentIssue
+				changes["parent"] = parentIssue.Identifier
 			}
 		}
 
 		// Check if any updates were specified
-		if len(input) == 0 {
+		if len(input) == 0 && update.state == "" {
 			output.Error("No updates specified. Use flags to specify what to update.", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 
-		// Update the issue
-		issue, err := client.UpdateIssue(context.Background(), args[0], input)
-		if err != nil {
-			// Standardize project not-found error when a project was provided
-			if cmd.Flags().Changed("project") {
-				projectID, _ := cmd.Flags().GetString("project")
-				if projectID != "" && projectID != "unassigned" && isProjectNotFoundErr(err) {
-					output.Error(fmt.Sprintf("Project '%s' not found", projectID), plaintext, jsonOut)
-					os.Exit(1)
+		// A single issue keeps the detailed output of the updated issue
+		if len(ids) == 1 && args[0] != "-" && !dryRun {
+			issue, _, err := update.apply(context.Background(), ids[0], false)
+			if err != nil {
+				output.Error(fmt.Sprintf("Failed to update issue: %v", err), plaintext, jsonOut)
+				os.Exit(exitCodeForError(err))
+			}
+
+			if jsonOut {
+				output.JSON(issue)
+			} else if plaintext {
+				fmt.Printf("Updated issue %s\n", issue.Identifier)
+				if issue.Parent != nil {
+					fmt.Printf("Parent: %s - %s\n", issue.Parent.Identifier, issue.Parent.Title)
+				}
+			} else {
+				output.Success(fmt.Sprintf("Updated issue %s", issue.Identifier), plaintext, jsonOut)
+				if issue.Parent != nil {
+					fmt.Printf("  %s Parent: %s - %s\n",
+						output.Blue.Sprint(output.IconReply),
+						output.Cyan.Sprint(issue.Parent.Identifier),
+						issue.Parent.Title)
 				}
 			}
-			output.Error(fmt.Sprintf("Failed to update issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			return
 		}
 
-		if jsonOut {
-			output.JSON(issue)
-		} else if plaintext {
-			fmt.Printf("Updated issue %s\n", issue.Identifier)
-			if issue.Parent != nil {
-				fmt.Printf("Parent: %s - %s\n", issue.Parent.Identifier, issue.Parent.Title)
+		results := update.applyAll(context.Background(), ids, concurrency, dryRun)
+		var firstErr error
+		for _, r := range results {
+			if r.err != nil && firstErr == nil {
+				firstErr = r.err
 			}
-		} else {
-			output.Success(fmt.Sprintf("Updated issue %s", issue.Identifier), plaintext, jsonOut)
-			if issue.Parent != nil {
-				fmt.Printf("  %s Parent: %s - %s\n",
-					color.New(color.FgBlue).Sprint("↳"),
-					color.New(color.FgCyan).Sprint(issue.Parent.Identifier),
-					issue.Parent.Title)
+		}
+
+		title, done := "Issues Updated", "Updated"
+		if dryRun {
+			title, done = "Dry Run: Issues to Update", "Would update"
+		}
+		printIssueResults(results, title, done, "update", plaintext, jsonOut)
+		if !jsonOut {
+			succeeded := 0
+			for _, r := range results {
+				if r.Success {
+					succeeded++
+				}
 			}
+			fmt.Printf("\n%s %d of %d issues\n", done, succeeded, len(results))
+		}
+
+		if firstErr != nil {
+			os.Exit(exitCodeForError(firstErr))
 		}
 	},
 }
 
+// issueIDInput is where "issue update -" reads issue IDs from.
+var issueIDInput io.Reader = os.Stdin
+
+// readIssueIDs returns the issue IDs given as args, or read from r when the
+// only arg is "-". IDs may be separated by any whitespace, may be quoted as
+// printed by --json, and are deduplicated keeping the first occurrence.
+func readIssueIDs(args []string, r io.Reader) ([]string, error) {
+	fields := args
+	for _, arg := range args {
+		if arg != "-" {
+			continue
+		}
+		if len(args) > 1 {
+			return nil, fmt.Errorf("'-' reads issue IDs from stdin and cannot be combined with other IDs")
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read issue IDs from stdin: %w", err)
+		}
+		fields = strings.Fields(string(data))
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		id := strings.Trim(field, `",`)
+		if id == "" || seen[strings.ToUpper(id)] {
+			continue
+		}
+		seen[strings.ToUpper(id)] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no issue IDs given")
+	}
+	return ids, nil
+}
+
+// issueUpdate is the set of changes made by "issue update". The input shared
+// by every issue is resolved once; the workflow state and the parent check
+// depend on each issue's team and identity, so apply resolves them per issue.
+type issueUpdate struct {
+	client  *api.Client
+	input   map[string]interface{}
+	changes map[string]string // the input as shown to the user, by flag name
+	state   string            // --state name, looked up in each issue's team
+	parent  *api.Issue        // new parent, which no issue may be its own
+	project string            // --project value, for a clearer not-found error
+
+	mu     sync.Mutex
+	states map[string][]api.WorkflowState // workflow states by team key
+	labels map[string][]api.Label         // labels by team key, for previews
+}
+
+func newIssueUpdate(client *api.Client) *issueUpdate {
+	return &issueUpdate{
+		client:  client,
+		input:   make(map[string]interface{}),
+		changes: make(map[string]string),
+		states:  make(map[string][]api.WorkflowState),
+		labels:  make(map[string][]api.Label),
+	}
+}
+
+// teamStates returns the workflow states of a team, fetching them once per
+// update however many of its issues are changed.
+func (u *issueUpdate) teamStates(ctx context.Context, teamKey string) ([]api.WorkflowState, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if states, ok := u.states[teamKey]; ok {
+		return states, nil
+	}
+	states, err := u.client.GetTeamStates(ctx, teamKey)
+	if err != nil {
+		return nil, err
+	}
+	u.states[teamKey] = states
+	return states, nil
+}
+
+// teamLabels returns the labels of a team, fetching them once per update.
+func (u *issueUpdate) teamLabels(ctx context.Context, teamKey string) ([]api.Label, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if labels, ok := u.labels[teamKey]; ok {
+		return labels, nil
+	}
+	labels, err := u.client.GetTeamLabels(ctx, teamKey)
+	if err != nil {
+		return nil, err
+	}
+	u.labels[teamKey] = labels
+	return labels, nil
+}
+
+// apply updates one issue and returns it with the changes made to it. With
+// dryRun it resolves and validates everything but skips the mutation,
+// returning the issue as it is now and the changes it would get.
+func (u *issueUpdate) apply(ctx context.Context, id string, dryRun bool) (*api.Issue, map[string]string, error) {
+	input := make(map[string]interface{}, len(u.input)+1)
+	for k, v := range u.input {
+		input[k] = v
+	}
+	changes := make(map[string]string, len(u.changes)+1)
+	for k, v := range u.changes {
+		changes[k] = v
+	}
+
+	// The issue itself is only needed for its team, its ID, or a preview
+	var issue *api.Issue
+	if u.state != "" || u.parent != nil || dryRun {
+		var err error
+		if issue, err = u.client.GetIssue(ctx, id); err != nil {
+			return nil, nil, fmt.Errorf("failed to get issue: %w", err)
+		}
+	}
+
+	if u.state != "" {
+		states, err := u.teamStates(ctx, issue.Team.Key)
+		if err != nil {
+			return issue, nil, fmt.Errorf("failed to get team states: %w", err)
+		}
+
+		// Find the state by name (case-insensitive)
+		var stateNames []string
+		for _, state := range states {
+			if strings.EqualFold(state.Name, u.state) {
+				input["stateId"] = state.ID
+				changes["state"] = state.Name
+				break
+			}
+			stateNames = append(stateNames, state.Name)
+		}
+		if input["stateId"] == nil {
+			return issue, nil, &exitCodeError{code: exitNotFound, msg: fmt.Sprintf("state '%s' not found in team %s. Available states: %s",
+				u.state, issue.Team.Key, strings.Join(stateNames, ", "))}
+		}
+	}
+
+	// Compare canonical IDs, since the user might pass either format
+	if u.parent != nil && u.parent.ID == issue.ID {
+		return issue, nil, &exitCodeError{code: exitValidation, msg: "an issue cannot be its own parent"}
+	}
+
+	if dryRun {
+		// A preview names the labels; when they can't be listed it keeps the IDs
+		if ids, _ := input["labelIds"].([]string); len(ids) > 0 {
+			if labels, err := u.teamLabels(ctx, issue.Team.Key); err == nil {
+				names := make([]string, len(ids))
+				for i, id := range ids {
+					names[i] = id
+					for _, label := range labels {
+						if label.ID == id {
+							names[i] = label.Name
+							break
+						}
+					}
+				}
+				changes["labels"] = strings.Join(names, ", ")
+			}
+		}
+		return issue, changes, nil
+	}
+
+	updated, err := u.client.UpdateIssue(ctx, id, input)
+	if err != nil {
+		// Standardize project not-found error when a project was provided
+		if u.project != "" && isProjectNotFoundErr(err) {
+			return issue, nil, &exitCodeError{code: exitNotFound, msg: fmt.Sprintf("project '%s' not found", u.project)}
+		}
+		return issue, nil, err
+	}
+	return updated, changes, nil
+}
+
+// applyAll applies the update to every issue, at most concurrency at a time,
+// and reports the outcome for each in the order of ids.
+func (u *issueUpdate) applyAll(ctx context.Context, ids []string, concurrency int, dryRun bool) []issueResult {
+	results := make([]issueResult, len(ids))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(ids)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := issueResult{ID: ids[i], Identifier: ids[i], Success: true}
+				issue, changes, err := u.apply(ctx, ids[i], dryRun)
+				if issue != nil {
+					result.ID, result.Identifier, result.Title = issue.ID, issue.Identifier, issue.Title
+				}
+				result.Changes = changes
+				if err != nil {
+					result.Success = false
+					result.Error = err.Error()
+					result.err = err
+				}
+				results[i] = result
+			}
+		}()
+	}
+	for i := range ids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
 var issueAttachCmd = &cobra.Command{
 	Use:   "attach [issue-id]",
 	Short: "Attach a resource to an issue",
@@ -1383,16 +1813,16 @@ Examples:
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		// Get issue to verify it exists and get its ID
 		issue, err := client.GetIssue(context.Background(), args[0])
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to fetch issue: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Build attachment input
@@ -1409,12 +1839,12 @@ Examples:
 
 		if prFlag != "" && urlFlag != "" {
 			output.Error("Cannot specify both --pr and --url", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 
 		if prFlag == "" && urlFlag == "" {
 			output.Error("Must specify either --pr or --url", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 
 		// Handle GitHub PR attachment
@@ -1422,7 +1852,7 @@ Examples:
 			prURL, prTitle, prSubtitle, prErr := buildGitHubPRAttachment(prFlag)
 			if prErr != nil {
 				output.Error(prErr.Error(), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 			input["url"] = prURL
 			if titleFlag != "" {
@@ -1446,7 +1876,7 @@ Examples:
 			input["url"] = urlFlag
 			if titleFlag == "" {
 				output.Error("--title is required when using --url", plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 			input["title"] = titleFlag
 			if subtitleFlag != "" {
@@ -1462,7 +1892,7 @@ Examples:
 		attachment, err := client.CreateAttachment(context.Background(), input)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to create attachment: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		if jsonOut {
@@ -1472,10 +1902,10 @@ Examples:
 			fmt.Printf("URL: %s\n", attachment.URL)
 		} else {
 			fmt.Printf("%s Attached to %s: %s\n",
-				color.New(color.FgGreen).Sprint("✓"),
-				color.New(color.FgCyan, color.Bold).Sprint(issue.Identifier),
+				output.Green.Sprint(output.IconCheck),
+				output.CyanBold.Sprint(issue.Identifier),
 				attachment.Title)
-			fmt.Printf("  %s\n", color.New(color.FgBlue, color.Underline).Sprint(attachment.URL))
+			fmt.Printf("  %s\n", output.Link.Sprint(attachment.URL))
 		}
 	},
 }
@@ -1557,6 +1987,216 @@ func detectGitHubRepoFromGit() (string, error) {
 	return "", fmt.Errorf("remote 'origin' is not a GitHub repository")
 }
 
+// issueResult reports what happened to one issue of a command that acts on
+// several issues.
+type issueResult struct {
+	ID         string            `json:"id"`
+	Identifier string            `json:"identifier,omitempty"`
+	Title      string            `json:"title,omitempty"`
+	Success    bool              `json:"success"`
+	Error      string            `json:"error,omitempty"`
+	Changes    map[string]string `json:"changes,omitempty"`
+
+	err error // the failure behind Error, for the exit code
+}
+
+// issueBulkAction describes a command that archives, unarchives or deletes
+// every issue given on the command line.
+type issueBulkAction struct {
+	verb     string // "archive", used in the prompt and in errors
+	done     string // "Archived", used in the summary
+	question string // confirmation prompt, with %s for what is affected
+	run      func(ctx context.Context, client *api.Client, id string) error
+}
+
+// runIssueBulkAction looks up every issue in args, asks for confirmation
+// unless --yes is set, then applies the action to each issue in turn. One
+// failure does not stop the others; the exit code reflects the first one.
+func runIssueBulkAction(cmd *cobra.Command, args []string, action issueBulkAction) {
+	plaintext := viper.GetBool("plaintext")
+	jsonOut := viper.GetBool("json")
+
+	authHeader, err := auth.GetAuthHeader()
+	if err != nil {
+		output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
+		os.Exit(exitAuth)
+	}
+
+	client := newClient(authHeader)
+	ctx := context.Background()
+
+	// Look every issue up first, so a typo aborts before anything changes
+	var issues []*api.Issue
+	seen := make(map[string]bool)
+	for _, id := range args {
+		issue, err := client.GetIssue(ctx, id)
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to find issue %s: %v", id, err), plaintext, jsonOut)
+			os.Exit(exitCodeForError(err))
+		}
+		if seen[issue.ID] {
+			continue
+		}
+		seen[issue.ID] = true
+		issues = append(issues, issue)
+	}
+
+	if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+		if !inputIsTerminal() {
+			output.Error(fmt.Sprintf("Not confirmed: %v", errNoConfirmation), plaintext, jsonOut)
+			os.Exit(exitValidation)
+		}
+		what := issues[0].Identifier
+		if len(issues) > 1 {
+			what = fmt.Sprintf("%d issues", len(issues))
+			for _, issue := range issues {
+				fmt.Fprintf(os.Stderr, "  %s %s\n", issue.Identifier, issue.Title)
+			}
+		}
+		ok, err := confirm(fmt.Sprintf(action.question, what))
+		if err != nil {
+			output.Error(fmt.Sprintf("Not confirmed: %v", err), plaintext, jsonOut)
+			os.Exit(exitValidation)
+		}
+		if !ok {
+			output.Info(fmt.Sprintf("Cancelled; nothing was %sd", action.verb), plaintext, jsonOut)
+			return
+		}
+	}
+
+	var firstErr error
+	results := make([]issueResult, len(issues))
+	for i, issue := range issues {
+		results[i] = issueResult{ID: issue.ID, Identifier: issue.Identifier, Title: issue.Title, Success: true}
+		if err := action.run(ctx, client, issue.ID); err != nil {
+			results[i].Success = false
+			results[i].Error = err.Error()
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	printIssueResults(results, "Issues "+action.done, action.done, action.verb, plaintext, jsonOut)
+
+	if firstErr != nil {
+		os.Exit(exitCodeForError(firstErr))
+	}
+}
+
+// printIssueResults prints what happened to each issue of a bulk command:
+// done ("Archived") labels successes and verb ("archive") failures. The
+// changes of each success are listed after it.
+func printIssueResults(results []issueResult, title, done, verb string, plaintext, jsonOut bool) {
+	if jsonOut {
+		output.JSON(results)
+	} else if plaintext {
+		fmt.Printf("# %s\n\n", title)
+		for _, r := range results {
+			if r.Success {
+				fmt.Printf("- %s: %s\n", r.Identifier, r.Title)
+				if len(r.Changes) > 0 {
+					fmt.Printf("  %s\n", formatIssueChanges(r.Changes))
+				}
+			} else {
+				fmt.Printf("- %s: failed to %s: %s\n", r.Identifier, verb, r.Error)
+			}
+		}
+	} else {
+		for _, r := range results {
+			if r.Success {
+				fmt.Printf("%s %s %s %s\n", output.Green.Sprint(output.IconCheck), done,
+					output.CyanBold.Sprint(r.Identifier), r.Title)
+				if len(r.Changes) > 0 {
+					fmt.Printf("    %s\n", output.Faint.Sprint(formatIssueChanges(r.Changes)))
+				}
+			} else {
+				fmt.Printf("%s Failed to %s %s: %s\n", output.Red.Sprint(output.IconCross), verb,
+					output.CyanBold.Sprint(r.Identifier), r.Error)
+			}
+		}
+	}
+}
+
+// formatIssueChanges lists changes as "name=value" pairs sorted by name.
+func formatIssueChanges(changes map[string]string) string {
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		names[i] = fmt.Sprintf("%s=%s", name, changes[name])
+	}
+	return strings.Join(names, ", ")
+}
+
+var issueArchiveCmd = &cobra.Command{
+	Use:   "archive ISSUE-ID...",
+	Short: "Archive issues",
+	Long: `Archive one or more issues. Archived issues are hidden from lists and
+views but can be restored with 'linctl issue unarchive'.
+
+Examples:
+  linctl issue archive ENG-123
+  linctl issue archive ENG-123 ENG-124 --yes`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runIssueBulkAction(cmd, args, issueBulkAction{
+			verb:     "archive",
+			done:     "Archived",
+			question: "Archive %s?",
+			run: func(ctx context.Context, client *api.Client, id string) error {
+				return client.ArchiveIssue(ctx, id)
+			},
+		})
+	},
+}
+
+var issueUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive ISSUE-ID...",
+	Short: "Restore archived issues",
+	Long: `Restore one or more archived issues.
+
+Examples:
+  linctl issue unarchive ENG-123
+  linctl issue unarchive ENG-123 ENG-124 --yes`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runIssueBulkAction(cmd, args, issueBulkAction{
+			verb:     "unarchive",
+			done:     "Unarchived",
+			question: "Unarchive %s?",
+			run: func(ctx context.Context, client *api.Client, id string) error {
+				return client.UnarchiveIssue(ctx, id)
+			},
+		})
+	},
+}
+
+var issueDeleteCmd = &cobra.Command{
+	Use:     "delete ISSUE-ID...",
+	Aliases: []string{"rm"},
+	Short:   "Move issues to the trash",
+	Long: `Delete one or more issues. Deleted issues go to the trash, where they can
+be restored from the web app for 30 days.
+
+Examples:
+  linctl issue delete ENG-123
+  linctl issue delete ENG-123 ENG-124 --yes`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runIssueBulkAction(cmd, args, issueBulkAction{
+			verb:     "delete",
+			done:     "Deleted",
+			question: "Move %s to the trash?",
+			run: func(ctx context.Context, client *api.Client, id string) error {
+				return client.DeleteIssue(ctx, id)
+			},
+		})
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(issueCmd)
 	issueCmd.AddCommand(issueListCmd)
@@ -1566,42 +2206,42 @@ func init() {
 	issueCmd.AddCommand(issueCreateCmd)
 	issueCmd.AddCommand(issueUpdateCmd)
 	issueCmd.AddCommand(issueAttachCmd)
+	issueCmd.AddCommand(issueArchiveCmd)
+	issueCmd.AddCommand(issueUnarchiveCmd)
+	issueCmd.AddCommand(issueDeleteCmd)
+
+	for _, c := range []*cobra.Command{issueArchiveCmd, issueUnarchiveCmd, issueDeleteCmd} {
+		c.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	}
 
 	// Issue list flags
-	issueListCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (email or 'me')")
-	issueListCmd.Flags().StringP("state", "s", "", "Filter by state name")
-	issueListCmd.Flags().StringP("team", "t", "", "Filter by team key")
-	issueListCmd.Flags().IntP("priority", "r", -1, "Filter by priority (0=None, 1=Urgent, 2=High, 3=Normal, 4=Low)")
-	issueListCmd.Flags().StringP("cycle", "y", "", "Filter by cycle ('current' or cycle number)")
+	addIssueFilterFlags(issueListCmd)
 	issueListCmd.Flags().IntP("limit", "l", 50, "Maximum number of issues to fetch")
-	issueListCmd.Flags().BoolP("include-completed", "c", false, "Include completed and canceled issues")
+	issueListCmd.Flags().Bool("all", false, "Fetch every matching issue (ignores --limit)")
 	issueListCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
-	issueListCmd.Flags().StringP("newer-than", "n", "", "Show issues created after this time (default: 6_months_ago, use 'all_time' for no filter)")
+	issueListCmd.Flags().String("fields", "", "Comma-separated fields to fetch and print (e.g. identifier,state,assignee)")
+
+	// Issue get flags
+	issueGetCmd.Flags().String("fields", "", "Comma-separated fields to fetch and print (e.g. identifier,state,assignee)")
 
 	// Issue search flags
-	issueSearchCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (email or 'me')")
-	issueSearchCmd.Flags().StringP("state", "s", "", "Filter by state name")
-	issueSearchCmd.Flags().StringP("team", "t", "", "Filter by team key")
-	issueSearchCmd.Flags().IntP("priority", "r", -1, "Filter by priority (0=None, 1=Urgent, 2=High, 3=Normal, 4=Low)")
-	issueSearchCmd.Flags().StringP("cycle", "y", "", "Filter by cycle ('current' or cycle number)")
+	addIssueFilterFlags(issueSearchCmd)
 	issueSearchCmd.Flags().IntP("limit", "l", 50, "Maximum number of issues to fetch")
-	issueSearchCmd.Flags().BoolP("include-completed", "c", false, "Include completed and canceled issues")
+	issueSearchCmd.Flags().Bool("all", false, "Fetch every matching issue (ignores --limit)")
 	issueSearchCmd.Flags().Bool("include-archived", false, "Include archived issues in results")
 	issueSearchCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
-	issueSearchCmd.Flags().StringP("newer-than", "n", "", "Show issues created after this time (default: 6_months_ago, use 'all_time' for no filter)")
 
 	// Issue create flags
 	issueCreateCmd.Flags().StringP("title", "", "", "Issue title (required)")
 	issueCreateCmd.Flags().StringP("description", "d", "", "Issue description")
-	issueCreateCmd.Flags().StringP("team", "t", "", "Team key (required)")
+	issueCreateCmd.Flags().StringP("team", "t", "", "Team key (required unless defaults.team is set)")
 	issueCreateCmd.Flags().Int("priority", 3, "Priority (0=None, 1=Urgent, 2=High, 3=Normal, 4=Low)")
 	issueCreateCmd.Flags().BoolP("assign-me", "m", false, "Assign to yourself")
-	issueCreateCmd.Flags().String("project", "", "Project ID to assign issue to")
+	issueCreateCmd.Flags().String("project", "", "Project ID to assign issue to, or its name")
 	issueCreateCmd.Flags().StringSlice("labels", []string{}, "Label IDs to attach (comma-separated)")
 	issueCreateCmd.Flags().String("delegate", "", "Delegate to agent (email, name, or displayName)")
 	issueCreateCmd.Flags().StringSlice("label", []string{}, "Label name(s) to apply (can be repeated)")
 	_ = issueCreateCmd.MarkFlagRequired("title")
-	_ = issueCreateCmd.MarkFlagRequired("team")
 
 	// Issue update flags
 	issueUpdateCmd.Flags().String("title", "", "New title for the issue")
@@ -1614,6 +2254,8 @@ func init() {
 	issueUpdateCmd.Flags().StringSlice("labels", []string{}, "Label IDs to set (comma-separated, replaces existing labels)")
 	issueUpdateCmd.Flags().String("parent", "", "Parent issue ID or identifier (use 'none', 'null', or empty to remove parent)")
 	issueUpdateCmd.Flags().String("delegate", "", "Delegate to agent (email, name, displayName, or 'none' to remove)")
+	issueUpdateCmd.Flags().Bool("dry-run", false, "Show each issue with the changes it would get, without changing it")
+	issueUpdateCmd.Flags().Int("concurrency", 4, "Number of issues to update at a time")
 
 	// Issue attach flags
 	issueAttachCmd.Flags().String("pr", "", "GitHub PR number or full URL")
@@ -1622,3 +2264,22 @@ func init() {
 	issueAttachCmd.Flags().String("subtitle", "", "Attachment subtitle")
 	issueAttachCmd.Flags().String("icon-url", "", "Icon URL for the attachment")
 }
+
+// addIssueFilterFlags adds the flags read by buildIssueFilter.
+func addIssueFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("assignee", "a", "", "Filter by assignee (email or 'me')")
+	cmd.Flags().StringArrayP("state", "s", nil, "Filter by state name, ignoring case (can be repeated)")
+	cmd.Flags().StringArrayP("team", "t", nil, "Filter by team key (can be repeated)")
+	cmd.Flags().StringArray("project", nil, "Filter by project name (ignoring case) or ID (can be repeated)")
+	cmd.Flags().Bool("no-project", false, "Only issues without a project")
+	cmd.Flags().StringArray("label", nil, "Filter by label name, ignoring case; matches any of them (can be repeated)")
+	cmd.Flags().StringArray("creator", nil, "Filter by creator (email or 'me', can be repeated)")
+	cmd.Flags().Bool("unassigned", false, "Only issues without an assignee")
+	cmd.Flags().String("due-before", "", "Only issues due before this date (e.g. 2025-10-01)")
+	cmd.Flags().Bool("has-parent", false, "Only sub-issues")
+	cmd.Flags().StringP("filter", "f", "", "Filter expression, e.g. 'label:bug priority<=2 -state:Backlog'")
+	cmd.Flags().IntP("priority", "r", -1, "Filter by priority (0=None, 1=Urgent, 2=High, 3=Normal, 4=Low)")
+	cmd.Flags().StringP("cycle", "y", "", "Filter by cycle ('current' or cycle number)")
+	cmd.Flags().BoolP("include-completed", "c", false, "Include completed and canceled issues")
+	cmd.Flags().StringP("newer-than", "n", "", "Show issues created after this time (default: 6_months_ago, use 'all_time' for no filter)")
+}
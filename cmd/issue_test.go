@@ -1,8 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+
+	"github.com/charlietran/linctl/pkg/api"
+	"github.com/spf13/cobra"
 )
 
 func TestIsValidUUID(t *testing.T) {
@@ -62,6 +72,10 @@ func TestIsProjectNotFoundErr(t *testing.T) {
 		{errors.New("issue not found"), false},
 		{errors.New("unknown error"), false},
 		{nil, false},
+		{&api.GraphQLErrors{Errors: []api.GraphQLError{{Message: "Entity not found: Project"}}}, true},
+		{&api.GraphQLErrors{Errors: []api.GraphQLError{{Message: "Entity not found", Path: []interface{}{"issueUpdate", "projectId"}}}}, true},
+		{&api.GraphQLErrors{Errors: []api.GraphQLError{{Message: "Entity not found: Issue"}}}, false},
+		{&api.GraphQLErrors{Errors: []api.GraphQLError{{Message: "Project is archived", Extensions: api.GraphQLErrorExtensions{Code: "INVALID_INPUT"}}}}, false},
 	}
 	for _, c := range cases {
 		got := isProjectNotFoundErr(c.in)
@@ -96,8 +110,151 @@ func TestIssueUpdateCommandExists(t *testing.T) {
 		t.Fatal("issueUpdateCmd should not be nil")
 	}
 
-	if issueUpdateCmd.Use != "update [issue-id]" {
-		t.Errorf("Expected Use 'update [issue-id]', got '%s'", issueUpdateCmd.Use)
+	if issueUpdateCmd.Use != "update ISSUE-ID... | -" {
+		t.Errorf("Expected Use 'update ISSUE-ID... | -', got '%s'", issueUpdateCmd.Use)
+	}
+	if err := issueUpdateCmd.Args(issueUpdateCmd, []string{}); err == nil {
+		t.Error("Expected error when no args provided")
+	}
+	if err := issueUpdateCmd.Args(issueUpdateCmd, []string{"ENG-1", "ENG-2"}); err != nil {
+		t.Errorf("Expected several ids to be accepted, got %v", err)
+	}
+	for _, name := range []string{"dry-run", "concurrency"} {
+		if issueUpdateCmd.Flags().Lookup(name) == nil {
+			t.Errorf("issueUpdateCmd should have --%s flag", name)
+		}
+	}
+}
+
+func TestReadIssueIDs(t *testing.T) {
+	ids, err := readIssueIDs([]string{"-"}, strings.NewReader("ENG-1\n\"ENG-2\",\n  eng-1 WEB-3\n\n"))
+	if err != nil {
+		t.Fatalf("readIssueIDs failed: %v", err)
+	}
+	if got := strings.Join(ids, " "); got != "ENG-1 ENG-2 WEB-3" {
+		t.Errorf("Expected deduplicated ids from stdin, got %q", got)
+	}
+
+	if ids, _ := readIssueIDs([]string{"ENG-1", "ENG-2", "ENG-1"}, nil); len(ids) != 2 {
+		t.Errorf("Expected duplicate args to be dropped, got %v", ids)
+	}
+	if _, err := readIssueIDs([]string{"-", "ENG-1"}, strings.NewReader("")); err == nil {
+		t.Error("Expected an error when '-' is combined with ids")
+	}
+	if _, err := readIssueIDs([]string{"-"}, strings.NewReader(" \n")); err == nil {
+		t.Error("Expected an error when stdin holds no ids")
+	}
+}
+
+// newIssueUpdateServer fakes the queries "issue update" makes. Issues of team
+// WEB have no Done state; every mutation is recorded.
+func newIssueUpdateServer(t *testing.T) (srv *httptest.Server, calls func(op string) []string) {
+	t.Helper()
+	var mu sync.Mutex
+	seen := make(map[string][]string)
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req api.GraphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		var op, data string
+		switch {
+		case strings.Contains(req.Query, "query Issue("):
+			op = "Issue"
+			id := req.Variables["id"].(string)
+			data = `{"issue":{"id":"id-` + id + `","identifier":"` + id + `","title":"T","team":{"key":"` + id[:3] + `"}}}`
+		case strings.Contains(req.Query, "TeamStates"):
+			op = "TeamStates"
+			data = `{"team":{"states":{"nodes":[{"id":"s-todo","name":"Todo"}]}}}`
+			if req.Variables["key"] == "ENG" {
+				data = `{"team":{"states":{"nodes":[{"id":"s-todo","name":"Todo"},{"id":"s-done","name":"Done"}]}}}`
+			}
+		case strings.Contains(req.Query, "TeamLabels"):
+			op = "TeamLabels"
+			data = `{"team":{"labels":{"nodes":[{"id":"l-bug","name":"Bug"}]}}}`
+		case strings.Contains(req.Query, "issueUpdate"):
+			op = "UpdateIssue"
+			id := req.Variables["id"].(string)
+			data = `{"issueUpdate":{"success":true,"issue":{"id":"id-` + id + `","identifier":"` + id + `","title":"T"}}}`
+		}
+		mu.Lock()
+		seen[op] = append(seen[op], fmt.Sprint(req.Variables["id"], req.Variables["key"]))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"data":` + data + `}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func(op string) []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return seen[op]
+	}
+}
+
+func TestIssueUpdateApplyAll(t *testing.T) {
+	srv, calls := newIssueUpdateServer(t)
+	update := newIssueUpdate(api.NewClientWithURL(srv.URL, "test-key"))
+	update.state = "done"
+	update.input["priority"] = 2
+
+	ids := []string{"ENG-1", "WEB-2", "ENG-3", "ENG-4"}
+	results := update.applyAll(context.Background(), ids, 3, false)
+
+	for i, r := range results {
+		if r.Identifier != ids[i] {
+			t.Errorf("results[%d] is %s, want results in input order", i, r.Identifier)
+		}
+	}
+	if r := results[1]; r.Success || !strings.Contains(r.Error, "state 'done' not found in team WEB") || exitCodeForError(r.err) != exitNotFound {
+		t.Errorf("Expected WEB-2 to fail with a not found state, got %+v", r)
+	}
+	if got := len(calls("UpdateIssue")); got != 3 {
+		t.Errorf("Expected 3 updates, got %d", got)
+	}
+	if got := len(calls("TeamStates")); got != 2 {
+		t.Errorf("Expected states to be fetched once per team, got %d", got)
+	}
+}
+
+func TestIssueUpdateDryRun(t *testing.T) {
+	srv, calls := newIssueUpdateServer(t)
+	update := newIssueUpdate(api.NewClientWithURL(srv.URL, "test-key"))
+	update.parent = &api.Issue{ID: "id-ENG-2"}
+	update.input["parentId"] = "id-ENG-2"
+
+	results := update.applyAll(context.Background(), []string{"ENG-1", "ENG-2"}, 4, true)
+
+	if !results[0].Success || results[0].Title != "T" {
+		t.Errorf("Expected ENG-1 to pass the dry run, got %+v", results[0])
+	}
+	if results[1].Success || exitCodeForError(results[1].err) != exitValidation {
+		t.Errorf("Expected ENG-2 to be refused as its own parent, got %+v", results[1])
+	}
+	if got := calls("UpdateIssue"); len(got) != 0 {
+		t.Errorf("Expected no updates in a dry run, got %v", got)
+	}
+}
+
+func TestIssueUpdateDryRunShowsChanges(t *testing.T) {
+	srv, _ := newIssueUpdateServer(t)
+	update := newIssueUpdate(api.NewClientWithURL(srv.URL, "test-key"))
+	update.state = "done"
+	update.input["labelIds"] = []string{"l-bug", "l-gone"}
+	update.changes["labels"] = "l-bug, l-gone"
+	update.changes["assignee"] = "Ada"
+
+	results := update.applyAll(context.Background(), []string{"ENG-1", "WEB-2"}, 2, true)
+
+	want := "assignee=Ada, labels=Bug, l-gone, state=Done"
+	if got := formatIssueChanges(results[0].Changes); got != want {
+		t.Errorf("Expected ENG-1 to preview %q, got %q", want, got)
+	}
+	if results[1].Success || results[1].Changes != nil {
+		t.Errorf("Expected WEB-2 to fail without changes, got %+v", results[1])
+	}
+
+	out := captureStdout(t, func() {
+		printIssueResults(results, "Dry Run", "Would update", "update", true, false)
+	})
+	if !strings.Contains(out, "- ENG-1: T\n  assignee=Ada, labels=Bug, l-gone, state=Done\n") {
+		t.Errorf("Expected the changes under ENG-1, got:\n%s", out)
 	}
 }
 
@@ -170,3 +327,159 @@ func TestIssueUpdateFlags(t *testing.T) {
 		t.Fatal("issueUpdateCmd should have --priority flag")
 	}
 }
+
+// newIssueFilterCmd returns a command with the filter flags of issue list,
+// parsed from args, so each test starts from the defaults.
+func newIssueFilterCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "list"}
+	addIssueFilterFlags(cmd)
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func filterJSON(t *testing.T, filter map[string]interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestBuildIssueFilterWithExpression(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{
+			[]string{"--filter", "label:bug", "--newer-than", "all_time"},
+			`{"and":[{"state":{"type":{"nin":["completed","canceled"]}}},{"labels":{"some":{"name":{"eqIgnoreCase":"bug"}}}}]}`,
+		},
+		{
+			[]string{"--filter", "state:Done OR state:Canceled", "--newer-than", "all_time"},
+			`{"or":[{"state":{"name":{"eqIgnoreCase":"Done"}}},{"state":{"name":{"eqIgnoreCase":"Canceled"}}}]}`,
+		},
+		{
+			[]string{"--filter", "created>=2024-01-01", "--include-completed", "--team", "ENG"},
+			`{"and":[{"team":{"key":{"eq":"ENG"}}},{"createdAt":{"gte":"2024-01-01T00:00:00Z"}}]}`,
+		},
+	}
+	for _, tt := range tests {
+		got := filterJSON(t, buildIssueFilter(newIssueFilterCmd(t, tt.args...)))
+		if got != tt.want {
+			t.Errorf("%v\n got: %s\nwant: %s", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestBuildIssueFilterMultiValueFlags(t *testing.T) {
+	base := []string{"--include-completed", "--newer-than", "all_time"}
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{
+			[]string{"--state", "Todo", "--state", "In Progress", "--team", "ENG"},
+			`{"state":{"or":[{"name":{"eqIgnoreCase":"Todo"}},{"name":{"eqIgnoreCase":"In Progress"}}]},"team":{"key":{"eq":"ENG"}}}`,
+		},
+		{
+			[]string{"--state", "todo"},
+			`{"state":{"name":{"eqIgnoreCase":"todo"}}}`,
+		},
+		{
+			[]string{"--label", "bug", "--label", "regression", "--unassigned"},
+			`{"assignee":{"null":true},"labels":{"some":{"or":[{"name":{"eqIgnoreCase":"bug"}},{"name":{"eqIgnoreCase":"regression"}}]}}}`,
+		},
+		{
+			[]string{"--project", "Q3 Launch"},
+			`{"project":{"name":{"eqIgnoreCase":"Q3 Launch"}}}`,
+		},
+		{
+			[]string{"--project", "Q3 Launch", "--project", "6f1b2c3d-0000-4000-8000-000000000000"},
+			`{"project":{"or":[{"id":{"eq":"6f1b2c3d-0000-4000-8000-000000000000"}},{"name":{"eqIgnoreCase":"Q3 Launch"}}]}}`,
+		},
+		{
+			[]string{"--project", "q3 launch", "--project", "Other"},
+			`{"project":{"or":[{"name":{"eqIgnoreCase":"q3 launch"}},{"name":{"eqIgnoreCase":"Other"}}]}}`,
+		},
+		{
+			[]string{"--no-project", "--has-parent", "--due-before", "2025-10-01"},
+			`{"dueDate":{"lt":"2025-10-01"},"parent":{"null":false},"project":{"null":true}}`,
+		},
+		{
+			[]string{"--creator", "ada@example.com", "--creator", "grace@example.com"},
+			`{"creator":{"email":{"in":["ada@example.com","grace@example.com"]}}}`,
+		},
+		{
+			[]string{"--creator", "me", "--creator", "ada@example.com"},
+			`{"and":[{"or":[{"creator":{"isMe":{"eq":true}}},{"creator":{"email":{"eq":"ada@example.com"}}}]}]}`,
+		},
+		{
+			[]string{"--label", "Bug, P1"},
+			`{"labels":{"some":{"name":{"eqIgnoreCase":"Bug, P1"}}}}`,
+		},
+		{
+			[]string{"--state", "Done, Verified", "--project", "Q3, Q4 Launch"},
+			`{"project":{"name":{"eqIgnoreCase":"Q3, Q4 Launch"}},"state":{"name":{"eqIgnoreCase":"Done, Verified"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		got := filterJSON(t, buildIssueFilter(newIssueFilterCmd(t, append(base, tt.args...)...)))
+		if got != tt.want {
+			t.Errorf("%v\n got: %s\nwant: %s", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestIssueArchiveCommandsExist(t *testing.T) {
+	for use, c := range map[string]*cobra.Command{
+		"archive ISSUE-ID...":   issueArchiveCmd,
+		"unarchive ISSUE-ID...": issueUnarchiveCmd,
+		"delete ISSUE-ID...":    issueDeleteCmd,
+	} {
+		if c.Use != use {
+			t.Errorf("Expected Use %q, got %q", use, c.Use)
+		}
+		if err := c.Args(c, []string{}); err == nil {
+			t.Errorf("%s: expected error when no args provided", c.Name())
+		}
+		if err := c.Args(c, []string{"ENG-1", "ENG-2"}); err != nil {
+			t.Errorf("%s: expected several ids to be accepted, got %v", c.Name(), err)
+		}
+		yes := c.Flags().Lookup("yes")
+		if yes == nil || yes.Shorthand != "y" {
+			t.Errorf("%s: expected a --yes/-y flag", c.Name())
+		}
+	}
+}
+
+func TestResolveProjectID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req api.GraphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		name := req.Variables["filter"].(map[string]interface{})["name"].(map[string]interface{})["eqIgnoreCase"]
+		nodes := map[string]string{
+			"q3 launch": `[{"id":"p-1","name":"Q3 Launch"}]`,
+			"roadmap":   `[{"id":"p-2","name":"Roadmap"},{"id":"p-3","name":"roadmap"}]`,
+		}[strings.ToLower(name.(string))]
+		if nodes == "" {
+			nodes = "[]"
+		}
+		_, _ = w.Write([]byte(`{"data":{"projects":{"nodes":` + nodes + `}}}`))
+	}))
+	defer srv.Close()
+	client := api.NewClientWithURL(srv.URL, "test-key")
+
+	if id, err := resolveProjectID(context.Background(), client, "Q3 launch"); err != nil || id != "p-1" {
+		t.Errorf("resolveProjectID(Q3 launch) = %q, %v; want p-1", id, err)
+	}
+	if _, err := resolveProjectID(context.Background(), client, "Missing"); exitCodeForError(err) != exitNotFound {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+	if _, err := resolveProjectID(context.Background(), client, "Roadmap"); exitCodeForError(err) != exitValidation {
+		t.Errorf("Expected an ambiguity error, got %v", err)
+	}
+}
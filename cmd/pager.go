@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/charlietran/linctl/pkg/output"
+	"github.com/spf13/viper"
+)
+
+// startPager pages the rest of a command's output when stdout is a terminal.
+// The pager is $LINCTL_PAGER, the "pager" config setting, $PAGER or less;
+// --no-pager turns it off. Commands start it once their data is fetched, so
+// errors before that are printed directly. Exits after it starts must go
+// through output.Exit, which stops the pager first.
+func startPager() {
+	if viper.GetBool("no-pager") {
+		return
+	}
+	command := viper.GetString("pager")
+	if command == "" {
+		command = os.Getenv("PAGER")
+	}
+	if command == "" {
+		command = "less"
+	}
+	// A missing pager is not worth failing over; print directly instead.
+	_ = output.StartPager(command)
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// defaultListLimit is used when --limit is omitted or not positive.
+const defaultListLimit = 50
+
+// listLimit returns how many results a list command should fetch.
+// With --all it returns 0, which the paginated API helpers treat as "fetch every page".
+func listLimit(cmd *cobra.Command) int {
+	if all, _ := cmd.Flags().GetBool("all"); all {
+		return 0
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	return limit
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/charlietran/linctl/pkg/auth"
 	"github.com/charlietran/linctl/pkg/output"
 	"github.com/charlietran/linctl/pkg/utils"
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -20,15 +19,16 @@ import (
 // This enables dependency injection in tests without changing public API types.
 type projectAPI interface {
 	GetTeam(ctx context.Context, key string) (*api.Team, error)
-	GetProjects(ctx context.Context, filter map[string]interface{}, first int, after string, orderBy string) (*api.Projects, error)
+	GetProjectsPaginated(ctx context.Context, filter map[string]interface{}, limit int, orderBy string) (*api.Projects, error)
 	CreateProject(ctx context.Context, input map[string]interface{}) (*api.Project, error)
 	UpdateProject(ctx context.Context, id string, input map[string]interface{}) (*api.Project, error)
 	ArchiveProject(ctx context.Context, id string) (bool, error)
 	GetProject(ctx context.Context, id string) (*api.Project, error)
+	GetProjectFields(ctx context.Context, id string, fields []string) (map[string]interface{}, error)
 }
 
 // Injection points for testing
-var newAPIClient = func(authHeader string) projectAPI { return api.NewClient(authHeader) }
+var newAPIClient = func(authHeader string) projectAPI { return newClient(authHeader) }
 var getAuthHeader = auth.GetAuthHeader
 
 // constructProjectURL constructs an ID-based project URL
@@ -71,12 +71,13 @@ var projectListCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
+		mustApplyConfigDefaults(cmd, plaintext, jsonOut)
 
 		// Get auth header
 		authHeader, err := getAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
@@ -85,7 +86,7 @@ var projectListCmd = &cobra.Command{
 		// Get filters
 		teamKey, _ := cmd.Flags().GetString("team")
 		state, _ := cmd.Flags().GetString("state")
-		limit, _ := cmd.Flags().GetInt("limit")
+		limit := listLimit(cmd)
 		includeCompleted, _ := cmd.Flags().GetBool("include-completed")
 
 		// Build filter
@@ -95,7 +96,7 @@ var projectListCmd = &cobra.Command{
 			team, err := client.GetTeam(context.Background(), teamKey)
 			if err != nil {
 				output.Error(fmt.Sprintf("Failed to find team '%s': %v", teamKey, err), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitCodeForError(err))
 			}
 			filter["team"] = map[string]interface{}{"id": team.ID}
 		}
@@ -113,7 +114,7 @@ var projectListCmd = &cobra.Command{
 		createdAt, err := utils.ParseTimeExpression(newerThan)
 		if err != nil {
 			output.Error(fmt.Sprintf("Invalid newer-than value: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 		if createdAt != "" {
 			filter["createdAt"] = map[string]interface{}{"gte": createdAt}
@@ -133,16 +134,17 @@ var projectListCmd = &cobra.Command{
 				orderBy = ""
 			default:
 				output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 		}
 
 		// Get projects
-		projects, err := client.GetProjects(context.Background(), filter, limit, "", orderBy)
+		projects, err := client.GetProjectsPaginated(context.Background(), filter, limit, orderBy)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to list projects: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
+		startPager()
 
 		// Handle output
 		if jsonOut {
@@ -195,7 +197,7 @@ var projectListCmd = &cobra.Command{
 			rows := [][]string{}
 
 			for _, project := range projects.Nodes {
-				lead := color.New(color.FgYellow).Sprint("Unassigned")
+				lead := output.Yellow.Sprint("Unassigned")
 				if project.Lead != nil {
 					lead = project.Lead.Name
 				}
@@ -210,18 +212,18 @@ var projectListCmd = &cobra.Command{
 					}
 				}
 
-				stateColor := color.New(color.FgGreen)
+				stateColor := output.Green
 				switch project.State {
 				case "planned":
-					stateColor = color.New(color.FgCyan)
+					stateColor = output.Cyan
 				case "started":
-					stateColor = color.New(color.FgBlue)
+					stateColor = output.Blue
 				case "paused":
-					stateColor = color.New(color.FgYellow)
+					stateColor = output.Yellow
 				case "completed":
-					stateColor = color.New(color.FgGreen)
+					stateColor = output.Green
 				case "canceled":
-					stateColor = color.New(color.FgRed)
+					stateColor = output.Red
 				}
 
 				// Format priority
@@ -231,7 +233,7 @@ var projectListCmd = &cobra.Command{
 				}
 
 				rows = append(rows, []string{
-					truncateString(project.Name, 25),
+					project.Name,
 					stateColor.Sprint(project.State),
 					priorityStr,
 					lead,
@@ -245,11 +247,12 @@ var projectListCmd = &cobra.Command{
 			output.Table(output.TableData{
 				Headers: headers,
 				Rows:    rows,
+				Shrink:  "Name",
 			}, plaintext, jsonOut)
 
 			if !plaintext && !jsonOut {
 				fmt.Printf("\n%s %d projects\n",
-					color.New(color.FgGreen).Sprint("✓"),
+					output.Green.Sprint(output.IconCheck),
 					len(projects.Nodes))
 			}
 		}
@@ -260,29 +263,52 @@ var projectGetCmd = &cobra.Command{
 	Use:     "get PROJECT-ID",
 	Aliases: []string{"show"},
 	Short:   "Get project details",
-	Long:    `Get detailed information about a specific project.`,
-	Args:    cobra.ExactArgs(1),
+	Long: `Get detailed information about a specific project.
+
+Use --fields to fetch and print only some fields.
+
+Examples:
+  linctl project get PROJECT-ID
+  linctl project get PROJECT-ID --fields name,state,lead,targetDate --json`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		plaintext := viper.GetBool("plaintext")
 		jsonOut := viper.GetBool("json")
 		projectID := args[0]
 
+		fields, err := selectedFields(cmd, api.ProjectFields)
+		if err != nil {
+			output.Error(err.Error(), plaintext, jsonOut)
+			os.Exit(exitValidation)
+		}
+
 		// Get auth header
 		authHeader, err := getAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
 		client := newAPIClient(authHeader)
 
+		if fields != nil {
+			record, err := client.GetProjectFields(context.Background(), projectID, fields)
+			if err != nil {
+				output.Error(fmt.Sprintf("Failed to get project: %v", err), plaintext, jsonOut)
+				os.Exit(exitCodeForError(err))
+			}
+			renderFieldRecord(record, fields, plaintext, jsonOut)
+			return
+		}
+
 		// Get project details
 		project, err := client.GetProject(context.Background(), projectID)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to get project: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
+		startPager()
 
 		// Handle output
 		if jsonOut {
@@ -510,41 +536,45 @@ var projectGetCmd = &cobra.Command{
 		} else {
 			// Formatted output
 			fmt.Println()
-			fmt.Printf("%s %s\n", color.New(color.FgCyan, color.Bold).Sprint("📁 Project:"), project.Name)
-			fmt.Println(strings.Repeat("─", 50))
+			fmt.Printf("%s %s\n", output.CyanBold.Sprint(output.IconProject.Prefix("Project:")), project.Name)
+			fmt.Println(output.Rule(50))
 
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("ID:"), project.ID)
+			fmt.Printf("%s %s\n", output.Bold.Sprint("ID:"), project.ID)
 
 			if project.Description != "" {
-				fmt.Printf("\n%s\n%s\n", color.New(color.Bold).Sprint("Description:"), project.Description)
+				fmt.Printf("\n%s\n%s\n", output.Bold.Sprint("Description:"), output.Markdown(project.Description))
 			}
 
-			stateColor := color.New(color.FgGreen)
+			if project.Content != "" {
+				fmt.Printf("\n%s\n%s\n", output.Bold.Sprint("Content:"), output.Markdown(project.Content))
+			}
+
+			stateColor := output.Green
 			switch project.State {
 			case "planned":
-				stateColor = color.New(color.FgCyan)
+				stateColor = output.Cyan
 			case "started":
-				stateColor = color.New(color.FgBlue)
+				stateColor = output.Blue
 			case "paused":
-				stateColor = color.New(color.FgYellow)
+				stateColor = output.Yellow
 			case "completed":
-				stateColor = color.New(color.FgGreen)
+				stateColor = output.Green
 			case "canceled":
-				stateColor = color.New(color.FgRed)
+				stateColor = output.Red
 			}
-			fmt.Printf("\n%s %s\n", color.New(color.Bold).Sprint("State:"), stateColor.Sprint(project.State))
+			fmt.Printf("\n%s %s\n", output.Bold.Sprint("State:"), stateColor.Sprint(project.State))
 
 			if project.Priority > 0 {
-				fmt.Printf("%s %d\n", color.New(color.Bold).Sprint("Priority:"), project.Priority)
+				fmt.Printf("%s %d\n", output.Bold.Sprint("Priority:"), project.Priority)
 			}
 
-			progressColor := color.New(color.FgRed)
+			progressColor := output.Red
 			if project.Progress >= 0.75 {
-				progressColor = color.New(color.FgGreen)
+				progressColor = output.Green
 			} else if project.Progress >= 0.5 {
-				progressColor = color.New(color.FgYellow)
+				progressColor = output.Yellow
 			}
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Progress:"), progressColor.Sprintf("%.0f%%", project.Progress*100))
+			fmt.Printf("%s %s\n", output.Bold.Sprint("Progress:"), progressColor.Sprintf("%.0f%%", project.Progress*100))
 
 			if project.Initiatives != nil && len(project.Initiatives.Nodes) > 0 {
 				initiatives := ""
@@ -554,7 +584,7 @@ var projectGetCmd = &cobra.Command{
 					}
 					initiatives += initiative.Name
 				}
-				fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Initiatives:"), initiatives)
+				fmt.Printf("%s %s\n", output.Bold.Sprint("Initiatives:"), initiatives)
 			}
 
 			if project.Labels != nil && len(project.Labels.Nodes) > 0 {
@@ -565,61 +595,63 @@ var projectGetCmd = &cobra.Command{
 					}
 					labels += label.Name
 				}
-				fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Labels:"), labels)
+				fmt.Printf("%s %s\n", output.Bold.Sprint("Labels:"), labels)
 			}
 
 			if project.StartDate != nil || project.TargetDate != nil {
 				fmt.Println()
 				if project.StartDate != nil {
-					fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Start Date:"), *project.StartDate)
+					fmt.Printf("%s %s\n", output.Bold.Sprint("Start Date:"), *project.StartDate)
 				}
 				if project.TargetDate != nil {
-					fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Target Date:"), *project.TargetDate)
+					fmt.Printf("%s %s\n", output.Bold.Sprint("Target Date:"), *project.TargetDate)
 				}
 			}
 
 			if project.Lead != nil {
 				fmt.Printf("\n%s %s (%s)\n",
-					color.New(color.Bold).Sprint("Lead:"),
+					output.Bold.Sprint("Lead:"),
 					project.Lead.Name,
-					color.New(color.FgCyan).Sprint(project.Lead.Email))
+					output.Cyan.Sprint(project.Lead.Email))
 			}
 
 			if project.Teams != nil && len(project.Teams.Nodes) > 0 {
-				fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("Teams:"))
+				fmt.Printf("\n%s\n", output.Bold.Sprint("Teams:"))
 				for _, team := range project.Teams.Nodes {
-					fmt.Printf("  • %s - %s\n",
-						color.New(color.FgCyan).Sprint(team.Key),
+					fmt.Printf("  %s %s - %s\n",
+						output.IconBullet,
+						output.Cyan.Sprint(team.Key),
 						team.Name)
 				}
 			}
 
 			// Show members if available
 			if project.Members != nil && len(project.Members.Nodes) > 0 {
-				fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("Members:"))
+				fmt.Printf("\n%s\n", output.Bold.Sprint("Members:"))
 				for _, member := range project.Members.Nodes {
-					fmt.Printf("  • %s (%s)\n",
+					fmt.Printf("  %s %s (%s)\n",
+						output.IconBullet,
 						member.Name,
-						color.New(color.FgCyan).Sprint(member.Email))
+						output.Cyan.Sprint(member.Email))
 				}
 			}
 
 			// Show sample issues if available
 			if project.Issues != nil && len(project.Issues.Nodes) > 0 {
-				fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("Recent Issues:"))
+				fmt.Printf("\n%s\n", output.Bold.Sprint("Recent Issues:"))
 				for i, issue := range project.Issues.Nodes {
 					if i >= 5 {
 						break // Show only first 5
 					}
-					stateIcon := "○"
+					stateIcon := output.IconPending.String()
 					if issue.State != nil {
 						switch issue.State.Type {
 						case "completed":
-							stateIcon = color.New(color.FgGreen).Sprint("✓")
+							stateIcon = output.Green.Sprint(output.IconCheck)
 						case "started":
-							stateIcon = color.New(color.FgBlue).Sprint("◐")
+							stateIcon = output.Blue.Sprint(output.IconInProgress)
 						case "canceled":
-							stateIcon = color.New(color.FgRed).Sprint("✗")
+							stateIcon = output.Red.Sprint(output.IconCross)
 						}
 					}
 					assignee := "Unassigned"
@@ -628,14 +660,14 @@ var projectGetCmd = &cobra.Command{
 					}
 					fmt.Printf("  %s %s %s (%s)\n",
 						stateIcon,
-						color.New(color.FgCyan).Sprint(issue.Identifier),
+						output.Cyan.Sprint(issue.Identifier),
 						issue.Title,
-						color.New(color.FgWhite, color.Faint).Sprint(assignee))
+						output.Faint.Sprint(assignee))
 				}
 			}
 
 			// Show timestamps
-			fmt.Printf("\n%s\n", color.New(color.Bold).Sprint("Timeline:"))
+			fmt.Printf("\n%s\n", output.Bold.Sprint("Timeline:"))
 			fmt.Printf("  Created: %s\n", project.CreatedAt.Format("2006-01-02"))
 			fmt.Printf("  Updated: %s\n", project.UpdatedAt.Format("2006-01-02"))
 			if project.CompletedAt != nil {
@@ -648,8 +680,8 @@ var projectGetCmd = &cobra.Command{
 			// Show URL
 			if project.URL != "" {
 				fmt.Printf("\n%s %s\n",
-					color.New(color.Bold).Sprint("URL:"),
-					color.New(color.FgBlue, color.Underline).Sprint(constructProjectURL(project.ID, project.URL)))
+					output.Bold.Sprint("URL:"),
+					output.Link.Sprint(constructProjectURL(project.ID, project.URL)))
 			}
 
 			fmt.Println()
@@ -684,14 +716,14 @@ Examples:
 		// Validate required fields
 		if name == "" || teamKey == "" {
 			output.Error("Both --name and --team are required", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 
 		// Get auth header
 		authHeader, err := getAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
@@ -701,7 +733,7 @@ Examples:
 		team, err := client.GetTeam(context.Background(), teamKey)
 		if err != nil {
 			output.Error(fmt.Sprintf("Team '%s' not found. Use 'linctl team list' to see available teams.", teamKey), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Get optional fields
@@ -721,7 +753,7 @@ Examples:
 			}
 			if !valid {
 				output.Error(fmt.Sprintf("Invalid state. Must be one of: %s", strings.Join(allowedStates, ", ")), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 		}
 
@@ -731,7 +763,7 @@ Examples:
 			priority, _ = cmd.Flags().GetInt("priority")
 			if priority < 0 || priority > 4 {
 				output.Error("Priority must be between 0 (None) and 4 (Low)", plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 		}
 
@@ -739,7 +771,7 @@ Examples:
 		if targetDate != "" {
 			if _, err := time.Parse("2006-01-02", targetDate); err != nil {
 				output.Error("Invalid --target-date format. Expected YYYY-MM-DD", plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 		}
 
@@ -766,7 +798,7 @@ Examples:
 		project, err := client.CreateProject(context.Background(), input)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to create project: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Handle output
@@ -793,15 +825,15 @@ Examples:
 			fmt.Printf("- **URL**: %s\n", constructProjectURL(project.ID, project.URL))
 		} else {
 			fmt.Println()
-			fmt.Printf("%s Project created successfully\n", color.New(color.FgGreen).Sprint("✓"))
+			fmt.Printf("%s Project created successfully\n", output.Green.Sprint(output.IconCheck))
 			fmt.Println()
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Name:"), project.Name)
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("ID:"), project.ID)
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("State:"), project.State)
+			fmt.Printf("%s %s\n", output.Bold.Sprint("Name:"), project.Name)
+			fmt.Printf("%s %s\n", output.Bold.Sprint("ID:"), project.ID)
+			fmt.Printf("%s %s\n", output.Bold.Sprint("State:"), project.State)
 			if project.Teams != nil && len(project.Teams.Nodes) > 0 {
-				fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Team:"), project.Teams.Nodes[0].Key)
+				fmt.Printf("%s %s\n", output.Bold.Sprint("Team:"), project.Teams.Nodes[0].Key)
 			}
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("URL:"), color.New(color.FgBlue, color.Underline).Sprint(constructProjectURL(project.ID, project.URL)))
+			fmt.Printf("%s %s\n", output.Bold.Sprint("URL:"), output.Link.Sprint(constructProjectURL(project.ID, project.URL)))
 			fmt.Println()
 		}
 	},
@@ -823,14 +855,14 @@ Examples:
 		// Validate argument provided
 		if projectID == "" {
 			output.Error("Project UUID is required", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 
 		// Get auth header
 		authHeader, err := getAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
@@ -840,7 +872,7 @@ Examples:
 		success, err := client.ArchiveProject(context.Background(), projectID)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to archive project: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Try to fetch project details to include the name in output (best effort)
@@ -870,12 +902,12 @@ Examples:
 			fmt.Printf("- **Status**: Archived\n")
 		} else {
 			fmt.Println()
-			fmt.Printf("%s Project archived successfully\n", color.New(color.FgGreen).Sprint("✓"))
+			fmt.Printf("%s Project archived successfully\n", output.Green.Sprint(output.IconCheck))
 			fmt.Println()
 			if projectName != "" {
-				fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Name:"), projectName)
+				fmt.Printf("%s %s\n", output.Bold.Sprint("Name:"), projectName)
 			}
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Project ID:"), projectID)
+			fmt.Printf("%s %s\n", output.Bold.Sprint("Project ID:"), projectID)
 			fmt.Println()
 		}
 	},
@@ -906,14 +938,14 @@ Examples:
 		// Validate project UUID provided
 		if projectID == "" {
 			output.Error("Project UUID is required", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 
 		// Get auth header
 		authHeader, err := getAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
@@ -946,7 +978,7 @@ Examples:
 		// Validate at least one field provided
 		if len(input) == 0 {
 			output.Error("At least one field to update is required", plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitValidation)
 		}
 
 		// Validate state if provided
@@ -961,7 +993,7 @@ Examples:
 			}
 			if !valid {
 				output.Error(fmt.Sprintf("Invalid state. Must be one of: %s", strings.Join(allowedStates, ", ")), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 		}
 
@@ -969,7 +1001,7 @@ Examples:
 		if priority, ok := input["priority"].(int); ok {
 			if priority < 0 || priority > 4 {
 				output.Error("Priority must be between 0 (None) and 4 (Low)", plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 		}
 
@@ -977,7 +1009,7 @@ Examples:
 		project, err := client.UpdateProject(context.Background(), projectID, input)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to update project: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Handle output
@@ -999,17 +1031,17 @@ Examples:
 			fmt.Printf("- **URL**: %s\n", constructProjectURL(project.ID, project.URL))
 		} else {
 			fmt.Println()
-			fmt.Printf("%s Project updated successfully\n", color.New(color.FgGreen).Sprint("✓"))
+			fmt.Printf("%s Project updated successfully\n", output.Green.Sprint(output.IconCheck))
 			fmt.Println()
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Name:"), project.Name)
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("ID:"), project.ID)
+			fmt.Printf("%s %s\n", output.Bold.Sprint("Name:"), project.Name)
+			fmt.Printf("%s %s\n", output.Bold.Sprint("ID:"), project.ID)
 			if project.State != "" {
-				fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("State:"), project.State)
+				fmt.Printf("%s %s\n", output.Bold.Sprint("State:"), project.State)
 			}
 			if project.Priority > 0 {
-				fmt.Printf("%s %d\n", color.New(color.Bold).Sprint("Priority:"), project.Priority)
+				fmt.Printf("%s %d\n", output.Bold.Sprint("Priority:"), project.Priority)
 			}
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("URL:"), color.New(color.FgBlue, color.Underline).Sprint(constructProjectURL(project.ID, project.URL)))
+			fmt.Printf("%s %s\n", output.Bold.Sprint("URL:"), output.Link.Sprint(constructProjectURL(project.ID, project.URL)))
 			fmt.Println()
 		}
 	},
@@ -1027,10 +1059,14 @@ func init() {
 	projectListCmd.Flags().StringP("team", "t", "", "Filter by team key")
 	projectListCmd.Flags().StringP("state", "s", "", "Filter by state (planned, started, paused, completed, canceled)")
 	projectListCmd.Flags().IntP("limit", "l", 50, "Maximum number of projects to return")
+	projectListCmd.Flags().Bool("all", false, "Fetch every matching project (ignores --limit)")
 	projectListCmd.Flags().BoolP("include-completed", "c", false, "Include completed and canceled projects")
 	projectListCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
 	projectListCmd.Flags().StringP("newer-than", "n", "", "Show projects created after this time (default: 6_months_ago, use 'all_time' for no filter)")
 
+	// Get command flags
+	projectGetCmd.Flags().String("fields", "", "Comma-separated fields to fetch and print (e.g. name,state,lead)")
+
 	// Create command flags
 	projectCreateCmd.Flags().String("name", "", "Project name (required)")
 	projectCreateCmd.Flags().String("team", "", "Team key (required)")
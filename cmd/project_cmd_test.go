@@ -20,7 +20,7 @@ func (m *mockProjectClient) GetTeam(ctx context.Context, key string) (*api.Team,
 	return &api.Team{ID: "team-1", Key: key, Name: "Team-" + key}, nil
 }
 
-func (m *mockProjectClient) GetProjects(ctx context.Context, filter map[string]interface{}, first int, after string, orderBy string) (*api.Projects, error) {
+func (m *mockProjectClient) GetProjectsPaginated(ctx context.Context, filter map[string]interface{}, limit int, orderBy string) (*api.Projects, error) {
 	return &api.Projects{}, nil
 }
 
@@ -53,6 +53,20 @@ func (m *mockProjectClient) GetProject(ctx context.Context, id string) (*api.Pro
 	return &api.Project{ID: id, Name: "Alpha"}, nil
 }
 
+func (m *mockProjectClient) GetProjectFields(ctx context.Context, id string, fields []string) (map[string]interface{}, error) {
+	project := map[string]interface{}{
+		"id":    id,
+		"name":  "Alpha",
+		"state": "started",
+		"lead":  map[string]interface{}{"id": "u1", "name": "Ada"},
+	}
+	record := make(map[string]interface{})
+	for _, field := range fields {
+		record[field] = project[field]
+	}
+	return record, nil
+}
+
 func withInjectedProjectClient(t *testing.T, mc *mockProjectClient, fn func()) {
 	t.Helper()
 	oldNew := newAPIClient
@@ -153,3 +167,17 @@ func TestProjectUpdateFlags(t *testing.T) {
 		}
 	}
 }
+
+func TestProjectGet_Fields_Output(t *testing.T) {
+	mc := &mockProjectClient{}
+	withInjectedProjectClient(t, mc, func() {
+		viper.Set("plaintext", true)
+		viper.Set("json", false)
+		_ = projectGetCmd.Flags().Set("fields", "name,lead")
+		defer func() { _ = projectGetCmd.Flags().Set("fields", "") }()
+		out := captureStdout(t, func() { projectGetCmd.Run(projectGetCmd, []string{"p1"}) })
+		if out != "name: Alpha\nlead: Ada\n" {
+			t.Fatalf("unexpected output:\n%s", out)
+		}
+	})
+}
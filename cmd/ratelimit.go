@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charlietran/linctl/pkg/api"
+	"github.com/charlietran/linctl/pkg/auth"
+	"github.com/charlietran/linctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var rateLimitCmd = &cobra.Command{
+	Use:     "rate-limit",
+	Aliases: []string{"ratelimit"},
+	Short:   "Show API rate-limit status",
+	Long: `Show how much of Linear's request and complexity budget remains.
+
+Linear reports rate limits on every API response; this command makes a
+lightweight request and shows the values it returned.
+
+Examples:
+  linctl rate-limit           # Show remaining requests and complexity
+  linctl rate-limit --json    # Machine-readable output for CI`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		// Get auth header
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
+			os.Exit(exitAuth)
+		}
+
+		// Create API client
+		client := newClient(authHeader)
+
+		status, err := client.GetRateLimit(context.Background())
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to get rate limit: %v", err), plaintext, jsonOut)
+			os.Exit(exitCodeForError(err))
+		}
+
+		// Handle output
+		if jsonOut {
+			output.JSON(status)
+		} else if plaintext {
+			if status.Requests != nil {
+				fmt.Printf("Requests: %d/%d remaining (resets %s)\n",
+					status.Requests.Remaining, status.Requests.Limit, status.Requests.Reset.Format(time.RFC3339))
+			}
+			if status.Complexity != nil {
+				fmt.Printf("Complexity: %d/%d remaining (resets %s)\n",
+					status.Complexity.Remaining, status.Complexity.Limit, status.Complexity.Reset.Format(time.RFC3339))
+			}
+			if status.LastQueryComplexity > 0 {
+				fmt.Printf("Last Query Complexity: %d\n", status.LastQueryComplexity)
+			}
+		} else {
+			fmt.Println()
+			fmt.Println(output.CyanBold.Sprint(output.IconClock.Prefix("API Rate Limits")))
+			fmt.Println(output.Rule(50))
+
+			if status.Requests != nil {
+				printRateLimitBucket("Requests:", status.Requests)
+			}
+			if status.Complexity != nil {
+				printRateLimitBucket("Complexity:", status.Complexity)
+			}
+			if status.LastQueryComplexity > 0 {
+				fmt.Printf("\n%s %d points\n", output.Bold.Sprint("Last Query Complexity:"), status.LastQueryComplexity)
+			}
+			fmt.Println()
+		}
+	},
+}
+
+// printRateLimitBucket prints one rate-limit bucket, coloring the remaining
+// budget by how close it is to being exhausted.
+func printRateLimitBucket(label string, bucket *api.RateLimit) {
+	remainingColor := output.Green
+	if bucket.Limit > 0 {
+		switch ratio := float64(bucket.Remaining) / float64(bucket.Limit); {
+		case ratio < 0.1:
+			remainingColor = output.RedBold
+		case ratio < 0.25:
+			remainingColor = output.Yellow
+		}
+	}
+
+	fmt.Printf("\n%s %s / %d remaining\n",
+		output.Bold.Sprint(label),
+		remainingColor.Sprint(bucket.Remaining),
+		bucket.Limit)
+	if !bucket.Reset.IsZero() {
+		fmt.Printf("  Resets: %s (%s)\n",
+			bucket.Reset.Local().Format("2006-01-02 15:04:05"),
+			output.Faint.Sprintf("in %s", time.Until(bucket.Reset).Round(time.Second)))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(rateLimitCmd)
+}
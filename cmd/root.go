@@ -5,27 +5,46 @@ import (
 	"os"
 	"strings"
 
-	"github.com/fatih/color"
+	"github.com/charlietran/linctl/pkg/auth"
+	"github.com/charlietran/linctl/pkg/output"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	cfgFile   string
-	plaintext bool
-	jsonOut   bool
+	cfgFile     string
+	plaintext   bool
+	jsonOut     bool
+	authProfile string
+	noCache     bool
+	noPager     bool
+	debug       bool
+	traceFile   string
+	recordDir   string
+	replayDir   string
+	jqExpr      string
+	outTemplate string
+	outFormat   string
+	colorMode   string
+	asciiOut    bool
 )
 
 // version is set at build time via -ldflags
 // default value is for local dev builds
 var version = "dev"
 
-// generateHeader creates a nice header box with proper Unicode box drawing
+// generateHeader creates a nice header box with proper Unicode box drawing,
+// or plain ASCII in ASCII mode
 func generateHeader() string {
 	lines := []string{
 		"🚀 linctl",
 		"Linear CLI - Built with ❤️",
 	}
+	topLeft, topRight, bottomLeft, bottomRight, horizontal, vertical := "┌", "┐", "└", "┘", "─", "│"
+	if output.ASCII() {
+		lines = []string{"linctl", "Linear CLI"}
+		topLeft, topRight, bottomLeft, bottomRight, horizontal, vertical = "+", "+", "+", "+", "-", "|"
+	}
 
 	// Find the longest line
 	maxLen := 0
@@ -42,24 +61,24 @@ func generateHeader() string {
 	var result strings.Builder
 
 	// Top border
-	result.WriteString("┌")
-	result.WriteString(strings.Repeat("─", width))
-	result.WriteString("┐\n")
+	result.WriteString(topLeft)
+	result.WriteString(strings.Repeat(horizontal, width))
+	result.WriteString(topRight + "\n")
 
 	// Content lines
 	for _, line := range lines {
 		padding := (width - len(line)) / 2
-		result.WriteString("│")
+		result.WriteString(vertical)
 		result.WriteString(strings.Repeat(" ", padding))
 		result.WriteString(line)
 		result.WriteString(strings.Repeat(" ", width-padding-len(line)))
-		result.WriteString("│\n")
+		result.WriteString(vertical + "\n")
 	}
 
 	// Bottom border
-	result.WriteString("└")
-	result.WriteString(strings.Repeat("─", width))
-	result.WriteString("┘")
+	result.WriteString(bottomLeft)
+	result.WriteString(strings.Repeat(horizontal, width))
+	result.WriteString(bottomRight)
 
 	return result.String()
 }
@@ -68,15 +87,36 @@ func generateHeader() string {
 var rootCmd = &cobra.Command{
 	Use:     "linctl",
 	Short:   "A comprehensive Linear CLI tool",
-	Long:    color.New(color.FgCyan).Sprintf("%s\nA comprehensive CLI tool for Linear's API featuring:\n• Issue management (create, list, update, archive)\n• Project tracking and collaboration  \n• Team and user management\n• Comments and attachments\n• Webhook configuration\n• Table/plaintext/JSON output formats\n", generateHeader()),
+	Long:    rootLong(),
 	Version: version,
 }
 
+// rootLong describes linctl for the root command's help.
+func rootLong() string {
+	features := []string{
+		"Issue management (create, list, update, archive)",
+		"Project tracking and collaboration  ",
+		"Team and user management",
+		"Comments and attachments",
+		"Webhook configuration",
+		"Table/plaintext/JSON output formats",
+	}
+	var b strings.Builder
+	b.WriteString(generateHeader())
+	b.WriteString("\nA comprehensive CLI tool for Linear's API featuring:\n")
+	for _, feature := range features {
+		b.WriteString(output.IconBullet.Prefix(feature) + "\n")
+	}
+	return output.Cyan.Sprint(b.String())
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
 	err := rootCmd.Execute()
+	output.StopPager()
 	if err != nil {
-		os.Exit(1)
+		// Cobra only fails here for unknown commands, flags or bad arguments
+		os.Exit(exitValidation)
 	}
 }
 
@@ -86,26 +126,66 @@ func GetRootCmd() *cobra.Command {
 }
 
 func init() {
-	cobra.OnInitialize(initConfig)
+	cobra.OnInitialize(initConfig, initStyle, initOutputFormat, initOutputFilter, initConfigNotice, initProfile)
+
+	// Help is printed without running the initializers, so apply --color and
+	// --ascii here and rebuild the banner to match.
+	defaultHelp := rootCmd.HelpFunc()
+	rootCmd.SetHelpFunc(func(c *cobra.Command, args []string) {
+		initStyle()
+		rootCmd.Long = rootLong()
+		defaultHelp(c, args)
+	})
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.linctl.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&plaintext, "plaintext", "p", false, "plaintext output (non-interactive)")
 	rootCmd.PersistentFlags().BoolVarP(&jsonOut, "json", "j", false, "JSON output")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", output.ColorAuto, "when to use colors: auto, always or never (auto respects NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVar(&asciiOut, "ascii", false, "use ASCII symbols instead of emoji and box drawing (or set LINCTL_ASCII=1)")
+	rootCmd.PersistentFlags().StringVar(&outFormat, "format", "", "output format: table, plain, json, ndjson, yaml, csv or tsv")
+	rootCmd.PersistentFlags().StringVar(&jqExpr, "jq", "", "filter JSON output with a jq expression; a built-in subset of jq without def, destructuring, label/break or input (implies --json)")
+	rootCmd.PersistentFlags().StringVar(&outTemplate, "template", "", "format JSON output with a Go template (implies --json)")
+	rootCmd.PersistentFlags().StringVar(&authProfile, "profile", "", "auth profile to use (overrides LINCTL_PROFILE)")
+	rootCmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "print long output directly instead of through $PAGER")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "bypass the on-disk cache of teams, states, labels and users")
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "log every API request to stderr (or set LINCTL_DEBUG=1)")
+	rootCmd.PersistentFlags().StringVar(&traceFile, "trace-file", "", "append a JSON line per API request to this file (or set LINCTL_TRACE_FILE)")
+	rootCmd.PersistentFlags().StringVar(&recordDir, "record", "", "record API requests and responses as cassettes in this directory")
+	rootCmd.PersistentFlags().StringVar(&replayDir, "replay", "", "serve API responses from cassettes in this directory instead of Linear")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("plaintext", rootCmd.PersistentFlags().Lookup("plaintext"))
 	_ = viper.BindPFlag("json", rootCmd.PersistentFlags().Lookup("json"))
+	_ = viper.BindPFlag("color", rootCmd.PersistentFlags().Lookup("color"))
+	_ = viper.BindPFlag("ascii", rootCmd.PersistentFlags().Lookup("ascii"))
+	_ = viper.BindEnv("ascii", "LINCTL_ASCII")
+	_ = viper.BindPFlag("no-pager", rootCmd.PersistentFlags().Lookup("no-pager"))
+	_ = viper.BindEnv("pager", "LINCTL_PAGER")
+	_ = viper.BindPFlag("no-cache", rootCmd.PersistentFlags().Lookup("no-cache"))
+	_ = viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
+	_ = viper.BindPFlag("trace-file", rootCmd.PersistentFlags().Lookup("trace-file"))
+	_ = viper.BindEnv("debug", "LINCTL_DEBUG")
+	_ = viper.BindEnv("trace-file", "LINCTL_TRACE_FILE")
+	_ = viper.BindPFlag("record", rootCmd.PersistentFlags().Lookup("record"))
+	_ = viper.BindPFlag("replay", rootCmd.PersistentFlags().Lookup("replay"))
+	_ = viper.BindEnv("record", "LINCTL_RECORD")
+	_ = viper.BindEnv("replay", "LINCTL_REPLAY")
+	_ = viper.BindEnv("api.endpoint", "LINCTL_API_URL")
 }
 
-// initConfig reads in config file and ENV variables if set.
+// initConfig reads in config file and ENV variables if set. Without
+// --config, the defaults section of any .linctl.yaml in the current directory
+// or its parents is merged over the home config, the closest file winning.
 func initConfig() {
+	var home string
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
 	} else {
 		// Find home directory.
-		home, err := os.UserHomeDir()
+		var err error
+		home, err = os.UserHomeDir()
 		cobra.CheckErr(err)
 
 		// Search config in home directory with name ".linctl" (without extension).
@@ -117,9 +197,134 @@ func initConfig() {
 	viper.AutomaticEnv() // read in environment variables that match
 
 	// If a config file is found, read it in.
+	configFiles = nil
 	if err := viper.ReadInConfig(); err == nil {
-		if !plaintext && !jsonOut {
-			fmt.Fprintln(os.Stderr, color.New(color.FgGreen).Sprintf("✅ Using config file: %s", viper.ConfigFileUsed()))
+		configFiles = append(configFiles, viper.ConfigFileUsed())
+	}
+
+	if cfgFile == "" {
+		if cwd, err := os.Getwd(); err == nil {
+			for _, path := range findProjectConfigs(cwd, home) {
+				if err := mergeProjectConfig(path); err != nil {
+					output.Warn(fmt.Sprintf("Ignoring %s: %v", path, err))
+					continue
+				}
+				configFiles = append(configFiles, path)
+			}
 		}
 	}
 }
+
+// initConfigNotice reports the config files in use, once the output format
+// is known, so that it never precedes machine-readable output.
+func initConfigNotice() {
+	if len(configFiles) == 0 || plaintext || jsonOut {
+		return
+	}
+	label := "Using config file"
+	if len(configFiles) > 1 {
+		label = "Using config files"
+	}
+	output.Notice(fmt.Sprintf("%s: %s", label, strings.Join(configFiles, ", ")))
+}
+
+// initStyle applies --color and --ascii before anything is printed.
+func initStyle() {
+	output.SetASCII(viper.GetBool("ascii"))
+	if err := output.SetColorMode(viper.GetString("color")); err != nil {
+		_ = output.SetColorMode(output.ColorAuto)
+		output.Error(err.Error(), plaintext, false)
+		os.Exit(exitValidation)
+	}
+}
+
+// initOutputFormat applies --format. Table and plain are the default and
+// --plaintext output; every other format is rendered from the data --json
+// prints, so those switch commands to JSON output.
+func initOutputFormat() {
+	format := strings.ToLower(outFormat)
+	if format == "" {
+		// A configured default gives way to --json, --plaintext, --jq and --template.
+		if plaintext || jsonOut || jqExpr != "" || outTemplate != "" {
+			return
+		}
+		format = strings.ToLower(viper.GetString("defaults.format"))
+	}
+	if format == "" {
+		return
+	}
+	switch format {
+	case output.FormatTable:
+		if plaintext || jsonOut {
+			output.Error("--format table cannot be combined with --plaintext or --json", plaintext, false)
+			os.Exit(exitValidation)
+		}
+		return
+	case output.FormatPlain:
+		if jsonOut {
+			output.Error("--format plain cannot be combined with --json", true, false)
+			os.Exit(exitValidation)
+		}
+		plaintext = true
+		viper.Set("plaintext", true)
+		return
+	}
+
+	formatter, err := output.NewFormatter(format)
+	if err != nil {
+		output.Error(err.Error(), plaintext, false)
+		os.Exit(exitValidation)
+	}
+	if plaintext {
+		output.Error(fmt.Sprintf("--format %s cannot be combined with --plaintext", format), true, false)
+		os.Exit(exitValidation)
+	}
+	if jsonOut && format != output.FormatJSON {
+		output.Error(fmt.Sprintf("--format %s cannot be combined with --json", format), plaintext, false)
+		os.Exit(exitValidation)
+	}
+	if (jqExpr != "" || outTemplate != "") && format != output.FormatJSON {
+		output.Error(fmt.Sprintf("--format %s cannot be combined with --jq or --template", format), plaintext, false)
+		os.Exit(exitValidation)
+	}
+	output.SetFormatter(formatter)
+	jsonOut = true
+	viper.Set("json", true)
+}
+
+// initOutputFilter sets up --jq or --template. Both work on the data --json
+// prints, so either one switches commands to JSON output.
+func initOutputFilter() {
+	if jqExpr == "" && outTemplate == "" {
+		return
+	}
+	if jqExpr != "" && outTemplate != "" {
+		output.Error("--jq and --template cannot be used together", plaintext, false)
+		os.Exit(exitValidation)
+	}
+
+	var filter output.Filter
+	var err error
+	if jqExpr != "" {
+		filter, err = output.NewJQFilter(jqExpr)
+		if err != nil {
+			output.Error(fmt.Sprintf("Invalid --jq expression: %v", err), plaintext, false)
+			os.Exit(exitValidation)
+		}
+	} else {
+		filter, err = output.NewTemplateFilter(outTemplate)
+		if err != nil {
+			output.Error(fmt.Sprintf("Invalid --template: %v", err), plaintext, false)
+			os.Exit(exitValidation)
+		}
+	}
+
+	output.SetFilter(filter)
+	jsonOut = true
+	viper.Set("json", true)
+}
+
+// initProfile selects the auth profile given with --profile.
+func initProfile() {
+	auth.SetProfile(authProfile)
+}
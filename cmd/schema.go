@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+
+	"github.com/charlietran/linctl/pkg/api"
+	"github.com/charlietran/linctl/pkg/auth"
+	"github.com/charlietran/linctl/pkg/output"
+	"github.com/charlietran/linctl/pkg/schema"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Fetch Linear's GraphQL schema and validate queries offline",
+	Long: `Save Linear's GraphQL schema locally and check queries against it without
+network access.
+
+Validation reports unknown fields, arguments, types, enum values and
+variables as errors, and use of deprecated fields and enum values as
+warnings. Run it in CI to catch schema changes before they break scripts.
+
+Examples:
+  linctl schema fetch                          # Save the schema to ~/.linctl-schema.json
+  linctl schema validate my-query.graphql      # Check a query file
+  linctl schema validate                       # Check linctl's own built-in queries
+  linctl schema validate --strict *.graphql    # Fail on deprecation warnings too`,
+}
+
+var schemaFetchCmd = &cobra.Command{
+	Use:   "fetch",
+	Short: "Download the schema through introspection",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		path, err := schemaPath(cmd)
+		if err != nil {
+			output.Error(err.Error(), plaintext, jsonOut)
+			os.Exit(exitInternal)
+		}
+
+		// Get auth header
+		authHeader, err := auth.GetAuthHeader()
+		if err != nil {
+			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
+			os.Exit(exitAuth)
+		}
+
+		// Create API client
+		client := newClient(authHeader)
+
+		data, err := client.ExecuteRaw(context.Background(), schema.IntrospectionQuery, nil)
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to fetch schema: %v", err), plaintext, jsonOut)
+			os.Exit(exitCodeForError(err))
+		}
+
+		s, err := schema.Parse(data)
+		if err != nil {
+			output.Error(err.Error(), plaintext, jsonOut)
+			os.Exit(exitInternal)
+		}
+
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, data, "", "  "); err != nil {
+			output.Error(fmt.Sprintf("Failed to format schema: %v", err), plaintext, jsonOut)
+			os.Exit(exitInternal)
+		}
+		if err := os.WriteFile(path, pretty.Bytes(), 0644); err != nil {
+			output.Error(fmt.Sprintf("Failed to save schema: %v", err), plaintext, jsonOut)
+			os.Exit(exitInternal)
+		}
+
+		stats := s.Stats()
+		if jsonOut {
+			output.JSON(map[string]interface{}{
+				"path":       path,
+				"types":      stats.Types,
+				"queries":    stats.Queries,
+				"mutations":  stats.Mutations,
+				"deprecated": stats.Deprecated,
+			})
+		} else if plaintext {
+			fmt.Printf("Saved schema to %s (%d types, %d queries, %d mutations, %d deprecated fields)\n",
+				path, stats.Types, stats.Queries, stats.Mutations, stats.Deprecated)
+		} else {
+			fmt.Printf("%s Saved schema to %s\n", output.Green.Sprint(output.IconSuccess), output.Cyan.Sprint(path))
+			fmt.Printf("   %d types, %d queries, %d mutations, %s\n",
+				stats.Types, stats.Queries, stats.Mutations,
+				output.Yellow.Sprintf("%d deprecated fields", stats.Deprecated))
+		}
+	},
+}
+
+// schemaProblem is a diagnostic tied to the file it was found in.
+type schemaProblem struct {
+	File     string          `json:"file"`
+	Line     int             `json:"line"`
+	Column   int             `json:"column"`
+	Severity schema.Severity `json:"severity"`
+	Message  string          `json:"message"`
+}
+
+var schemaValidateCmd = &cobra.Command{
+	Use:   "validate [file.graphql...]",
+	Short: "Validate GraphQL documents against the saved schema",
+	Long: `Validate GraphQL documents against the schema saved by 'linctl schema fetch'.
+
+Pass one or more .graphql files (or - for stdin). Without files, or with
+--builtin, the queries built into linctl (pkg/api/queries.go, and the --fields
+queries with every field selected) are checked.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		builtin, _ := cmd.Flags().GetBool("builtin")
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		path, err := schemaPath(cmd)
+		if err != nil {
+			output.Error(err.Error(), plaintext, jsonOut)
+			os.Exit(exitInternal)
+		}
+		s, err := schema.Load(path)
+		if err != nil {
+			output.Error(err.Error(), plaintext, jsonOut)
+			os.Exit(schemaLoadExitCode(err))
+		}
+
+		var problems []schemaProblem
+		add := func(file string, diags []schema.Diagnostic) {
+			for _, d := range diags {
+				problems = append(problems, schemaProblem{
+					File: file, Line: d.Pos.Line, Column: d.Pos.Column, Severity: d.Severity, Message: d.Message,
+				})
+			}
+		}
+
+		documents := 0
+		for _, file := range args {
+			src, err := readSchemaInput(file)
+			if err != nil {
+				output.Error(err.Error(), plaintext, jsonOut)
+				os.Exit(exitValidation)
+			}
+			documents++
+			add(file, s.ValidateSource(src))
+		}
+
+		if builtin || len(args) == 0 {
+			name, src := api.QueriesSource()
+			queries, err := schema.ExtractQueries(name, src)
+			if err != nil {
+				output.Error(fmt.Sprintf("Failed to read built-in queries: %v", err), plaintext, jsonOut)
+				os.Exit(exitInternal)
+			}
+			for _, q := range queries {
+				documents++
+				diags := s.ValidateSource(q.Source)
+				for i := range diags {
+					diags[i] = q.Offset(diags[i])
+				}
+				add(name, diags)
+			}
+
+			fieldsQueries := api.FieldsQueries()
+			ops := make([]string, 0, len(fieldsQueries))
+			for op := range fieldsQueries {
+				ops = append(ops, op)
+			}
+			sort.Strings(ops)
+			for _, op := range ops {
+				documents++
+				add(fmt.Sprintf("pkg/api/fields.go (%s)", op), s.ValidateSource(fieldsQueries[op]))
+			}
+		}
+
+		errorCount, warningCount := 0, 0
+		for _, p := range problems {
+			if p.Severity == schema.SeverityError {
+				errorCount++
+			} else {
+				warningCount++
+			}
+		}
+
+		if jsonOut {
+			if problems == nil {
+				problems = []schemaProblem{}
+			}
+			output.JSON(map[string]interface{}{
+				"schema":    path,
+				"documents": documents,
+				"errors":    errorCount,
+				"warnings":  warningCount,
+				"problems":  problems,
+			})
+		} else {
+			for _, p := range problems {
+				severity := string(p.Severity)
+				if !plaintext {
+					if p.Severity == schema.SeverityError {
+						severity = output.Red.Sprint(severity)
+					} else {
+						severity = output.Yellow.Sprint(severity)
+					}
+				}
+				fmt.Printf("%s:%d:%d: %s: %s\n", p.File, p.Line, p.Column, severity, p.Message)
+			}
+
+			summary := fmt.Sprintf("%d documents checked: %d errors, %d warnings", documents, errorCount, warningCount)
+			if plaintext {
+				fmt.Println(summary)
+			} else if errorCount == 0 && warningCount == 0 {
+				fmt.Printf("%s %s\n", output.Green.Sprint(output.IconSuccess), summary)
+			} else {
+				fmt.Printf("%s %s\n", output.Yellow.Sprint(output.IconWarning), summary)
+			}
+		}
+
+		if errorCount > 0 || (strict && warningCount > 0) {
+			os.Exit(exitValidation)
+		}
+	},
+}
+
+// schemaPath returns the --schema flag, or the default schema location.
+func schemaPath(cmd *cobra.Command) (string, error) {
+	if path, _ := cmd.Flags().GetString("schema"); path != "" {
+		return path, nil
+	}
+	return schema.DefaultPath()
+}
+
+// schemaLoadExitCode maps a schema.Load failure to an exit code: a schema that
+// was never fetched is not found, an unreadable file is an internal error and
+// a file that is not an introspection result is a validation error.
+func schemaLoadExitCode(err error) int {
+	var pathErr *fs.PathError
+	switch {
+	case errors.Is(err, schema.ErrNoSchema):
+		return exitNotFound
+	case errors.As(err, &pathErr):
+		return exitInternal
+	}
+	return exitValidation
+}
+
+// readSchemaInput reads a document from a file, or from stdin for "-".
+func readSchemaInput(file string) (string, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return string(data), nil
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.AddCommand(schemaFetchCmd)
+	schemaCmd.AddCommand(schemaValidateCmd)
+
+	schemaCmd.PersistentFlags().String("schema", "", "Schema file (default is $HOME/.linctl-schema.json)")
+
+	schemaValidateCmd.Flags().Bool("builtin", false, "Also validate linctl's built-in queries")
+	schemaValidateCmd.Flags().Bool("strict", false, "Exit non-zero on deprecation warnings as well as errors")
+}
@@ -4,12 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
 
-	"github.com/charlietran/linctl/pkg/api"
 	"github.com/charlietran/linctl/pkg/auth"
 	"github.com/charlietran/linctl/pkg/output"
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -39,14 +36,14 @@ var teamListCmd = &cobra.Command{
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		// Get limit
-		limit, _ := cmd.Flags().GetInt("limit")
+		limit := listLimit(cmd)
 
 		// Get sort option
 		sortBy, _ := cmd.Flags().GetString("sort")
@@ -62,15 +59,15 @@ var teamListCmd = &cobra.Command{
 				orderBy = ""
 			default:
 				output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 		}
 
 		// Get teams
-		teams, err := client.GetTeams(context.Background(), limit, "", orderBy)
+		teams, err := client.GetTeamsPaginated(context.Background(), limit, orderBy)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to list teams: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Handle output
@@ -79,10 +76,7 @@ var teamListCmd = &cobra.Command{
 		} else if plaintext {
 			fmt.Println("Key\tName\tDescription\tPrivate\tIssues")
 			for _, team := range teams.Nodes {
-				description := team.Description
-				if len(description) > 50 {
-					description = description[:47] + "..."
-				}
+				description := output.Truncate(team.Description, 50)
 				fmt.Printf("%s\t%s\t%s\t%v\t%d\n",
 					team.Key,
 					team.Name,
@@ -97,20 +91,17 @@ var teamListCmd = &cobra.Command{
 			rows := [][]string{}
 
 			for _, team := range teams.Nodes {
-				description := team.Description
-				if len(description) > 40 {
-					description = description[:37] + "..."
-				}
+				description := output.Truncate(team.Description, 40)
 
 				privateStr := ""
 				if team.Private {
-					privateStr = color.New(color.FgYellow).Sprint("🔒 Yes")
+					privateStr = output.Yellow.Sprint(output.IconLock.Prefix("Yes"))
 				} else {
-					privateStr = color.New(color.FgGreen).Sprint("No")
+					privateStr = output.Green.Sprint("No")
 				}
 
 				rows = append(rows, []string{
-					color.New(color.FgCyan, color.Bold).Sprint(team.Key),
+					output.CyanBold.Sprint(team.Key),
 					team.Name,
 					description,
 					privateStr,
@@ -125,7 +116,7 @@ var teamListCmd = &cobra.Command{
 
 			if !plaintext && !jsonOut {
 				fmt.Printf("\n%s %d teams\n",
-					color.New(color.FgGreen).Sprint("✓"),
+					output.Green.Sprint(output.IconCheck),
 					len(teams.Nodes))
 			}
 		}
@@ -147,17 +138,17 @@ var teamGetCmd = &cobra.Command{
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		// Get team details
-		team, err := client.GetTeam(context.Background(), teamKey)
+		team, err := client.GetTeamDetails(context.Background(), teamKey)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to get team: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Handle output
@@ -175,23 +166,23 @@ var teamGetCmd = &cobra.Command{
 			// Formatted output
 			fmt.Println()
 			fmt.Printf("%s %s (%s)\n",
-				color.New(color.FgCyan, color.Bold).Sprint("👥 Team:"),
+				output.CyanBold.Sprint(output.IconTeam.Prefix("Team:")),
 				team.Name,
-				color.New(color.FgCyan).Sprint(team.Key))
-			fmt.Println(strings.Repeat("─", 50))
+				output.Cyan.Sprint(team.Key))
+			fmt.Println(output.Rule(50))
 
 			if team.Description != "" {
 				fmt.Printf("\n%s\n%s\n",
-					color.New(color.Bold).Sprint("Description:"),
+					output.Bold.Sprint("Description:"),
 					team.Description)
 			}
 
-			privateStr := color.New(color.FgGreen).Sprint("No")
+			privateStr := output.Green.Sprint("No")
 			if team.Private {
-				privateStr = color.New(color.FgYellow).Sprint("🔒 Yes")
+				privateStr = output.Yellow.Sprint(output.IconLock.Prefix("Yes"))
 			}
-			fmt.Printf("\n%s %s\n", color.New(color.Bold).Sprint("Private:"), privateStr)
-			fmt.Printf("%s %d\n", color.New(color.Bold).Sprint("Total Issues:"), team.IssueCount)
+			fmt.Printf("\n%s %s\n", output.Bold.Sprint("Private:"), privateStr)
+			fmt.Printf("%s %d\n", output.Bold.Sprint("Total Issues:"), team.IssueCount)
 			fmt.Println()
 		}
 	},
@@ -211,17 +202,17 @@ var teamMembersCmd = &cobra.Command{
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		// Get team members
 		members, err := client.GetTeamMembers(context.Background(), teamKey)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to get team members: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Handle output
@@ -248,24 +239,24 @@ var teamMembersCmd = &cobra.Command{
 
 			for _, member := range members.Nodes {
 				role := "Member"
-				roleColor := color.New(color.FgWhite)
+				roleColor := output.White
 				if member.Admin {
 					role = "Admin"
-					roleColor = color.New(color.FgYellow)
+					roleColor = output.Yellow
 				}
 				if member.IsMe {
 					role = role + " (You)"
-					roleColor = color.New(color.FgCyan, color.Bold)
+					roleColor = output.CyanBold
 				}
 
-				status := color.New(color.FgGreen).Sprint("✓ Active")
+				status := output.Green.Sprint(output.IconCheck.Prefix("Active"))
 				if !member.Active {
-					status = color.New(color.FgRed).Sprint("✗ Inactive")
+					status = output.Red.Sprint(output.IconCross.Prefix("Inactive"))
 				}
 
 				rows = append(rows, []string{
 					member.Name,
-					color.New(color.FgCyan).Sprint(member.Email),
+					output.Cyan.Sprint(member.Email),
 					roleColor.Sprint(role),
 					status,
 				})
@@ -278,9 +269,9 @@ var teamMembersCmd = &cobra.Command{
 
 			if !plaintext && !jsonOut {
 				fmt.Printf("\n%s %d members in team %s\n",
-					color.New(color.FgGreen).Sprint("✓"),
+					output.Green.Sprint(output.IconCheck),
 					len(members.Nodes),
-					color.New(color.FgCyan).Sprint(teamKey))
+					output.Cyan.Sprint(teamKey))
 			}
 		}
 	},
@@ -294,5 +285,6 @@ func init() {
 
 	// List command flags
 	teamListCmd.Flags().IntP("limit", "l", 50, "Maximum number of teams to return")
+	teamListCmd.Flags().Bool("all", false, "Fetch every team (ignores --limit)")
 	teamListCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
 }
@@ -4,12 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/charlietran/linctl/pkg/api"
 	"github.com/charlietran/linctl/pkg/auth"
 	"github.com/charlietran/linctl/pkg/output"
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -39,14 +37,14 @@ var userListCmd = &cobra.Command{
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		// Get filters
-		limit, _ := cmd.Flags().GetInt("limit")
+		limit := listLimit(cmd)
 		activeOnly, _ := cmd.Flags().GetBool("active")
 
 		// Get sort option
@@ -63,15 +61,15 @@ var userListCmd = &cobra.Command{
 				orderBy = ""
 			default:
 				output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
-				os.Exit(1)
+				os.Exit(exitValidation)
 			}
 		}
 
 		// Get users
-		users, err := client.GetUsers(context.Background(), limit, "", orderBy)
+		users, err := client.GetUsersPaginated(context.Background(), limit, orderBy)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to list users: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Filter active users if requested
@@ -110,24 +108,24 @@ var userListCmd = &cobra.Command{
 
 			for _, user := range filteredUsers {
 				role := "Member"
-				roleColor := color.New(color.FgWhite)
+				roleColor := output.White
 				if user.Admin {
 					role = "Admin"
-					roleColor = color.New(color.FgYellow)
+					roleColor = output.Yellow
 				}
 				if user.IsMe {
 					role = role + " (You)"
-					roleColor = color.New(color.FgCyan, color.Bold)
+					roleColor = output.CyanBold
 				}
 
-				status := color.New(color.FgGreen).Sprint("✓ Active")
+				status := output.Green.Sprint(output.IconCheck.Prefix("Active"))
 				if !user.Active {
-					status = color.New(color.FgRed).Sprint("✗ Inactive")
+					status = output.Red.Sprint(output.IconCross.Prefix("Inactive"))
 				}
 
 				rows = append(rows, []string{
 					user.Name,
-					color.New(color.FgCyan).Sprint(user.Email),
+					output.Cyan.Sprint(user.Email),
 					roleColor.Sprint(role),
 					status,
 				})
@@ -140,7 +138,7 @@ var userListCmd = &cobra.Command{
 
 			if !plaintext && !jsonOut {
 				fmt.Printf("\n%s %d users\n",
-					color.New(color.FgGreen).Sprint("✓"),
+					output.Green.Sprint(output.IconCheck),
 					len(filteredUsers))
 			}
 		}
@@ -162,17 +160,17 @@ var userGetCmd = &cobra.Command{
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		// Get user details
 		user, err := client.GetUser(context.Background(), email)
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to get user: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Handle output
@@ -191,35 +189,35 @@ var userGetCmd = &cobra.Command{
 			// Formatted output
 			fmt.Println()
 			fmt.Printf("%s %s\n",
-				color.New(color.FgCyan, color.Bold).Sprint("👤 User:"),
+				output.CyanBold.Sprint(output.IconUser.Prefix("User:")),
 				user.Name)
-			fmt.Println(strings.Repeat("─", 50))
+			fmt.Println(output.Rule(50))
 
-			fmt.Printf("\n%s %s\n", color.New(color.Bold).Sprint("Email:"),
-				color.New(color.FgCyan).Sprint(user.Email))
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("ID:"), user.ID)
+			fmt.Printf("\n%s %s\n", output.Bold.Sprint("Email:"),
+				output.Cyan.Sprint(user.Email))
+			fmt.Printf("%s %s\n", output.Bold.Sprint("ID:"), user.ID)
 
 			role := "Member"
-			roleColor := color.New(color.FgWhite)
+			roleColor := output.White
 			if user.Admin {
 				role = "Admin"
-				roleColor = color.New(color.FgYellow)
+				roleColor = output.Yellow
 			}
 			if user.IsMe {
 				role = role + " (You)"
-				roleColor = color.New(color.FgCyan, color.Bold)
+				roleColor = output.CyanBold
 			}
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Role:"), roleColor.Sprint(role))
+			fmt.Printf("%s %s\n", output.Bold.Sprint("Role:"), roleColor.Sprint(role))
 
-			status := color.New(color.FgGreen).Sprint("✓ Active")
+			status := output.Green.Sprint(output.IconCheck.Prefix("Active"))
 			if !user.Active {
-				status = color.New(color.FgRed).Sprint("✗ Inactive")
+				status = output.Red.Sprint(output.IconCross.Prefix("Inactive"))
 			}
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Status:"), status)
+			fmt.Printf("%s %s\n", output.Bold.Sprint("Status:"), status)
 
 			if user.AvatarURL != "" {
-				fmt.Printf("\n%s\n%s\n", color.New(color.Bold).Sprint("Avatar:"),
-					color.New(color.FgBlue).Sprint(user.AvatarURL))
+				fmt.Printf("\n%s\n%s\n", output.Bold.Sprint("Avatar:"),
+					output.Blue.Sprint(user.AvatarURL))
 			}
 			fmt.Println()
 		}
@@ -238,17 +236,17 @@ var userMeCmd = &cobra.Command{
 		authHeader, err := auth.GetAuthHeader()
 		if err != nil {
 			output.Error(fmt.Sprintf("Authentication failed: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitAuth)
 		}
 
 		// Create API client
-		client := api.NewClient(authHeader)
+		client := newClient(authHeader)
 
 		// Get current user
 		user, err := client.GetViewer(context.Background())
 		if err != nil {
 			output.Error(fmt.Sprintf("Failed to get current user: %v", err), plaintext, jsonOut)
-			os.Exit(1)
+			os.Exit(exitCodeForError(err))
 		}
 
 		// Handle output
@@ -267,31 +265,31 @@ var userMeCmd = &cobra.Command{
 			// Formatted output
 			fmt.Println()
 			fmt.Printf("%s %s\n",
-				color.New(color.FgCyan, color.Bold).Sprint("👤 Current User:"),
+				output.CyanBold.Sprint(output.IconUser.Prefix("Current User:")),
 				user.Name)
-			fmt.Println(strings.Repeat("─", 50))
+			fmt.Println(output.Rule(50))
 
-			fmt.Printf("\n%s %s\n", color.New(color.Bold).Sprint("Email:"),
-				color.New(color.FgCyan).Sprint(user.Email))
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("ID:"), user.ID)
+			fmt.Printf("\n%s %s\n", output.Bold.Sprint("Email:"),
+				output.Cyan.Sprint(user.Email))
+			fmt.Printf("%s %s\n", output.Bold.Sprint("ID:"), user.ID)
 
 			role := "Member"
-			roleColor := color.New(color.FgWhite)
+			roleColor := output.White
 			if user.Admin {
 				role = "Admin"
-				roleColor = color.New(color.FgYellow, color.Bold)
+				roleColor = output.YellowBold
 			}
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Role:"), roleColor.Sprint(role))
+			fmt.Printf("%s %s\n", output.Bold.Sprint("Role:"), roleColor.Sprint(role))
 
-			status := color.New(color.FgGreen).Sprint("✓ Active")
+			status := output.Green.Sprint(output.IconCheck.Prefix("Active"))
 			if !user.Active {
-				status = color.New(color.FgRed).Sprint("✗ Inactive")
+				status = output.Red.Sprint(output.IconCross.Prefix("Inactive"))
 			}
-			fmt.Printf("%s %s\n", color.New(color.Bold).Sprint("Status:"), status)
+			fmt.Printf("%s %s\n", output.Bold.Sprint("Status:"), status)
 
 			if user.AvatarURL != "" {
-				fmt.Printf("\n%s\n%s\n", color.New(color.Bold).Sprint("Avatar:"),
-					color.New(color.FgBlue).Sprint(user.AvatarURL))
+				fmt.Printf("\n%s\n%s\n", output.Bold.Sprint("Avatar:"),
+					output.Blue.Sprint(user.AvatarURL))
 			}
 			fmt.Println()
 		}
@@ -306,6 +304,7 @@ func init() {
 
 	// List command flags
 	userListCmd.Flags().IntP("limit", "l", 50, "Maximum number of users to return")
+	userListCmd.Flags().Bool("all", false, "Fetch every user (ignores --limit)")
 	userListCmd.Flags().BoolP("active", "a", false, "Show only active users")
 	userListCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
 }
@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charlietran/linctl/pkg/api"
+	"github.com/charlietran/linctl/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// viewAPI captures the subset of API client used by view commands.
+type viewAPI interface {
+	GetTeam(ctx context.Context, key string) (*api.Team, error)
+	GetCustomViewsPaginated(ctx context.Context, limit int) (*api.CustomViews, error)
+	GetCustomViewIssuesPaginated(ctx context.Context, id string, filter map[string]interface{}, limit int, orderBy string) (*api.Issues, error)
+	CreateCustomView(ctx context.Context, input map[string]interface{}) (*api.CustomView, error)
+}
+
+// Injection point for testing
+var newViewAPIClient = func(authHeader string) viewAPI { return newClient(authHeader) }
+
+// viewCmd represents the view command
+var viewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Run and save Linear custom views",
+	Long: `List, run and save Linear custom views, the saved issue filters shown in
+the sidebar of the web app.
+
+Examples:
+  linctl view list
+  linctl view run "Triage"
+  linctl view save "My bugs" -- issue list --assignee me --label bug`,
+}
+
+var viewListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List custom views",
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		authHeader, err := getAuthHeader()
+		if err != nil {
+			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
+			os.Exit(exitAuth)
+		}
+
+		client := newViewAPIClient(authHeader)
+
+		views, err := client.GetCustomViewsPaginated(context.Background(), 0)
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to list views: %v", err), plaintext, jsonOut)
+			os.Exit(exitCodeForError(err))
+		}
+
+		issueViews := make([]api.CustomView, 0, len(views.Nodes))
+		for _, view := range views.Nodes {
+			if isIssueView(view) {
+				issueViews = append(issueViews, view)
+			}
+		}
+
+		if len(issueViews) == 0 {
+			output.Info("No views found", plaintext, jsonOut)
+			return
+		}
+		startPager()
+
+		if jsonOut {
+			output.JSON(issueViews)
+			return
+		}
+
+		if plaintext {
+			fmt.Println("# Views")
+			for _, view := range issueViews {
+				fmt.Printf("## %s\n", view.Name)
+				fmt.Printf("- **ID**: %s\n", view.ID)
+				fmt.Printf("- **Team**: %s\n", viewTeam(view))
+				fmt.Printf("- **Shared**: %t\n", view.Shared)
+				if view.Creator != nil {
+					fmt.Printf("- **Creator**: %s\n", view.Creator.Name)
+				}
+				fmt.Printf("- **Updated**: %s\n", view.UpdatedAt.Format("2006-01-02"))
+				if view.Description != nil && *view.Description != "" {
+					fmt.Printf("- **Description**: %s\n", *view.Description)
+				}
+				fmt.Println()
+			}
+			fmt.Printf("\nTotal: %d views\n", len(issueViews))
+			return
+		}
+
+		headers := []string{"Name", "Team", "Shared", "Creator", "Updated"}
+		rows := make([][]string, len(issueViews))
+		for i, view := range issueViews {
+			shared := output.Faint.Sprint("Personal")
+			if view.Shared {
+				shared = output.Green.Sprint("Shared")
+			}
+			creator := ""
+			if view.Creator != nil {
+				creator = view.Creator.Name
+			}
+			rows[i] = []string{
+				view.Name,
+				viewTeam(view),
+				shared,
+				creator,
+				view.UpdatedAt.Format("2006-01-02"),
+			}
+		}
+
+		output.Table(output.TableData{
+			Headers: headers,
+			Rows:    rows,
+			Shrink:  "Name",
+		}, plaintext, jsonOut)
+
+		fmt.Printf("\n%s %d views\n", output.Green.Sprint(output.IconCheck), len(issueViews))
+	},
+}
+
+var viewRunCmd = &cobra.Command{
+	Use:   "run <name>",
+	Short: "List the issues in a custom view",
+	Long: `List the issues in a custom view, found by name (case-insensitive), ID or
+the slug in its URL. Output matches 'linctl issue list'.
+
+Examples:
+  linctl view run "Triage"
+  linctl view run triage --all --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		authHeader, err := getAuthHeader()
+		if err != nil {
+			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
+			os.Exit(exitAuth)
+		}
+
+		client := newViewAPIClient(authHeader)
+
+		views, err := client.GetCustomViewsPaginated(context.Background(), 0)
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to list views: %v", err), plaintext, jsonOut)
+			os.Exit(exitCodeForError(err))
+		}
+
+		view, err := findView(views.Nodes, args[0])
+		if err != nil {
+			output.Error(err.Error(), plaintext, jsonOut)
+			os.Exit(exitNotFound)
+		}
+		if !isIssueView(*view) {
+			output.Error(fmt.Sprintf("View '%s' lists %ss; only issue views can be run", view.Name, strings.ToLower(view.ModelName)), plaintext, jsonOut)
+			os.Exit(exitValidation)
+		}
+
+		sortBy, _ := cmd.Flags().GetString("sort")
+		orderBy := ""
+		switch sortBy {
+		case "created", "createdAt":
+			orderBy = "createdAt"
+		case "updated", "updatedAt":
+			orderBy = "updatedAt"
+		case "linear", "":
+			// Use empty string for Linear's default sort
+			orderBy = ""
+		default:
+			output.Error(fmt.Sprintf("Invalid sort option: %s. Valid options are: linear, created, updated", sortBy), plaintext, jsonOut)
+			os.Exit(exitValidation)
+		}
+
+		issues, err := client.GetCustomViewIssuesPaginated(context.Background(), view.ID, nil, listLimit(cmd), orderBy)
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to run view '%s': %v", view.Name, err), plaintext, jsonOut)
+			os.Exit(exitCodeForError(err))
+		}
+
+		renderIssueCollection(issues, plaintext, jsonOut, "No issues in this view", "issues", "# "+view.Name)
+	},
+}
+
+var viewSaveCmd = &cobra.Command{
+	Use:   "save <name> -- issue list [flags]",
+	Short: "Save 'issue list' flags as a custom view",
+	Long: `Save the filters of an 'issue list' command as a new Linear custom view, so
+the same slice of issues shows up in the web app and in 'linctl view run'.
+
+Every filter flag of 'issue list' can be saved, including --filter. Unlike
+'issue list', a saved view is not limited to the last 6 months unless you pass
+--newer-than; relative times such as 2_weeks_ago are fixed when the view is
+saved. With a single --team the view is created in that team.
+
+Examples:
+  linctl view save "My bugs" -- issue list --assignee me --label bug
+  linctl view save "ENG triage" --shared -- issue list --team ENG --unassigned`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		plaintext := viper.GetBool("plaintext")
+		jsonOut := viper.GetBool("json")
+
+		scratch, err := parseSavedIssueList(args, cmd.ArgsLenAtDash())
+		if err != nil {
+			output.Error(err.Error(), plaintext, jsonOut)
+			os.Exit(exitValidation)
+		}
+		name := args[0]
+		filter := buildIssueFilter(scratch)
+
+		authHeader, err := getAuthHeader()
+		if err != nil {
+			output.Error("Not authenticated. Run 'linctl auth' first.", plaintext, jsonOut)
+			os.Exit(exitAuth)
+		}
+
+		client := newViewAPIClient(authHeader)
+
+		input := map[string]interface{}{
+			"name":       name,
+			"filterData": filter,
+		}
+		if shared, _ := cmd.Flags().GetBool("shared"); shared {
+			input["shared"] = true
+		}
+		if description, _ := cmd.Flags().GetString("description"); description != "" {
+			input["description"] = description
+		}
+		if teams, _ := scratch.Flags().GetStringArray("team"); len(teams) == 1 {
+			team, err := client.GetTeam(context.Background(), teams[0])
+			if err != nil {
+				output.Error(fmt.Sprintf("Failed to find team '%s': %v", teams[0], err), plaintext, jsonOut)
+				os.Exit(exitCodeForError(err))
+			}
+			input["teamId"] = team.ID
+		}
+
+		view, err := client.CreateCustomView(context.Background(), input)
+		if err != nil {
+			output.Error(fmt.Sprintf("Failed to save view: %v", err), plaintext, jsonOut)
+			os.Exit(exitCodeForError(err))
+		}
+
+		if jsonOut {
+			output.JSON(view)
+		} else if plaintext {
+			fmt.Printf("# View Saved\n\n")
+			fmt.Printf("- **Name**: %s\n", view.Name)
+			fmt.Printf("- **ID**: %s\n", view.ID)
+			fmt.Printf("- **Team**: %s\n", viewTeam(*view))
+			fmt.Printf("- **Shared**: %t\n", view.Shared)
+		} else {
+			fmt.Printf("%s Saved view %s\n", output.Green.Sprint(output.IconCheck), output.Bold.Sprint(view.Name))
+			fmt.Printf("%s %s\n", output.Faint.Sprint("Run it with:"), output.Cyan.Sprintf("linctl view run %q", view.Name))
+		}
+	},
+}
+
+// parseSavedIssueList parses the "issue list <flags>" after "--" in the
+// arguments of view save into a command holding the issue list filter flags.
+func parseSavedIssueList(args []string, dash int) (*cobra.Command, error) {
+	if dash < 0 {
+		return nil, fmt.Errorf("nothing to save; use: linctl view save <name> -- issue list <flags>")
+	}
+	if dash != 1 {
+		return nil, fmt.Errorf("expected a single view name before --")
+	}
+	rest := args[dash:]
+	if len(rest) < 2 || rest[0] != "issue" || (rest[1] != "list" && rest[1] != "ls") {
+		return nil, fmt.Errorf("expected 'issue list' after --, e.g. linctl view save %q -- issue list --team ENG", args[0])
+	}
+
+	scratch := &cobra.Command{Use: "list"}
+	addIssueFilterFlags(scratch)
+	if err := scratch.Flags().Parse(rest[2:]); err != nil {
+		return nil, fmt.Errorf("cannot save these issue list flags: %v", err)
+	}
+	if extra := scratch.Flags().Args(); len(extra) > 0 {
+		return nil, fmt.Errorf("unexpected argument %q after 'issue list'", extra[0])
+	}
+	if !scratch.Flags().Changed("newer-than") {
+		_ = scratch.Flags().Set("newer-than", "all_time")
+	}
+	return scratch, nil
+}
+
+// findView finds a view by ID, URL slug or case-insensitive name.
+func findView(views []api.CustomView, ref string) (*api.CustomView, error) {
+	var matches []*api.CustomView
+	for i := range views {
+		view := &views[i]
+		if view.ID == ref || view.SlugId == ref {
+			return view, nil
+		}
+		if strings.EqualFold(view.Name, ref) {
+			matches = append(matches, view)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("view '%s' not found; run 'linctl view list' to see your views", ref)
+	case 1:
+		return matches[0], nil
+	}
+	ids := make([]string, len(matches))
+	for i, view := range matches {
+		ids[i] = fmt.Sprintf("%s (%s)", view.ID, viewTeam(*view))
+	}
+	return nil, fmt.Errorf("%d views are named '%s'; run one by ID: %s", len(matches), ref, strings.Join(ids, ", "))
+}
+
+// isIssueView reports whether a view lists issues, as opposed to projects.
+func isIssueView(view api.CustomView) bool {
+	return view.ModelName == "" || strings.EqualFold(view.ModelName, "Issue")
+}
+
+func viewTeam(view api.CustomView) string {
+	if view.Team != nil {
+		return view.Team.Key
+	}
+	return "Workspace"
+}
+
+func init() {
+	rootCmd.AddCommand(viewCmd)
+	viewCmd.AddCommand(viewListCmd)
+	viewCmd.AddCommand(viewRunCmd)
+	viewCmd.AddCommand(viewSaveCmd)
+
+	viewRunCmd.Flags().IntP("limit", "l", 50, "Maximum number of issues to fetch")
+	viewRunCmd.Flags().Bool("all", false, "Fetch every issue in the view (ignores --limit)")
+	viewRunCmd.Flags().StringP("sort", "o", "linear", "Sort order: linear (default), created, updated")
+
+	viewSaveCmd.Flags().Bool("shared", false, "Share the view with your workspace or team")
+	viewSaveCmd.Flags().StringP("description", "d", "", "View description")
+}
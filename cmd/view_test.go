@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charlietran/linctl/pkg/api"
+	"github.com/spf13/viper"
+)
+
+type mockViewClient struct {
+	views   []api.CustomView
+	ranID   string
+	created map[string]interface{}
+}
+
+func (m *mockViewClient) GetTeam(ctx context.Context, key string) (*api.Team, error) {
+	return &api.Team{ID: "team-" + key, Key: key}, nil
+}
+
+func (m *mockViewClient) GetCustomViewsPaginated(ctx context.Context, limit int) (*api.CustomViews, error) {
+	return &api.CustomViews{Nodes: m.views}, nil
+}
+
+func (m *mockViewClient) GetCustomViewIssuesPaginated(ctx context.Context, id string, filter map[string]interface{}, limit int, orderBy string) (*api.Issues, error) {
+	m.ranID = id
+	return &api.Issues{Nodes: []api.Issue{{Identifier: "ENG-7", Title: "Crash on save"}}}, nil
+}
+
+func (m *mockViewClient) CreateCustomView(ctx context.Context, input map[string]interface{}) (*api.CustomView, error) {
+	m.created = input
+	name, _ := input["name"].(string)
+	shared, _ := input["shared"].(bool)
+	return &api.CustomView{ID: "v-new", Name: name, Shared: shared}, nil
+}
+
+func withInjectedViewClient(t *testing.T, mc *mockViewClient, fn func()) {
+	t.Helper()
+	oldNew := newViewAPIClient
+	oldAuth := getAuthHeader
+	newViewAPIClient = func(_ string) viewAPI { return mc }
+	getAuthHeader = func() (string, error) { return "Bearer test", nil }
+	defer func() { newViewAPIClient = oldNew; getAuthHeader = oldAuth }()
+	fn()
+}
+
+func TestFindView(t *testing.T) {
+	views := []api.CustomView{
+		{ID: "v1", Name: "Triage", SlugId: "a1b2", Team: &api.Team{Key: "ENG"}},
+		{ID: "v2", Name: "My bugs"},
+		{ID: "v3", Name: "triage", Team: &api.Team{Key: "WEB"}},
+	}
+
+	for ref, want := range map[string]string{"v2": "v2", "my BUGS": "v2", "a1b2": "v1"} {
+		view, err := findView(views, ref)
+		if err != nil || view.ID != want {
+			t.Errorf("findView(%q) = %v, %v; want %s", ref, view, err, want)
+		}
+	}
+
+	if _, err := findView(views, "TRIAGE"); err == nil || !contains(err.Error(), "v1 (ENG), v3 (WEB)") {
+		t.Errorf("Expected an ambiguity error listing both views, got %v", err)
+	}
+	if _, err := findView(views, "Roadmap"); err == nil || !contains(err.Error(), "not found") {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestParseSavedIssueList(t *testing.T) {
+	tests := []struct {
+		args    []string
+		dash    int
+		wantErr string
+	}{
+		{[]string{"Bugs"}, -1, "nothing to save"},
+		{[]string{"Bugs", "extra", "issue", "list"}, 2, "single view name"},
+		{[]string{"Bugs", "project", "list"}, 1, "expected 'issue list'"},
+		{[]string{"Bugs", "issue", "list", "--sort", "updated"}, 1, "unknown flag: --sort"},
+		{[]string{"Bugs", "issue", "list", "stray"}, 1, `unexpected argument "stray"`},
+	}
+	for _, tt := range tests {
+		_, err := parseSavedIssueList(tt.args, tt.dash)
+		if err == nil || !contains(err.Error(), tt.wantErr) {
+			t.Errorf("parseSavedIssueList(%v) error = %v, want %q", tt.args, err, tt.wantErr)
+		}
+	}
+
+	scratch, err := parseSavedIssueList([]string{"Bugs", "issue", "ls", "--label", "bug", "-s", "Todo"}, 1)
+	if err != nil {
+		t.Fatalf("parseSavedIssueList failed: %v", err)
+	}
+	if newerThan, _ := scratch.Flags().GetString("newer-than"); newerThan != "all_time" {
+		t.Errorf("Expected saved views to drop the 6 month default, got newer-than %q", newerThan)
+	}
+}
+
+func TestViewSave_CreatesViewFromIssueListFlags(t *testing.T) {
+	mc := &mockViewClient{}
+	withInjectedViewClient(t, mc, func() {
+		viper.Set("plaintext", true)
+		viper.Set("json", false)
+		defer viper.Set("plaintext", false)
+
+		if err := viewSaveCmd.Flags().Parse([]string{"--shared", "ENG bugs", "--", "issue", "list", "--team", "ENG", "--label", "bug", "--include-completed"}); err != nil {
+			t.Fatal(err)
+		}
+		out := captureStdout(t, func() { viewSaveCmd.Run(viewSaveCmd, viewSaveCmd.Flags().Args()) })
+		if !contains(out, "# View Saved") || !contains(out, "**Name**: ENG bugs") {
+			t.Fatalf("unexpected output:\n%s", out)
+		}
+	})
+
+	if mc.created["teamId"] != "team-ENG" || mc.created["shared"] != true {
+		t.Errorf("unexpected input: %v", mc.created)
+	}
+	got := filterJSON(t, mc.created["filterData"].(map[string]interface{}))
+	want := `{"labels":{"some":{"name":{"eqIgnoreCase":"bug"}}},"team":{"key":{"eq":"ENG"}}}`
+	if got != want {
+		t.Errorf("filterData = %s, want %s", got, want)
+	}
+}
+
+func TestViewRun_ResolvesNameAndRendersIssues(t *testing.T) {
+	mc := &mockViewClient{views: []api.CustomView{{ID: "v1", Name: "Triage", ModelName: "Issue"}}}
+	withInjectedViewClient(t, mc, func() {
+		viper.Set("plaintext", true)
+		viper.Set("json", false)
+		defer viper.Set("plaintext", false)
+
+		out := captureStdout(t, func() { viewRunCmd.Run(viewRunCmd, []string{"triage"}) })
+		if !contains(out, "# Triage") || !contains(out, "**ID**: ENG-7") {
+			t.Fatalf("unexpected output:\n%s", out)
+		}
+	})
+	if mc.ranID != "v1" {
+		t.Errorf("Expected view v1 to run, got %q", mc.ranID)
+	}
+}
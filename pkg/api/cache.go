@@ -0,0 +1,218 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultCacheTTL is how long cached lookups stay fresh.
+const DefaultCacheTTL = time.Hour
+
+// cacheSuffix marks the files managed by Cache so Clear leaves anything else alone.
+const cacheSuffix = ".json"
+
+// Cache stores responses of rarely-changing lookups (teams, workflow states,
+// labels, users, the organization) on disk so repeated commands skip the API.
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// cacheEntry is the on-disk format of a cached response.
+type cacheEntry struct {
+	Operation string          `json:"operation"`
+	StoredAt  time.Time       `json:"storedAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// CacheStatus summarizes the contents of the cache directory.
+type CacheStatus struct {
+	Dir     string        `json:"dir"`
+	TTL     time.Duration `json:"ttl"`
+	Entries int           `json:"entries"`
+	Fresh   int           `json:"fresh"`
+	Expired int           `json:"expired"`
+	Bytes   int64         `json:"bytes"`
+}
+
+// DefaultCacheDir returns the per-user cache directory for linctl.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	return filepath.Join(dir, "linctl"), nil
+}
+
+// NewCache returns a cache in dir whose entries expire after ttl.
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// Dir returns the directory holding the cache files.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// cacheKey identifies a request. The credentials are part of the key so
+// different workspaces and profiles never share entries.
+func cacheKey(authHeader, query string, variables map[string]interface{}) (string, error) {
+	vars, err := json.Marshal(variables)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal variables: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(authHeader))
+	h.Write([]byte{0})
+	h.Write([]byte(query))
+	h.Write([]byte{0})
+	h.Write(vars)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+cacheSuffix)
+}
+
+// get returns the cached data for key if it exists and has not expired.
+func (c *Cache) get(key string) (json.RawMessage, bool) {
+	raw, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, false
+	}
+	if c.now().Sub(entry.StoredAt) > c.ttl {
+		return nil, false
+	}
+	return entry.Data, true
+}
+
+// set stores data for key, replacing the file atomically so concurrent
+// readers never see a partial entry.
+func (c *Cache) set(key, operation string, data json.RawMessage) error {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(cacheEntry{Operation: operation, StoredAt: c.now(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// Clear removes every cached entry and returns how many were deleted.
+func (c *Cache) Clear() (int, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), cacheSuffix) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.dir, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Status reports how many entries the cache holds and how many are still fresh.
+func (c *Cache) Status() (*CacheStatus, error) {
+	status := &CacheStatus{Dir: c.dir, TTL: c.ttl}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return status, nil
+		}
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), cacheSuffix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		status.Entries++
+		status.Bytes += info.Size()
+
+		if _, fresh := c.get(strings.TrimSuffix(entry.Name(), cacheSuffix)); fresh {
+			status.Fresh++
+		} else {
+			status.Expired++
+		}
+	}
+	return status, nil
+}
+
+// SetCache enables caching of lookup queries. A nil cache disables it.
+func (c *Client) SetCache(cache *Cache) {
+	c.cache = cache
+}
+
+// executeCached runs a read-only lookup through the cache when one is
+// configured. Cache failures never fail the request; they only cost a fetch.
+func (c *Client) executeCached(ctx context.Context, operation, query string, variables map[string]interface{}, result interface{}) error {
+	if c.cache == nil {
+		return c.Execute(ctx, query, variables, result)
+	}
+
+	key, err := cacheKey(c.authHeader, query, variables)
+	if err != nil {
+		return c.Execute(ctx, query, variables, result)
+	}
+
+	data, ok := c.cache.get(key)
+	if ok && c.tracer != nil {
+		c.traceCacheHit(query, variables, len(data))
+	}
+	if !ok {
+		if err := c.Execute(ctx, query, variables, &data); err != nil {
+			return err
+		}
+		_ = c.cache.set(key, operation, data)
+	}
+
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to unmarshal data: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newUsersServer answers every request with a single user and counts the requests.
+func newUsersServer(t *testing.T, requests *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		_, _ = w.Write([]byte(`{"data":{"users":{"nodes":[{"id":"user-1","name":"Ada"}],"pageInfo":{"hasNextPage":false}}}}`))
+	}))
+}
+
+func TestCachedLookupSkipsAPIUntilExpired(t *testing.T) {
+	var requests int32
+	server := newUsersServer(t, &requests)
+	defer server.Close()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewCache(t.TempDir(), time.Hour)
+	cache.now = func() time.Time { return now }
+
+	client := NewClientWithURL(server.URL, "test-key")
+	client.SetCache(cache)
+
+	for i := 0; i < 3; i++ {
+		users, err := client.GetUsers(context.Background(), 100, "", "")
+		if err != nil {
+			t.Fatalf("GetUsers failed: %v", err)
+		}
+		if len(users.Nodes) != 1 || users.Nodes[0].Name != "Ada" {
+			t.Fatalf("Unexpected users: %+v", users.Nodes)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request with a warm cache, got %d", requests)
+	}
+
+	// Different variables are cached separately
+	if _, err := client.GetUsers(context.Background(), 50, "", ""); err != nil {
+		t.Fatalf("GetUsers failed: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected a new request for different variables, got %d", requests)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := client.GetUsers(context.Background(), 100, "", ""); err != nil {
+		t.Fatalf("GetUsers failed: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Expected expired entry to be refetched, got %d requests", requests)
+	}
+}
+
+func TestCacheIsKeyedByCredentials(t *testing.T) {
+	var requests int32
+	server := newUsersServer(t, &requests)
+	defer server.Close()
+
+	cache := NewCache(t.TempDir(), time.Hour)
+	for _, key := range []string{"key-a", "key-b", "key-a"} {
+		client := NewClientWithURL(server.URL, key)
+		client.SetCache(cache)
+		if _, err := client.GetUsers(context.Background(), 100, "", ""); err != nil {
+			t.Fatalf("GetUsers failed: %v", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected one request per credential, got %d", requests)
+	}
+}
+
+func TestCacheStatusAndClear(t *testing.T) {
+	var requests int32
+	server := newUsersServer(t, &requests)
+	defer server.Close()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewCache(t.TempDir(), time.Hour)
+	cache.now = func() time.Time { return now }
+
+	client := NewClientWithURL(server.URL, "test-key")
+	client.SetCache(cache)
+	_, _ = client.GetUsers(context.Background(), 100, "", "")
+	now = now.Add(2 * time.Hour)
+	_, _ = client.GetUsers(context.Background(), 50, "", "")
+
+	status, err := cache.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if status.Entries != 2 || status.Fresh != 1 || status.Expired != 1 || status.Bytes == 0 {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+
+	removed, err := cache.Clear()
+	if err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 entries removed, got %d", removed)
+	}
+	if status, _ := cache.Status(); status.Entries != 0 {
+		t.Errorf("Expected empty cache after clear, got %+v", status)
+	}
+}
+
+func TestCacheMissingDirectory(t *testing.T) {
+	cache := NewCache(t.TempDir()+"/missing", time.Hour)
+	if removed, err := cache.Clear(); err != nil || removed != 0 {
+		t.Errorf("Clear on missing dir = %d, %v", removed, err)
+	}
+	if status, err := cache.Status(); err != nil || status.Entries != 0 {
+		t.Errorf("Status on missing dir = %+v, %v", status, err)
+	}
+}
+
+func TestTeamIssueCountsBypassCache(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "query Team(") && strings.Contains(string(body), "issueCount") {
+			t.Errorf("Cached team query must not select issueCount: %s", body)
+		}
+		if strings.Contains(string(body), "query Teams(") {
+			_, _ = w.Write([]byte(`{"data":{"teams":{"nodes":[{"id":"team-1","key":"ENG","issueCount":7}],"pageInfo":{"hasNextPage":false}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"team":{"id":"team-1","key":"ENG","issueCount":7}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	client.SetCache(NewCache(t.TempDir(), time.Hour))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTeam(context.Background(), "ENG"); err != nil {
+			t.Fatalf("GetTeam failed: %v", err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected GetTeam to be cached, got %d requests", requests)
+	}
+
+	for i := 0; i < 2; i++ {
+		team, err := client.GetTeamDetails(context.Background(), "ENG")
+		if err != nil {
+			t.Fatalf("GetTeamDetails failed: %v", err)
+		}
+		if team.IssueCount != 7 {
+			t.Errorf("Expected issue count 7, got %d", team.IssueCount)
+		}
+	}
+	if requests != 3 {
+		t.Errorf("Expected GetTeamDetails to bypass the cache, got %d requests", requests)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTeams(context.Background(), 50, "", ""); err != nil {
+			t.Fatalf("GetTeams failed: %v", err)
+		}
+	}
+	if requests != 5 {
+		t.Errorf("Expected GetTeams to bypass the cache, got %d requests", requests)
+	}
+}
@@ -0,0 +1,359 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCassetteMiss is returned in replay mode when no recorded interaction
+// matches a request. It is never retried.
+var ErrCassetteMiss = errors.New("no recorded response")
+
+// CassetteMode selects whether a Cassette records live traffic or replays it.
+type CassetteMode int
+
+const (
+	CassetteRecord CassetteMode = iota + 1
+	CassetteReplay
+)
+
+// Interaction is one recorded GraphQL request/response pair. Credentials are
+// never written to a cassette.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the request half of an Interaction.
+type RecordedRequest struct {
+	Operation string                 `json:"operation"`
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+	Hash      string                 `json:"hash"`
+}
+
+// RecordedResponse is the response half of an Interaction.
+type RecordedResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+// Cassette is an http.RoundTripper that saves GraphQL interactions to a
+// directory, one JSON file each, or serves them back from it.
+//
+// Replay matches a request by its exact query and variables first, then by
+// its query and variables with date-time values ignored, so requests whose
+// filters are computed from the current time (such as --newer-than
+// 2_weeks_ago) still replay. Once every match for a request has been used,
+// the last one is served again. Anything else, including the same operation
+// with other variables, is a miss.
+type Cassette struct {
+	dir  string
+	mode CassetteMode
+	// Next performs real requests while recording; http.DefaultTransport when nil.
+	Next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+var cassetteNamePattern = regexp.MustCompile(`^(\d+)-.*\.json$`)
+
+// NewCassette opens dir for recording or replay. Recording appends to any
+// interactions already in dir so a script of several linctl invocations can
+// be captured into one cassette.
+func NewCassette(dir string, mode CassetteMode) (*Cassette, error) {
+	cassette := &Cassette{dir: dir, mode: mode}
+
+	switch mode {
+	case CassetteRecord:
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, fmt.Errorf("failed to create cassette directory: %w", err)
+		}
+	case CassetteReplay:
+		if err := cassette.load(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %d", mode)
+	}
+	return cassette, nil
+}
+
+// cassetteFiles returns the interaction files in dir in recording order.
+func cassetteFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && cassetteNamePattern.MatchString(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (c *Cassette) load() error {
+	names, err := cassetteFiles(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read cassette directory: %w", err)
+	}
+
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(c.dir, name))
+		if err != nil {
+			return fmt.Errorf("failed to read cassette %s: %w", name, err)
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(data, &interaction); err != nil {
+			return fmt.Errorf("failed to parse cassette %s: %w", name, err)
+		}
+		c.interactions = append(c.interactions, &interaction)
+	}
+	c.used = make([]bool, len(c.interactions))
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	recorded := recordRequest(body)
+
+	if c.mode == CassetteReplay {
+		interaction, err := c.match(recorded)
+		if err != nil {
+			return nil, err
+		}
+		return interaction.Response.toHTTP(req), nil
+	}
+
+	next := c.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status:  resp.StatusCode,
+			Headers: recordHeaders(resp.Header),
+			Body:    rawJSON(respBody),
+		},
+	}
+	if err := c.save(interaction); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// match finds the interaction to replay for a request.
+func (c *Cassette) match(req RecordedRequest) (*Interaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, exact := range []bool{true, false} {
+		for i, interaction := range c.interactions {
+			if c.used[i] {
+				continue
+			}
+			if interaction.Request.matches(req, exact) {
+				c.used[i] = true
+				return interaction, nil
+			}
+		}
+	}
+
+	// Requests repeated more often than recorded get the last matching answer
+	for _, exact := range []bool{true, false} {
+		for i := len(c.interactions) - 1; i >= 0; i-- {
+			if c.interactions[i].Request.matches(req, exact) {
+				return c.interactions[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w for %s in %s", ErrCassetteMiss, req.Operation, c.dir)
+}
+
+// matches compares requests by query and variables when exact, else by query
+// and variables with date-times ignored.
+func (r RecordedRequest) matches(other RecordedRequest, exact bool) bool {
+	if exact {
+		return r.Hash == other.Hash
+	}
+	if r.Query != other.Query {
+		return false
+	}
+	a, errA := json.Marshal(withoutTimestamps(r.Variables))
+	b, errB := json.Marshal(withoutTimestamps(other.Variables))
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// withoutTimestamps returns a copy of a variables value with every RFC 3339
+// date-time string replaced by a placeholder.
+func withoutTimestamps(v interface{}) interface{} {
+	switch t := v.(type) {
+	case string:
+		if _, err := time.Parse(time.RFC3339Nano, t); err == nil {
+			return "<timestamp>"
+		}
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, item := range t {
+			out[k] = withoutTimestamps(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, item := range t {
+			out[i] = withoutTimestamps(item)
+		}
+		return out
+	}
+	return v
+}
+
+// save writes an interaction to the next free sequence number in the directory.
+func (c *Cassette) save(interaction *Interaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	names, err := cassetteFiles(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read cassette directory: %w", err)
+	}
+	seq := 1
+	if len(names) > 0 {
+		_, _ = fmt.Sscanf(names[len(names)-1], "%d-", &seq)
+		seq++
+	}
+
+	for ; ; seq++ {
+		name := fmt.Sprintf("%04d-%s.json", seq, interaction.Request.Operation)
+		f, err := os.OpenFile(filepath.Join(c.dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if os.IsExist(err) {
+			// Another linctl process recorded concurrently; take the next number
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write cassette: %w", err)
+		}
+		_, err = f.Write(append(data, '\n'))
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	}
+}
+
+// recordRequest extracts what identifies a GraphQL request body.
+func recordRequest(body []byte) RecordedRequest {
+	var req GraphQLRequest
+	_ = json.Unmarshal(body, &req)
+
+	vars, _ := json.Marshal(req.Variables)
+	sum := sha256.Sum256(append([]byte(req.Query+"\x00"), vars...))
+
+	return RecordedRequest{
+		Operation: operationName(req.Query),
+		Query:     req.Query,
+		Variables: req.Variables,
+		Hash:      hex.EncodeToString(sum[:]),
+	}
+}
+
+// recordHeaders keeps the response headers linctl reads, such as rate limits
+// and Retry-After, and drops cookies and transfer details.
+func recordHeaders(header http.Header) map[string]string {
+	out := map[string]string{}
+	for name, values := range header {
+		canonical := http.CanonicalHeaderKey(name)
+		if canonical == "Content-Type" || canonical == "Retry-After" || strings.HasPrefix(canonical, "X-") {
+			out[canonical] = strings.Join(values, ", ")
+		}
+	}
+	return out
+}
+
+// rawJSON keeps a JSON body as-is and stores anything else as a JSON string.
+func rawJSON(body []byte) json.RawMessage {
+	if json.Valid(body) {
+		return json.RawMessage(body)
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
+
+func (r RecordedResponse) toHTTP(req *http.Request) *http.Response {
+	body := []byte(r.Body)
+	var text string
+	if json.Unmarshal(body, &text) == nil {
+		body = []byte(text)
+	}
+
+	header := http.Header{}
+	for name, value := range r.Headers {
+		header.Set(name, value)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// SetTransport replaces the HTTP transport used for API requests, for example
+// with a Cassette.
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	c.httpClient.Transport = transport
+}
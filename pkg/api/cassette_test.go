@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Requests-Limit", "1500")
+		w.Header().Set("X-RateLimit-Requests-Remaining", "42")
+		_, _ = w.Write([]byte(`{"data":{"team":{"id":"team-1","key":"ENG","name":"Engineering"}}}`))
+	}))
+
+	recorder, err := NewCassette(dir, CassetteRecord)
+	if err != nil {
+		t.Fatalf("NewCassette failed: %v", err)
+	}
+	client := NewClientWithURL(server.URL, "lin_api_secret")
+	client.SetTransport(recorder)
+	if _, err := client.GetTeam(context.Background(), "ENG"); err != nil {
+		t.Fatalf("GetTeam while recording failed: %v", err)
+	}
+	server.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 || filepath.Base(files[0]) != "0001-Team.json" {
+		t.Fatalf("Expected a single 0001-Team.json cassette, got %v", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(string(data), "lin_api_secret") {
+		t.Error("Expected cassette not to contain credentials")
+	}
+
+	replayer, err := NewCassette(dir, CassetteReplay)
+	if err != nil {
+		t.Fatalf("NewCassette failed: %v", err)
+	}
+	client = NewClientWithURL(server.URL, "other-key")
+	client.SetTransport(replayer)
+
+	team, err := client.GetTeam(context.Background(), "ENG")
+	if err != nil {
+		t.Fatalf("GetTeam while replaying failed: %v", err)
+	}
+	if team.Name != "Engineering" {
+		t.Errorf("Expected replayed team, got %+v", team)
+	}
+	if status := client.LastRateLimit(); status == nil || status.Requests == nil || status.Requests.Remaining != 42 {
+		t.Errorf("Expected rate-limit headers to replay, got %+v", status)
+	}
+
+	// The same operation with other variables is not replayed
+	if _, err := client.GetTeam(context.Background(), "OPS"); !errors.Is(err, ErrCassetteMiss) {
+		t.Errorf("Expected a cassette miss for other variables, got %v", err)
+	}
+}
+
+func TestCassetteReplayIgnoresOnlyTimestamps(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		key := "ENG-1"
+		if strings.Contains(string(body), "ENG-2") {
+			key = "ENG-2"
+		}
+		_, _ = w.Write([]byte(`{"data":{"issue":{"identifier":"` + key + `"}}}`))
+	}))
+
+	const query = `query Recent($id: String!, $since: DateTimeOrDuration) { issue(id: $id) { identifier } }`
+	execute := func(client *Client, id, since string) (string, error) {
+		var response struct {
+			Issue struct {
+				Identifier string `json:"identifier"`
+			} `json:"issue"`
+		}
+		err := client.Execute(context.Background(), query, map[string]interface{}{
+			"id":    id,
+			"since": since,
+		}, &response)
+		return response.Issue.Identifier, err
+	}
+
+	recorder, err := NewCassette(dir, CassetteRecord)
+	if err != nil {
+		t.Fatalf("NewCassette failed: %v", err)
+	}
+	client := NewClientWithURL(server.URL, "key")
+	client.SetTransport(recorder)
+	for _, id := range []string{"ENG-1", "ENG-2"} {
+		if _, err := execute(client, id, "2024-01-01T10:00:00Z"); err != nil {
+			t.Fatalf("Recording %s failed: %v", id, err)
+		}
+	}
+	server.Close()
+
+	replayer, err := NewCassette(dir, CassetteReplay)
+	if err != nil {
+		t.Fatalf("NewCassette failed: %v", err)
+	}
+	client = NewClientWithURL(server.URL, "key")
+	client.SetTransport(replayer)
+
+	// A later run computes a different timestamp but asks for the same issues
+	for _, id := range []string{"ENG-2", "ENG-1", "ENG-2"} {
+		got, err := execute(client, id, "2024-03-05T08:30:00.123Z")
+		if err != nil {
+			t.Fatalf("Replaying %s failed: %v", id, err)
+		}
+		if got != id {
+			t.Errorf("Expected the recorded response for %s, got %s", id, got)
+		}
+	}
+
+	if _, err := execute(client, "ENG-3", "2024-01-01T10:00:00Z"); !errors.Is(err, ErrCassetteMiss) {
+		t.Errorf("Expected a cassette miss for an unrecorded issue, got %v", err)
+	}
+	if _, err := execute(client, "ENG-1", "last week"); !errors.Is(err, ErrCassetteMiss) {
+		t.Errorf("Expected a cassette miss for a non-timestamp change, got %v", err)
+	}
+}
+
+func TestCassetteMissIsNotRetried(t *testing.T) {
+	replayer, err := NewCassette(t.TempDir(), CassetteReplay)
+	if err != nil {
+		t.Fatalf("NewCassette failed: %v", err)
+	}
+
+	client := NewClientWithURL("http://linear.invalid/graphql", "key")
+	client.SetTransport(replayer)
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		t.Fatal("Expected cassette miss not to be retried")
+		return nil
+	}
+
+	_, err = client.GetOrganization(context.Background())
+	if !errors.Is(err, ErrCassetteMiss) {
+		t.Fatalf("Expected ErrCassetteMiss, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Organization") {
+		t.Errorf("Expected operation in error, got %v", err)
+	}
+}
+
+func TestCassetteRecordingAppends(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"organization":{"id":"org-1"}}}`))
+	}))
+	defer server.Close()
+
+	// Each linctl invocation opens its own recorder on the same directory
+	for i := 0; i < 2; i++ {
+		recorder, err := NewCassette(dir, CassetteRecord)
+		if err != nil {
+			t.Fatalf("NewCassette failed: %v", err)
+		}
+		client := NewClientWithURL(server.URL, "key")
+		client.SetTransport(recorder)
+		if _, err := client.GetOrganization(context.Background()); err != nil {
+			t.Fatalf("GetOrganization failed: %v", err)
+		}
+	}
+
+	for _, name := range []string{"0001-Organization.json", "0002-Organization.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected %s: %v", name, err)
+		}
+	}
+}
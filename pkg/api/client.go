@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -15,9 +16,18 @@ const (
 )
 
 type Client struct {
-	httpClient   *http.Client
-	authHeader   string
-	baseURL      string
+	httpClient  *http.Client
+	authHeader  string
+	baseURL     string
+	cache       *Cache  // on-disk cache for lookups, nil when disabled
+	tracer      *Tracer // reports requests for --debug, nil when disabled
+	retryPolicy RetryPolicy
+	sleep       func(ctx context.Context, d time.Duration) error
+
+	mu        sync.Mutex
+	rateLimit *RateLimitStatus // latest rate-limit headers seen on a response
+
+	viewerMu     sync.Mutex
 	cachedViewer *User // cached current user to avoid repeated API calls
 }
 
@@ -31,17 +41,6 @@ type GraphQLResponse struct {
 	Errors []GraphQLError  `json:"errors,omitempty"`
 }
 
-type GraphQLError struct {
-	Message   string                 `json:"message"`
-	Locations []GraphQLErrorLocation `json:"locations,omitempty"`
-	Path      []interface{}          `json:"path,omitempty"`
-}
-
-type GraphQLErrorLocation struct {
-	Line   int `json:"line"`
-	Column int `json:"column"`
-}
-
 // NewClient creates a new Linear API client
 func NewClient(authHeader string) *Client {
 	return NewClientWithURL(BaseURL, authHeader)
@@ -53,11 +52,18 @@ func NewClientWithURL(baseURL, authHeader string) *Client {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		authHeader: authHeader,
-		baseURL:    baseURL,
+		authHeader:  authHeader,
+		baseURL:     baseURL,
+		retryPolicy: DefaultRetryPolicy(),
+		sleep:       sleepContext,
 	}
 }
 
+// SetTimeout sets the per-request HTTP timeout.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.httpClient.Timeout = timeout
+}
+
 // Execute performs a GraphQL request
 func (c *Client) Execute(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
 	reqBody := GraphQLRequest{
@@ -70,61 +76,97 @@ func (c *Client) Execute(ctx context.Context, query string, variables map[string
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
+	retryAllowed := !isMutation(query) || isRetrySafe(ctx)
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", c.authHeader)
-	req.Header.Set("User-Agent", "linctl/0.1.0")
+	var data json.RawMessage
+	for attempt := 0; ; attempt++ {
+		var header http.Header
+		data, header, err = c.attempt(ctx, jsonBody)
+		if err == nil || !retryAllowed || !isRetryable(ctx, err) || attempt >= c.retryPolicy.MaxRetries {
+			break
+		}
 
-	resp, err := c.httpClient.Do(req)
+		delay, ok := c.retryPolicy.delay(attempt, header)
+		if !ok {
+			break
+		}
+		if sleepErr := c.sleep(ctx, delay); sleepErr != nil {
+			return sleepErr
+		}
+	}
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	body, err := io.ReadAll(resp.Body)
+	if result != nil {
+		if err := json.Unmarshal(data, result); err != nil {
+			return fmt.Errorf("failed to unmarshal data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// attempt sends the request once and returns the response data, or an
+// *HTTPError / *GraphQLErrors describing why the API rejected it.
+func (c *Client) attempt(ctx context.Context, jsonBody []byte) (json.RawMessage, http.Header, error) {
+	body, statusCode, header, err := c.do(ctx, jsonBody)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, header, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	if statusCode != http.StatusOK {
+		return nil, header, newHTTPError(statusCode, body)
 	}
 
 	var gqlResp GraphQLResponse
 	if err := json.Unmarshal(body, &gqlResp); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+		return nil, header, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if len(gqlResp.Errors) > 0 {
-		return fmt.Errorf("GraphQL errors: %v", gqlResp.Errors)
+		return nil, header, &GraphQLErrors{Errors: gqlResp.Errors, StatusCode: statusCode}
 	}
 
-	if result != nil {
-		if err := json.Unmarshal(gqlResp.Data, result); err != nil {
-			return fmt.Errorf("failed to unmarshal data: %w", err)
-		}
+	return gqlResp.Data, header, nil
+}
+
+// do sends a single GraphQL request and returns the raw response body.
+func (c *Client) do(ctx context.Context, jsonBody []byte) ([]byte, int, http.Header, error) {
+	if c.tracer == nil {
+		body, statusCode, header, _, err := c.send(ctx, jsonBody)
+		return body, statusCode, header, err
 	}
 
-	return nil
+	start := time.Now()
+	body, statusCode, header, reqHeader, err := c.send(ctx, jsonBody)
+	c.traceRequest(jsonBody, reqHeader, header, statusCode, len(body), start, err)
+	return body, statusCode, header, err
 }
 
-// Rate limiting helper
-func (c *Client) GetRateLimit(ctx context.Context) (*RateLimit, error) {
-	// This would query Linear's rate limiting info
-	// For now, we'll return a placeholder
-	return &RateLimit{
-		Limit:     5000,
-		Remaining: 4999,
-		Reset:     time.Now().Add(time.Hour),
-	}, nil
-}
+// send performs the HTTP round trip for do, also returning the request headers for tracing.
+func (c *Client) send(ctx context.Context, jsonBody []byte) ([]byte, int, http.Header, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, 0, nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", c.authHeader)
+	req.Header.Set("User-Agent", "linctl/0.1.0")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, nil, req.Header, &networkError{fmt.Errorf("request failed: %w", err)}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	c.recordRateLimit(resp.Header)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, resp.Header, req.Header, &networkError{fmt.Errorf("failed to read response: %w", err)}
+	}
 
-type RateLimit struct {
-	Limit     int       `json:"limit"`
-	Remaining int       `json:"remaining"`
-	Reset     time.Time `json:"reset"`
+	return body, resp.StatusCode, resp.Header, req.Header, nil
 }
@@ -0,0 +1,12 @@
+package api
+
+import _ "embed"
+
+//go:embed queries.go
+var queriesSource []byte
+
+// QueriesSource returns the file name and source of queries.go, so the
+// GraphQL documents it holds can be validated against a saved schema.
+func QueriesSource() (string, []byte) {
+	return "pkg/api/queries.go", queriesSource
+}
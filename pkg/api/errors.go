@@ -0,0 +1,215 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Error codes Linear reports in GraphQL error extensions.
+const (
+	ErrorCodeAuthentication = "AUTHENTICATION_ERROR"
+	ErrorCodeForbidden      = "FORBIDDEN"
+	ErrorCodeRateLimited    = "RATELIMITED"
+	ErrorCodeInvalidInput   = "INVALID_INPUT"
+	ErrorCodeInputError     = "INPUT_ERROR"
+	ErrorCodeNotFound       = "ENTITY_NOT_FOUND"
+	ErrorCodeValidation     = "GRAPHQL_VALIDATION_FAILED"
+	ErrorCodeBadUserInput   = "BAD_USER_INPUT"
+)
+
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Locations  []GraphQLErrorLocation `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions GraphQLErrorExtensions `json:"extensions,omitempty"`
+}
+
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLErrorExtensions holds the Linear-specific details attached to a GraphQL error.
+type GraphQLErrorExtensions struct {
+	Code                   string `json:"code,omitempty"`
+	Type                   string `json:"type,omitempty"`
+	UserError              bool   `json:"userError,omitempty"`
+	UserPresentableMessage string `json:"userPresentableMessage,omitempty"`
+}
+
+// Error returns the most helpful message for the error, preferring Linear's
+// user-presentable message when one is provided.
+func (e GraphQLError) Error() string {
+	msg := e.Message
+	if e.Extensions.UserPresentableMessage != "" && e.Extensions.UserPresentableMessage != e.Message {
+		msg = fmt.Sprintf("%s (%s)", e.Extensions.UserPresentableMessage, e.Message)
+	}
+	if len(e.Path) > 0 {
+		parts := make([]string, len(e.Path))
+		for i, p := range e.Path {
+			parts[i] = fmt.Sprint(p)
+		}
+		msg = fmt.Sprintf("%s: %s", strings.Join(parts, "."), msg)
+	}
+	return msg
+}
+
+// GraphQLErrors is returned by Execute when the response contains GraphQL errors.
+type GraphQLErrors struct {
+	Errors     []GraphQLError
+	StatusCode int
+}
+
+func (e *GraphQLErrors) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, gqlErr := range e.Errors {
+		msgs[i] = gqlErr.Error()
+	}
+	return "GraphQL errors: " + strings.Join(msgs, "; ")
+}
+
+// Code returns the first error code reported by the API, or an empty string.
+func (e *GraphQLErrors) Code() string {
+	for _, gqlErr := range e.Errors {
+		if gqlErr.Extensions.Code != "" {
+			return gqlErr.Extensions.Code
+		}
+	}
+	return ""
+}
+
+// HasCode reports whether any of the errors carries the given code.
+func (e *GraphQLErrors) HasCode(codes ...string) bool {
+	for _, gqlErr := range e.Errors {
+		for _, code := range codes {
+			if strings.EqualFold(gqlErr.Extensions.Code, code) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether the API could not find a requested entity.
+// Linear reports these as input errors, so the message is checked as well.
+func (e *GraphQLErrors) IsNotFound() bool {
+	if e.HasCode(ErrorCodeNotFound, "NOT_FOUND") {
+		return true
+	}
+	for _, gqlErr := range e.Errors {
+		if strings.Contains(strings.ToLower(gqlErr.Message), "not found") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsForbidden reports whether the request was rejected for lack of permission.
+func (e *GraphQLErrors) IsForbidden() bool {
+	return e.HasCode(ErrorCodeForbidden)
+}
+
+// IsAuthentication reports whether the credentials were missing or invalid.
+func (e *GraphQLErrors) IsAuthentication() bool {
+	return e.HasCode(ErrorCodeAuthentication)
+}
+
+// IsRateLimited reports whether the request was rejected by Linear's rate limiter.
+func (e *GraphQLErrors) IsRateLimited() bool {
+	return e.HasCode(ErrorCodeRateLimited)
+}
+
+// IsValidation reports whether the request itself was invalid: a malformed
+// query or input the API refused. Not-found errors are excluded.
+func (e *GraphQLErrors) IsValidation() bool {
+	if e.IsNotFound() {
+		return false
+	}
+	return e.HasCode(ErrorCodeInvalidInput, ErrorCodeInputError, ErrorCodeValidation, ErrorCodeBadUserInput)
+}
+
+// HTTPError is returned by Execute when the API responds with a non-200 status.
+// If the body contained GraphQL errors they are available through errors.As.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+	Errors     *GraphQLErrors
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+func (e *HTTPError) Unwrap() error {
+	if e.Errors == nil {
+		return nil
+	}
+	return e.Errors
+}
+
+// newHTTPError builds an HTTPError, decoding any GraphQL errors in the body.
+func newHTTPError(statusCode int, body []byte) *HTTPError {
+	httpErr := &HTTPError{StatusCode: statusCode, Body: string(body)}
+
+	var gqlResp GraphQLResponse
+	if err := json.Unmarshal(body, &gqlResp); err == nil && len(gqlResp.Errors) > 0 {
+		httpErr.Errors = &GraphQLErrors{Errors: gqlResp.Errors, StatusCode: statusCode}
+	}
+	return httpErr
+}
+
+// IsNotFound reports whether err means a requested entity does not exist.
+func IsNotFound(err error) bool {
+	var gqlErr *GraphQLErrors
+	if errors.As(err, &gqlErr) && gqlErr.IsNotFound() {
+		return true
+	}
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
+
+// IsForbidden reports whether err means the caller lacks permission.
+func IsForbidden(err error) bool {
+	var gqlErr *GraphQLErrors
+	if errors.As(err, &gqlErr) && gqlErr.IsForbidden() {
+		return true
+	}
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusForbidden
+}
+
+// IsAuthentication reports whether err means the credentials were rejected.
+func IsAuthentication(err error) bool {
+	var gqlErr *GraphQLErrors
+	if errors.As(err, &gqlErr) && gqlErr.IsAuthentication() {
+		return true
+	}
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusUnauthorized
+}
+
+// IsRateLimited reports whether err means Linear's rate limit was exceeded.
+func IsRateLimited(err error) bool {
+	var gqlErr *GraphQLErrors
+	if errors.As(err, &gqlErr) && gqlErr.IsRateLimited() {
+		return true
+	}
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests
+}
+
+// IsNetwork reports whether err means the API could not be reached or the
+// connection failed before a complete response arrived.
+func IsNetwork(err error) bool {
+	var netErr *networkError
+	return errors.As(err, &netErr)
+}
+
+// IsValidation reports whether err means the request or its input was invalid.
+func IsValidation(err error) bool {
+	var gqlErr *GraphQLErrors
+	return errors.As(err, &gqlErr) && gqlErr.IsValidation()
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExecuteReturnsGraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"data": null,
+			"errors": [{
+				"message": "Entity not found: Issue",
+				"path": ["issue"],
+				"extensions": {
+					"code": "INPUT_ERROR",
+					"type": "invalid input",
+					"userError": true,
+					"userPresentableMessage": "Could not find referenced Issue."
+				}
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	err := client.Execute(context.Background(), "query { issue(id: \"x\") { id } }", nil, nil)
+
+	var gqlErr *GraphQLErrors
+	if !errors.As(err, &gqlErr) {
+		t.Fatalf("Expected *GraphQLErrors, got %T: %v", err, err)
+	}
+	if len(gqlErr.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %d", len(gqlErr.Errors))
+	}
+
+	first := gqlErr.Errors[0]
+	if first.Extensions.Code != "INPUT_ERROR" {
+		t.Errorf("Expected code INPUT_ERROR, got %q", first.Extensions.Code)
+	}
+	if first.Extensions.UserPresentableMessage != "Could not find referenced Issue." {
+		t.Errorf("Unexpected userPresentableMessage: %q", first.Extensions.UserPresentableMessage)
+	}
+	if len(first.Path) != 1 || first.Path[0] != "issue" {
+		t.Errorf("Unexpected path: %v", first.Path)
+	}
+	if gqlErr.Code() != "INPUT_ERROR" {
+		t.Errorf("Expected Code() INPUT_ERROR, got %q", gqlErr.Code())
+	}
+	if !IsNotFound(err) {
+		t.Error("Expected IsNotFound to be true")
+	}
+	if IsValidation(err) {
+		t.Error("Expected not-found error not to count as validation")
+	}
+	if want := "GraphQL errors: issue: Could not find referenced Issue. (Entity not found: Issue)"; err.Error() != want {
+		t.Errorf("Unexpected message:\n got: %s\nwant: %s", err.Error(), want)
+	}
+}
+
+func TestExecuteReturnsHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"Argument Validation Error","extensions":{"code":"INVALID_INPUT"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	err := client.Execute(context.Background(), "query { viewer { id } }", nil, nil)
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected *HTTPError, got %T: %v", err, err)
+	}
+	if httpErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", httpErr.StatusCode)
+	}
+
+	// The GraphQL errors in the body are reachable through the HTTP error
+	var gqlErr *GraphQLErrors
+	if !errors.As(err, &gqlErr) {
+		t.Fatal("Expected *GraphQLErrors to be reachable via errors.As")
+	}
+	if !IsValidation(err) {
+		t.Error("Expected IsValidation to be true")
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	withCode := func(code string) error {
+		return fmt.Errorf("wrapped: %w", &GraphQLErrors{Errors: []GraphQLError{{
+			Message:    "failed",
+			Extensions: GraphQLErrorExtensions{Code: code},
+		}}})
+	}
+
+	cases := []struct {
+		name string
+		err  error
+		is   func(error) bool
+	}{
+		{"forbidden code", withCode("FORBIDDEN"), IsForbidden},
+		{"forbidden status", &HTTPError{StatusCode: http.StatusForbidden}, IsForbidden},
+		{"authentication code", withCode("AUTHENTICATION_ERROR"), IsAuthentication},
+		{"authentication status", &HTTPError{StatusCode: http.StatusUnauthorized}, IsAuthentication},
+		{"rate limited code", withCode("RATELIMITED"), IsRateLimited},
+		{"rate limited status", &HTTPError{StatusCode: http.StatusTooManyRequests}, IsRateLimited},
+		{"not found status", &HTTPError{StatusCode: http.StatusNotFound}, IsNotFound},
+		{"validation code", withCode("GRAPHQL_VALIDATION_FAILED"), IsValidation},
+	}
+	for _, c := range cases {
+		if !c.is(c.err) {
+			t.Errorf("%s: expected classification to match", c.name)
+		}
+	}
+
+	plain := errors.New("boom")
+	if IsNotFound(plain) || IsForbidden(plain) || IsValidation(plain) || IsRateLimited(plain) || IsAuthentication(plain) {
+		t.Error("Expected plain error not to match any classification")
+	}
+}
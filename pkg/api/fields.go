@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FieldSet maps the field names accepted by --fields to the GraphQL
+// selection that fetches them.
+type FieldSet map[string]string
+
+// IssueFields are the issue fields that can be requested with --fields.
+var IssueFields = FieldSet{
+	"id":            "id",
+	"identifier":    "identifier",
+	"number":        "number",
+	"title":         "title",
+	"description":   "description",
+	"priority":      "priority",
+	"priorityLabel": "priorityLabel",
+	"estimate":      "estimate",
+	"url":           "url",
+	"branchName":    "branchName",
+	"dueDate":       "dueDate",
+	"createdAt":     "createdAt",
+	"updatedAt":     "updatedAt",
+	"completedAt":   "completedAt",
+	"canceledAt":    "canceledAt",
+	"archivedAt":    "archivedAt",
+	"state":         "state { id name type color }",
+	"assignee":      "assignee { id name email }",
+	"creator":       "creator { id name email }",
+	"team":          "team { id key name }",
+	"project":       "project { id name state }",
+	"cycle":         "cycle { id number name }",
+	"parent":        "parent { id identifier title }",
+	"labels":        "labels { nodes { id name color } }",
+	"children":      "children { nodes { id identifier title state { name } } }",
+	"comments":      "comments { nodes { id body createdAt user { name } } }",
+}
+
+// ProjectFields are the project fields that can be requested with --fields.
+var ProjectFields = FieldSet{
+	"id":          "id",
+	"slugId":      "slugId",
+	"name":        "name",
+	"description": "description",
+	"content":     "content",
+	"state":       "state",
+	"progress":    "progress",
+	"health":      "health",
+	"priority":    "priority",
+	"startDate":   "startDate",
+	"targetDate":  "targetDate",
+	"url":         "url",
+	"icon":        "icon",
+	"color":       "color",
+	"createdAt":   "createdAt",
+	"updatedAt":   "updatedAt",
+	"completedAt": "completedAt",
+	"canceledAt":  "canceledAt",
+	"archivedAt":  "archivedAt",
+	"lead":        "lead { id name email }",
+	"creator":     "creator { id name email }",
+	"teams":       "teams { nodes { id key name } }",
+	"members":     "members { nodes { id name email } }",
+	"issues":      "issues(first: 50, orderBy: updatedAt) { nodes { id identifier title state { name } } }",
+}
+
+// Names returns the accepted field names in sorted order.
+func (s FieldSet) Names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse splits a comma-separated field list such as "identifier,state,assignee"
+// into canonical field names, in the order given and without duplicates.
+// Names are matched case-insensitively.
+func (s FieldSet) Parse(spec string) ([]string, error) {
+	var fields []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, ok := s.lookup(part)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q; valid fields are: %s", part, strings.Join(s.Names(), ", "))
+		}
+		if !seen[name] {
+			seen[name] = true
+			fields = append(fields, name)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields given; valid fields are: %s", strings.Join(s.Names(), ", "))
+	}
+	return fields, nil
+}
+
+func (s FieldSet) lookup(name string) (string, bool) {
+	if _, ok := s[name]; ok {
+		return name, true
+	}
+	for candidate := range s {
+		if strings.EqualFold(candidate, name) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// Selection returns the selection set body that fetches fields.
+func (s FieldSet) Selection(fields []string) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = s[field]
+	}
+	return strings.Join(parts, "\n")
+}
+
+// The --fields queries wrap the selection of the requested fields. Being
+// assembled at run time, they are validated through FieldsQueries.
+const (
+	issueFieldsQuery   = "query IssueFields($id: String!) {\n  issue(id: $id) {\n%s\n  }\n}"
+	issuesFieldsQuery  = "query IssuesFields($filter: IssueFilter, $first: Int, $after: String, $orderBy: PaginationOrderBy) {\n  issues(filter: $filter, first: $first, after: $after, orderBy: $orderBy) {\n    nodes {\n%s\n    }\n    pageInfo { hasNextPage endCursor }\n  }\n}"
+	projectFieldsQuery = "query ProjectFields($id: String!) {\n  project(id: $id) {\n%s\n  }\n}"
+)
+
+// FieldsQueries returns each --fields query with every field it accepts
+// selected, keyed by operation name, so that `linctl schema validate --builtin`
+// checks them along with the queries in queries.go.
+func FieldsQueries() map[string]string {
+	return map[string]string{
+		"IssueFields":   fmt.Sprintf(issueFieldsQuery, IssueFields.Selection(IssueFields.Names())),
+		"IssuesFields":  fmt.Sprintf(issuesFieldsQuery, IssueFields.Selection(IssueFields.Names())),
+		"ProjectFields": fmt.Sprintf(projectFieldsQuery, ProjectFields.Selection(ProjectFields.Names())),
+	}
+}
+
+// GetIssueFields returns only the requested fields of a single issue.
+func (c *Client) GetIssueFields(ctx context.Context, id string, fields []string) (map[string]interface{}, error) {
+	query := fmt.Sprintf(issueFieldsQuery, IssueFields.Selection(fields))
+
+	var response struct {
+		Issue map[string]interface{} `json:"issue"`
+	}
+	if err := c.Execute(ctx, query, map[string]interface{}{"id": id}, &response); err != nil {
+		return nil, err
+	}
+	if response.Issue == nil {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+	return response.Issue, nil
+}
+
+// GetIssuesFields returns one page of issues holding only the requested fields.
+func (c *Client) GetIssuesFields(ctx context.Context, filter map[string]interface{}, first int, after string, orderBy string, fields []string) ([]map[string]interface{}, PageInfo, error) {
+	query := fmt.Sprintf(issuesFieldsQuery, IssueFields.Selection(fields))
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if filter != nil {
+		variables["filter"] = filter
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+	if orderBy != "" {
+		variables["orderBy"] = orderBy
+	}
+
+	var response struct {
+		Issues struct {
+			Nodes    []map[string]interface{} `json:"nodes"`
+			PageInfo PageInfo                 `json:"pageInfo"`
+		} `json:"issues"`
+	}
+	if err := c.Execute(ctx, query, variables, &response); err != nil {
+		return nil, PageInfo{}, err
+	}
+	return response.Issues.Nodes, response.Issues.PageInfo, nil
+}
+
+// GetIssuesFieldsPaginated returns up to limit issues holding only the requested
+// fields, following cursors across pages. A limit of zero or less returns every
+// matching issue.
+func (c *Client) GetIssuesFieldsPaginated(ctx context.Context, filter map[string]interface{}, limit int, orderBy string, fields []string) ([]map[string]interface{}, PageInfo, error) {
+	return collectPages(ctx, limit, func(first int, after string) ([]map[string]interface{}, PageInfo, error) {
+		return c.GetIssuesFields(ctx, filter, first, after, orderBy, fields)
+	})
+}
+
+// GetProjectFields returns only the requested fields of a single project.
+func (c *Client) GetProjectFields(ctx context.Context, id string, fields []string) (map[string]interface{}, error) {
+	query := fmt.Sprintf(projectFieldsQuery, ProjectFields.Selection(fields))
+
+	var response struct {
+		Project map[string]interface{} `json:"project"`
+	}
+	if err := c.Execute(ctx, query, map[string]interface{}{"id": id}, &response); err != nil {
+		return nil, err
+	}
+	if response.Project == nil {
+		return nil, fmt.Errorf("project %s not found", id)
+	}
+	return response.Project, nil
+}
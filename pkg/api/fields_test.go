@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFieldSetParse(t *testing.T) {
+	fields, err := IssueFields.Parse(" identifier, State ,assignee,identifier")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if strings.Join(fields, ",") != "identifier,state,assignee" {
+		t.Errorf("Unexpected fields: %v", fields)
+	}
+
+	if _, err := IssueFields.Parse("identifier,nmae"); err == nil || !strings.Contains(err.Error(), `unknown field "nmae"`) {
+		t.Errorf("Expected unknown field error, got %v", err)
+	}
+	if _, err := IssueFields.Parse(" , "); err == nil {
+		t.Error("Expected error for empty field list")
+	}
+}
+
+func TestGetIssueFieldsSendsMinimalSelection(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		query = req.Query
+		_, _ = w.Write([]byte(`{"data": {"issue": {"identifier": "ENG-1", "state": {"id": "s1", "name": "Todo", "type": "unstarted", "color": "#fff"}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	record, err := client.GetIssueFields(context.Background(), "ENG-1", []string{"identifier", "state"})
+	if err != nil {
+		t.Fatalf("GetIssueFields failed: %v", err)
+	}
+
+	if !strings.Contains(query, "identifier\nstate { id name type color }") {
+		t.Errorf("Expected only the requested selections, got:\n%s", query)
+	}
+	for _, unwanted := range []string{"description", "history", "comments", "children"} {
+		if strings.Contains(query, unwanted) {
+			t.Errorf("Query should not select %s:\n%s", unwanted, query)
+		}
+	}
+	if len(record) != 2 || record["identifier"] != "ENG-1" {
+		t.Errorf("Unexpected record: %v", record)
+	}
+}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"context"
+)
+
+// MaxPageSize is the largest page Linear will return for a single connection query.
+const MaxPageSize = 250
+
+// fetchPageFunc fetches a single page of a connection starting after the given cursor.
+type fetchPageFunc[T any] func(first int, after string) ([]T, PageInfo, error)
+
+// collectPages follows endCursor until limit nodes have been collected or the
+// connection has no more pages. A limit of zero or less fetches every page.
+// The returned PageInfo describes the last page fetched, so HasNextPage reports
+// whether more results exist beyond the ones collected.
+func collectPages[T any](ctx context.Context, limit int, fetch fetchPageFunc[T]) ([]T, PageInfo, error) {
+	var (
+		nodes    []T
+		pageInfo PageInfo
+		cursor   string
+	)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, PageInfo{}, err
+		}
+
+		first := MaxPageSize
+		if limit > 0 {
+			if remaining := limit - len(nodes); remaining < first {
+				first = remaining
+			}
+		}
+
+		page, info, err := fetch(first, cursor)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		nodes = append(nodes, page...)
+		pageInfo = info
+
+		if !info.HasNextPage || info.EndCursor == "" || len(page) == 0 {
+			break
+		}
+		if limit > 0 && len(nodes) >= limit {
+			break
+		}
+		cursor = info.EndCursor
+	}
+
+	return nodes, pageInfo, nil
+}
+
+// GetIssuesPaginated returns up to limit issues, following cursors across pages.
+// A limit of zero or less returns every matching issue.
+func (c *Client) GetIssuesPaginated(ctx context.Context, filter map[string]interface{}, limit int, orderBy string) (*Issues, error) {
+	nodes, pageInfo, err := collectPages(ctx, limit, func(first int, after string) ([]Issue, PageInfo, error) {
+		page, err := c.GetIssues(ctx, filter, first, after, orderBy)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Issues{Nodes: nodes, PageInfo: pageInfo}, nil
+}
+
+// IssueSearchPaginated returns up to limit search matches, following cursors across pages.
+// A limit of zero or less returns every match.
+func (c *Client) IssueSearchPaginated(ctx context.Context, term string, filter map[string]interface{}, limit int, orderBy string, includeArchived bool) (*Issues, error) {
+	nodes, pageInfo, err := collectPages(ctx, limit, func(first int, after string) ([]Issue, PageInfo, error) {
+		page, err := c.IssueSearch(ctx, term, filter, first, after, orderBy, includeArchived)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Issues{Nodes: nodes, PageInfo: pageInfo}, nil
+}
+
+// GetProjectsPaginated returns up to limit projects, following cursors across pages.
+// A limit of zero or less returns every matching project.
+func (c *Client) GetProjectsPaginated(ctx context.Context, filter map[string]interface{}, limit int, orderBy string) (*Projects, error) {
+	nodes, pageInfo, err := collectPages(ctx, limit, func(first int, after string) ([]Project, PageInfo, error) {
+		page, err := c.GetProjects(ctx, filter, first, after, orderBy)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Projects{Nodes: nodes, PageInfo: pageInfo}, nil
+}
+
+// GetTeamsPaginated returns up to limit teams, following cursors across pages.
+// A limit of zero or less returns every team.
+func (c *Client) GetTeamsPaginated(ctx context.Context, limit int, orderBy string) (*Teams, error) {
+	nodes, pageInfo, err := collectPages(ctx, limit, func(first int, after string) ([]Team, PageInfo, error) {
+		page, err := c.GetTeams(ctx, first, after, orderBy)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Teams{Nodes: nodes, PageInfo: pageInfo}, nil
+}
+
+// GetUsersPaginated returns up to limit users, following cursors across pages.
+// A limit of zero or less returns every user.
+func (c *Client) GetUsersPaginated(ctx context.Context, limit int, orderBy string) (*Users, error) {
+	nodes, pageInfo, err := collectPages(ctx, limit, func(first int, after string) ([]User, PageInfo, error) {
+		page, err := c.GetUsers(ctx, first, after, orderBy)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Users{Nodes: nodes, PageInfo: pageInfo}, nil
+}
+
+// GetIssueCommentsPaginated returns up to limit comments for an issue, following cursors across pages.
+// A limit of zero or less returns every comment.
+func (c *Client) GetIssueCommentsPaginated(ctx context.Context, issueID string, limit int, orderBy string) (*Comments, error) {
+	nodes, pageInfo, err := collectPages(ctx, limit, func(first int, after string) ([]Comment, PageInfo, error) {
+		page, err := c.GetIssueComments(ctx, issueID, first, after, orderBy)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Comments{Nodes: nodes, PageInfo: pageInfo}, nil
+}
+
+// GetCustomViewsPaginated returns up to limit custom views, following cursors across pages.
+// A limit of zero or less returns every view.
+func (c *Client) GetCustomViewsPaginated(ctx context.Context, limit int) (*CustomViews, error) {
+	nodes, pageInfo, err := collectPages(ctx, limit, func(first int, after string) ([]CustomView, PageInfo, error) {
+		page, err := c.GetCustomViews(ctx, first, after)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CustomViews{Nodes: nodes, PageInfo: pageInfo}, nil
+}
+
+// GetCustomViewIssuesPaginated returns up to limit issues of a custom view, following cursors across pages.
+// A limit of zero or less returns every issue in the view.
+func (c *Client) GetCustomViewIssuesPaginated(ctx context.Context, id string, filter map[string]interface{}, limit int, orderBy string) (*Issues, error) {
+	nodes, pageInfo, err := collectPages(ctx, limit, func(first int, after string) ([]Issue, PageInfo, error) {
+		page, err := c.GetCustomViewIssues(ctx, id, filter, first, after, orderBy)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		return page.Nodes, page.PageInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Issues{Nodes: nodes, PageInfo: pageInfo}, nil
+}
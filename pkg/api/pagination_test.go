@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTeamPagesServer serves a teams connection of total nodes in pages of at most
+// the requested size, recording the "first" variable of every request.
+func newTeamPagesServer(t *testing.T, total int, firsts *[]int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		first := int(req.Variables["first"].(float64))
+		*firsts = append(*firsts, first)
+
+		start := 0
+		if after, ok := req.Variables["after"].(string); ok {
+			if _, err := fmt.Sscanf(after, "cursor-%d", &start); err != nil {
+				t.Fatalf("unexpected cursor %q", after)
+			}
+		}
+
+		end := start + first
+		if end > total {
+			end = total
+		}
+
+		nodes := []map[string]interface{}{}
+		for i := start; i < end; i++ {
+			nodes = append(nodes, map[string]interface{}{"id": fmt.Sprintf("team-%d", i)})
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"teams": map[string]interface{}{
+					"nodes": nodes,
+					"pageInfo": map[string]interface{}{
+						"hasNextPage": end < total,
+						"endCursor":   fmt.Sprintf("cursor-%d", end),
+					},
+				},
+			},
+		})
+	}))
+}
+
+func TestGetTeamsPaginatedFollowsCursor(t *testing.T) {
+	var firsts []int
+	server := newTeamPagesServer(t, 600, &firsts)
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	teams, err := client.GetTeamsPaginated(context.Background(), 0, "")
+	if err != nil {
+		t.Fatalf("GetTeamsPaginated failed: %v", err)
+	}
+
+	if len(teams.Nodes) != 600 {
+		t.Fatalf("Expected 600 teams, got %d", len(teams.Nodes))
+	}
+	if teams.Nodes[599].ID != "team-599" {
+		t.Errorf("Expected last team 'team-599', got '%s'", teams.Nodes[599].ID)
+	}
+	if teams.PageInfo.HasNextPage {
+		t.Error("Expected HasNextPage to be false after fetching everything")
+	}
+	if len(firsts) != 3 {
+		t.Errorf("Expected 3 requests, got %d", len(firsts))
+	}
+}
+
+func TestGetTeamsPaginatedRespectsLimit(t *testing.T) {
+	var firsts []int
+	server := newTeamPagesServer(t, 600, &firsts)
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	teams, err := client.GetTeamsPaginated(context.Background(), 300, "")
+	if err != nil {
+		t.Fatalf("GetTeamsPaginated failed: %v", err)
+	}
+
+	if len(teams.Nodes) != 300 {
+		t.Fatalf("Expected 300 teams, got %d", len(teams.Nodes))
+	}
+	if !teams.PageInfo.HasNextPage {
+		t.Error("Expected HasNextPage to be true when more teams remain")
+	}
+	if len(firsts) != 2 || firsts[0] != MaxPageSize || firsts[1] != 50 {
+		t.Errorf("Expected page sizes [%d 50], got %v", MaxPageSize, firsts)
+	}
+}
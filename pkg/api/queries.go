@@ -250,6 +250,28 @@ type Initiatives struct {
 	PageInfo PageInfo     `json:"pageInfo"`
 }
 
+// CustomView represents a saved view in Linear. FilterData is the IssueFilter
+// the view applies.
+type CustomView struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description *string                `json:"description"`
+	SlugId      string                 `json:"slugId"`
+	ModelName   string                 `json:"modelName"`
+	Shared      bool                   `json:"shared"`
+	FilterData  map[string]interface{} `json:"filterData"`
+	Team        *Team                  `json:"team"`
+	Creator     *User                  `json:"creator"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	UpdatedAt   time.Time              `json:"updatedAt"`
+}
+
+// CustomViews represents a paginated list of custom views
+type CustomViews struct {
+	Nodes    []CustomView `json:"nodes"`
+	PageInfo PageInfo     `json:"pageInfo"`
+}
+
 type PageInfo struct {
 	HasNextPage bool   `json:"hasNextPage"`
 	EndCursor   string `json:"endCursor"`
@@ -442,7 +464,11 @@ func (c *Client) GetViewer(ctx context.Context) (*User, error) {
 
 // GetViewerCached returns the current authenticated user, using a cached value if available.
 // This avoids repeated API calls when the viewer information is needed multiple times.
+// It is safe for concurrent use; callers racing on an empty cache share one request.
 func (c *Client) GetViewerCached(ctx context.Context) (*User, error) {
+	c.viewerMu.Lock()
+	defer c.viewerMu.Unlock()
+
 	if c.cachedViewer != nil {
 		return c.cachedViewer, nil
 	}
@@ -472,7 +498,7 @@ func (c *Client) GetOrganization(ctx context.Context) (*Organization, error) {
 		Organization Organization `json:"organization"`
 	}
 
-	err := c.Execute(ctx, query, nil, &response)
+	err := c.executeCached(ctx, "Organization", query, nil, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -1014,7 +1040,8 @@ func (c *Client) GetIssueAgentSession(ctx context.Context, issueId string) (*Iss
 	return &response.Issue, nil
 }
 
-// GetTeams returns a list of teams
+// GetTeams returns a list of teams. It is not cached, since issueCount changes
+// with every new issue.
 func (c *Client) GetTeams(ctx context.Context, first int, after string, orderBy string) (*Teams, error) {
 	query := `
 		query Teams($first: Int, $after: String, $orderBy: PaginationOrderBy) {
@@ -1339,7 +1366,8 @@ func (c *Client) UpdateIssue(ctx context.Context, id string, input map[string]in
 		} `json:"issueUpdate"`
 	}
 
-	err := c.Execute(ctx, query, variables, &response)
+	// issueUpdate sets fields to fixed values, so replaying it is safe
+	err := c.Execute(WithRetrySafe(ctx), query, variables, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -1412,7 +1440,8 @@ func (c *Client) CreateIssue(ctx context.Context, input map[string]interface{})
 	return &response.IssueCreate.Issue, nil
 }
 
-// GetTeam returns a single team by key
+// GetTeam returns a single team by key. Lookups are cached, so the result
+// leaves out issueCount; use GetTeamDetails to show it.
 func (c *Client) GetTeam(ctx context.Context, key string) (*Team, error) {
 	query := `
 		query Team($key: String!) {
@@ -1422,7 +1451,6 @@ func (c *Client) GetTeam(ctx context.Context, key string) (*Team, error) {
 				name
 				description
 				private
-				issueCount
 			}
 		}
 	`
@@ -1435,7 +1463,35 @@ func (c *Client) GetTeam(ctx context.Context, key string) (*Team, error) {
 		Team Team `json:"team"`
 	}
 
-	err := c.Execute(ctx, query, variables, &response)
+	err := c.executeCached(ctx, "Team", query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Team, nil
+}
+
+// GetTeamDetails returns a single team by key with its current issue count,
+// bypassing the cache.
+func (c *Client) GetTeamDetails(ctx context.Context, key string) (*Team, error) {
+	query := `
+		query TeamDetails($key: String!) {
+			team(id: $key) {
+				id
+				key
+				name
+				description
+				private
+				issueCount
+			}
+		}
+	`
+
+	var response struct {
+		Team Team `json:"team"`
+	}
+
+	err := c.Execute(ctx, query, map[string]interface{}{"key": key}, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -1506,7 +1562,7 @@ func (c *Client) GetTeamStates(ctx context.Context, teamKey string) ([]WorkflowS
 		} `json:"team"`
 	}
 
-	err := c.Execute(ctx, query, variables, &response)
+	err := c.executeCached(ctx, "TeamStates", query, variables, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -1547,7 +1603,7 @@ func (c *Client) GetTeamLabels(ctx context.Context, teamKey string) ([]Label, er
 		} `json:"team"`
 	}
 
-	err := c.Execute(ctx, query, variables, &response)
+	err := c.executeCached(ctx, "TeamLabels", query, variables, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -1633,7 +1689,7 @@ func (c *Client) GetUsers(ctx context.Context, first int, after string, orderBy
 		Users Users `json:"users"`
 	}
 
-	err := c.Execute(ctx, query, variables, &response)
+	err := c.executeCached(ctx, "Users", query, variables, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -1903,6 +1959,105 @@ func (c *Client) CreateAttachment(ctx context.Context, input map[string]interfac
 	return &response.AttachmentCreate.Attachment, nil
 }
 
+// ArchiveIssue archives an issue by ID or identifier
+func (c *Client) ArchiveIssue(ctx context.Context, id string) error {
+	query := `
+		mutation ArchiveIssue($id: String!) {
+			issueArchive(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		IssueArchive struct {
+			Success bool `json:"success"`
+		} `json:"issueArchive"`
+	}
+
+	// Archiving is idempotent
+	err := c.Execute(WithRetrySafe(ctx), query, variables, &response)
+	if err != nil {
+		return err
+	}
+
+	if !response.IssueArchive.Success {
+		return fmt.Errorf("issue %s could not be archived", id)
+	}
+
+	return nil
+}
+
+// UnarchiveIssue restores an archived issue by ID or identifier
+func (c *Client) UnarchiveIssue(ctx context.Context, id string) error {
+	query := `
+		mutation UnarchiveIssue($id: String!) {
+			issueUnarchive(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		IssueUnarchive struct {
+			Success bool `json:"success"`
+		} `json:"issueUnarchive"`
+	}
+
+	// Unarchiving is idempotent
+	err := c.Execute(WithRetrySafe(ctx), query, variables, &response)
+	if err != nil {
+		return err
+	}
+
+	if !response.IssueUnarchive.Success {
+		return fmt.Errorf("issue %s could not be unarchived", id)
+	}
+
+	return nil
+}
+
+// DeleteIssue moves an issue to the trash, where it can be restored from
+// the web app for 30 days
+func (c *Client) DeleteIssue(ctx context.Context, id string) error {
+	query := `
+		mutation DeleteIssue($id: String!) {
+			issueDelete(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": id,
+	}
+
+	var response struct {
+		IssueDelete struct {
+			Success bool `json:"success"`
+		} `json:"issueDelete"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return err
+	}
+
+	if !response.IssueDelete.Success {
+		return fmt.Errorf("issue %s could not be deleted", id)
+	}
+
+	return nil
+}
+
 // DeleteComment deletes a comment by ID
 func (c *Client) DeleteComment(ctx context.Context, commentID string) error {
 	query := `
@@ -2008,7 +2163,8 @@ func (c *Client) ResolveComment(ctx context.Context, commentID string) (*Comment
 		} `json:"commentUpdate"`
 	}
 
-	err = c.Execute(ctx, query, variables, &response)
+	// Resolving an already-resolved comment is a no-op, so this may be retried
+	err = c.Execute(WithRetrySafe(ctx), query, variables, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -2055,7 +2211,8 @@ func (c *Client) UnresolveComment(ctx context.Context, commentID string) (*Comme
 		} `json:"commentUpdate"`
 	}
 
-	err := c.Execute(ctx, query, variables, &response)
+	// Clearing resolution twice leaves the comment in the same state
+	err := c.Execute(WithRetrySafe(ctx), query, variables, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -2160,7 +2317,8 @@ func (c *Client) UpdateProject(ctx context.Context, id string, input map[string]
 		} `json:"projectUpdate"`
 	}
 
-	err := c.Execute(ctx, query, variables, &response)
+	// projectUpdate sets fields to fixed values, so replaying it is safe
+	err := c.Execute(WithRetrySafe(ctx), query, variables, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -2188,10 +2346,201 @@ func (c *Client) ArchiveProject(ctx context.Context, id string) (bool, error) {
 		} `json:"projectArchive"`
 	}
 
-	err := c.Execute(ctx, query, variables, &response)
+	// Archiving is idempotent
+	err := c.Execute(WithRetrySafe(ctx), query, variables, &response)
 	if err != nil {
 		return false, err
 	}
 
 	return response.ProjectArchive.Success, nil
 }
+
+// GetCustomViews returns the custom views visible to the user
+func (c *Client) GetCustomViews(ctx context.Context, first int, after string) (*CustomViews, error) {
+	query := `
+		query CustomViews($first: Int, $after: String) {
+			customViews(first: $first, after: $after) {
+				nodes {
+					id
+					name
+					description
+					slugId
+					modelName
+					shared
+					filterData
+					createdAt
+					updatedAt
+					team {
+						id
+						key
+						name
+					}
+					creator {
+						id
+						name
+						email
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"first": first,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var response struct {
+		CustomViews CustomViews `json:"customViews"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.CustomViews, nil
+}
+
+// GetCustomViewIssues returns the issues matching a custom view, narrowed by
+// an optional extra filter
+func (c *Client) GetCustomViewIssues(ctx context.Context, id string, filter map[string]interface{}, first int, after string, orderBy string) (*Issues, error) {
+	query := `
+		query CustomViewIssues($id: String!, $filter: IssueFilter, $first: Int, $after: String, $orderBy: PaginationOrderBy) {
+			customView(id: $id) {
+				issues(filter: $filter, first: $first, after: $after, orderBy: $orderBy) {
+					nodes {
+						id
+						identifier
+						title
+						description
+						priority
+						estimate
+						createdAt
+						updatedAt
+						dueDate
+						url
+						state {
+							id
+							name
+							type
+							color
+						}
+						assignee {
+							id
+							name
+							email
+						}
+						team {
+							id
+							key
+							name
+						}
+						labels {
+							nodes {
+								id
+								name
+								color
+							}
+						}
+						project {
+							id
+							name
+							state
+						}
+						cycle {
+							id
+							number
+							name
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    id,
+		"first": first,
+	}
+	if filter != nil {
+		variables["filter"] = filter
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+	if orderBy != "" {
+		variables["orderBy"] = orderBy
+	}
+
+	var response struct {
+		CustomView struct {
+			Issues Issues `json:"issues"`
+		} `json:"customView"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.CustomView.Issues, nil
+}
+
+// CreateCustomView creates a new custom view
+func (c *Client) CreateCustomView(ctx context.Context, input map[string]interface{}) (*CustomView, error) {
+	query := `
+		mutation CreateCustomView($input: CustomViewCreateInput!) {
+			customViewCreate(input: $input) {
+				customView {
+					id
+					name
+					description
+					slugId
+					modelName
+					shared
+					filterData
+					createdAt
+					updatedAt
+					team {
+						id
+						key
+						name
+					}
+					creator {
+						id
+						name
+						email
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		CustomViewCreate struct {
+			CustomView CustomView `json:"customView"`
+		} `json:"customViewCreate"`
+	}
+
+	err := c.Execute(ctx, query, variables, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.CustomViewCreate.CustomView, nil
+}
@@ -1,7 +1,12 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -248,3 +253,30 @@ func TestClientCachedViewerField(t *testing.T) {
 		t.Errorf("Expected cachedViewer.Name 'Test User', got '%s'", client.cachedViewer.Name)
 	}
 }
+
+func TestGetViewerCachedConcurrent(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1","name":"Ada"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			viewer, err := client.GetViewerCached(context.Background())
+			if err != nil || viewer.ID != "user-1" {
+				t.Errorf("GetViewerCached() = %v, %v", viewer, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if requests != 1 {
+		t.Errorf("Expected concurrent callers to share one request, got %d", requests)
+	}
+}
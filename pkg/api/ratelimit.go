@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Linear reports its leaky-bucket state on every GraphQL response using these headers.
+const (
+	headerRequestsLimit       = "X-RateLimit-Requests-Limit"
+	headerRequestsRemaining   = "X-RateLimit-Requests-Remaining"
+	headerRequestsReset       = "X-RateLimit-Requests-Reset"
+	headerComplexityLimit     = "X-RateLimit-Complexity-Limit"
+	headerComplexityRemaining = "X-RateLimit-Complexity-Remaining"
+	headerComplexityReset     = "X-RateLimit-Complexity-Reset"
+	headerQueryComplexity     = "X-Complexity"
+)
+
+// RateLimit describes one rate-limit bucket.
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// Used returns how much of the bucket has been consumed in the current window.
+func (r RateLimit) Used() int {
+	return r.Limit - r.Remaining
+}
+
+// RateLimitStatus is the latest rate-limit information reported by the API.
+type RateLimitStatus struct {
+	Requests            *RateLimit `json:"requests,omitempty"`
+	Complexity          *RateLimit `json:"complexity,omitempty"`
+	LastQueryComplexity int        `json:"lastQueryComplexity,omitempty"`
+	ObservedAt          time.Time  `json:"observedAt"`
+}
+
+// parseRateLimitHeaders extracts rate-limit information from response headers.
+// It returns nil when the response carried no rate-limit headers.
+func parseRateLimitHeaders(header http.Header, now time.Time) *RateLimitStatus {
+	status := &RateLimitStatus{
+		Requests:   parseRateLimitBucket(header, headerRequestsLimit, headerRequestsRemaining, headerRequestsReset),
+		Complexity: parseRateLimitBucket(header, headerComplexityLimit, headerComplexityRemaining, headerComplexityReset),
+		ObservedAt: now,
+	}
+	if v, err := strconv.Atoi(header.Get(headerQueryComplexity)); err == nil {
+		status.LastQueryComplexity = v
+	}
+
+	if status.Requests == nil && status.Complexity == nil {
+		return nil
+	}
+	return status
+}
+
+func parseRateLimitBucket(header http.Header, limitKey, remainingKey, resetKey string) *RateLimit {
+	limit, err := strconv.Atoi(header.Get(limitKey))
+	if err != nil {
+		return nil
+	}
+	remaining, err := strconv.Atoi(header.Get(remainingKey))
+	if err != nil {
+		return nil
+	}
+
+	bucket := &RateLimit{Limit: limit, Remaining: remaining}
+	// Reset is sent as UTC epoch milliseconds
+	if ms, err := strconv.ParseInt(header.Get(resetKey), 10, 64); err == nil {
+		bucket.Reset = time.UnixMilli(ms)
+	}
+	return bucket
+}
+
+// recordRateLimit stores the rate-limit headers of a response, if any.
+func (c *Client) recordRateLimit(header http.Header) {
+	status := parseRateLimitHeaders(header, time.Now())
+	if status == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimit = status
+}
+
+// LastRateLimit returns the rate-limit information from the most recent response,
+// or nil if no response has reported it yet.
+func (c *Client) LastRateLimit() *RateLimitStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.rateLimit == nil {
+		return nil
+	}
+	status := *c.rateLimit
+	return &status
+}
+
+// GetRateLimit returns the current rate-limit status. If no request has been made
+// yet, it issues a lightweight viewer query to read the headers.
+func (c *Client) GetRateLimit(ctx context.Context) (*RateLimitStatus, error) {
+	if status := c.LastRateLimit(); status != nil {
+		return status, nil
+	}
+
+	query := `
+		query RateLimitProbe {
+			viewer {
+				id
+			}
+		}
+	`
+	if err := c.Execute(ctx, query, nil, nil); err != nil {
+		return nil, err
+	}
+
+	status := c.LastRateLimit()
+	if status == nil {
+		return nil, fmt.Errorf("the API response did not include rate-limit headers")
+	}
+	return status, nil
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-RateLimit-Requests-Limit", "1500")
+	header.Set("X-RateLimit-Requests-Remaining", "1432")
+	header.Set("X-RateLimit-Requests-Reset", "1767225600000")
+	header.Set("X-RateLimit-Complexity-Limit", "250000")
+	header.Set("X-RateLimit-Complexity-Remaining", "249000")
+	header.Set("X-RateLimit-Complexity-Reset", "1767225600000")
+	header.Set("X-Complexity", "42")
+
+	now := time.Now()
+	status := parseRateLimitHeaders(header, now)
+	if status == nil {
+		t.Fatal("Expected rate-limit status, got nil")
+	}
+
+	if status.Requests == nil || status.Requests.Limit != 1500 || status.Requests.Remaining != 1432 {
+		t.Errorf("Unexpected requests bucket: %+v", status.Requests)
+	}
+	if status.Requests.Used() != 68 {
+		t.Errorf("Expected 68 used requests, got %d", status.Requests.Used())
+	}
+	if !status.Requests.Reset.Equal(time.UnixMilli(1767225600000)) {
+		t.Errorf("Unexpected reset time: %v", status.Requests.Reset)
+	}
+	if status.Complexity == nil || status.Complexity.Limit != 250000 || status.Complexity.Remaining != 249000 {
+		t.Errorf("Unexpected complexity bucket: %+v", status.Complexity)
+	}
+	if status.LastQueryComplexity != 42 {
+		t.Errorf("Expected last query complexity 42, got %d", status.LastQueryComplexity)
+	}
+	if !status.ObservedAt.Equal(now) {
+		t.Errorf("Expected ObservedAt %v, got %v", now, status.ObservedAt)
+	}
+}
+
+func TestParseRateLimitHeadersMissing(t *testing.T) {
+	if status := parseRateLimitHeaders(http.Header{}, time.Now()); status != nil {
+		t.Errorf("Expected nil status without headers, got %+v", status)
+	}
+}
+
+func TestExecuteRecordsRateLimit(t *testing.T) {
+	remaining := 100
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Requests-Limit", "1500")
+		w.Header().Set("X-RateLimit-Requests-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Requests-Reset", "1767225600000")
+		remaining--
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	if client.LastRateLimit() != nil {
+		t.Fatal("Expected no rate-limit status before any request")
+	}
+
+	// GetRateLimit probes the API when nothing has been observed yet
+	status, err := client.GetRateLimit(context.Background())
+	if err != nil {
+		t.Fatalf("GetRateLimit failed: %v", err)
+	}
+	if status.Requests.Remaining != 100 {
+		t.Errorf("Expected 100 remaining, got %d", status.Requests.Remaining)
+	}
+
+	// Subsequent requests replace the recorded values
+	if err := client.Execute(context.Background(), "query { viewer { id } }", nil, nil); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if got := client.LastRateLimit().Requests.Remaining; got != 99 {
+		t.Errorf("Expected 99 remaining after second request, got %d", got)
+	}
+}
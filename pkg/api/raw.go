@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// afterVariablePattern matches a declaration of the $after variable.
+var afterVariablePattern = regexp.MustCompile(`\$after\s*:`)
+
+// ExecuteRaw runs an arbitrary GraphQL document and returns its data object unchanged.
+func (c *Client) ExecuteRaw(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	var data json.RawMessage
+	if err := c.Execute(ctx, query, variables, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ExecutePaginated runs query once per page, passing the previous page's
+// pageInfo.endCursor as $after, until the connection has no next page. The
+// nodes (and edges) of every page are merged into the first page's connection,
+// whose pageInfo is replaced with the last page's. The query must declare an
+// $after variable and select pageInfo { hasNextPage endCursor } on exactly the
+// connection to paginate.
+func (c *Client) ExecutePaginated(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	if !afterVariablePattern.MatchString(query) {
+		return nil, fmt.Errorf("paginated queries must declare an $after: String variable")
+	}
+
+	vars := make(map[string]interface{}, len(variables)+1)
+	for key, value := range variables {
+		vars[key] = value
+	}
+
+	var (
+		merged     interface{}
+		connection map[string]interface{}
+	)
+	for {
+		raw, err := c.ExecuteRaw(ctx, query, vars)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := decodeJSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse page: %w", err)
+		}
+		pageConn := findConnection(page)
+		if pageConn == nil {
+			return nil, fmt.Errorf("paginated queries must select pageInfo { hasNextPage endCursor } and nodes")
+		}
+
+		if merged == nil {
+			merged, connection = page, pageConn
+		} else {
+			for _, key := range []string{"nodes", "edges"} {
+				if items, ok := pageConn[key].([]interface{}); ok {
+					existing, _ := connection[key].([]interface{})
+					connection[key] = append(existing, items...)
+				}
+			}
+			connection["pageInfo"] = pageConn["pageInfo"]
+		}
+
+		pageInfo, _ := pageConn["pageInfo"].(map[string]interface{})
+		hasNext, _ := pageInfo["hasNextPage"].(bool)
+		cursor, _ := pageInfo["endCursor"].(string)
+		if !hasNext || cursor == "" || cursor == vars["after"] {
+			break
+		}
+		vars["after"] = cursor
+	}
+
+	return json.Marshal(merged)
+}
+
+// decodeJSON decodes data keeping numbers as json.Number so IDs and counts
+// survive the round trip unchanged.
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// findConnection returns the first object (in key order) that holds a
+// pageInfo object next to a nodes or edges array.
+func findConnection(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if _, ok := v["pageInfo"].(map[string]interface{}); ok {
+			_, hasNodes := v["nodes"].([]interface{})
+			_, hasEdges := v["edges"].([]interface{})
+			if hasNodes || hasEdges {
+				return v
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if found := findConnection(v[key]); found != nil {
+				return found
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if found := findConnection(item); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestExecutePaginatedMergesNodes(t *testing.T) {
+	var firsts []int
+	server := newTeamPagesServer(t, 600, &firsts)
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	query := `query Teams($first: Int, $after: String) {
+		teams(first: $first, after: $after) { nodes { id } pageInfo { hasNextPage endCursor } }
+	}`
+
+	data, err := client.ExecutePaginated(context.Background(), query, map[string]interface{}{"first": 250})
+	if err != nil {
+		t.Fatalf("ExecutePaginated failed: %v", err)
+	}
+
+	var result struct {
+		Teams Teams `json:"teams"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to decode merged result: %v", err)
+	}
+	if len(result.Teams.Nodes) != 600 {
+		t.Errorf("Expected 600 merged nodes, got %d", len(result.Teams.Nodes))
+	}
+	if result.Teams.Nodes[599].ID != "team-599" {
+		t.Errorf("Expected nodes in order, last was %s", result.Teams.Nodes[599].ID)
+	}
+	if result.Teams.PageInfo.HasNextPage {
+		t.Error("Expected merged pageInfo to come from the last page")
+	}
+	if len(firsts) != 3 {
+		t.Errorf("Expected 3 requests, got %d", len(firsts))
+	}
+}
+
+func TestExecutePaginatedRequiresAfterVariable(t *testing.T) {
+	client := NewClientWithURL("http://linear.invalid/graphql", "test-key")
+	if _, err := client.ExecutePaginated(context.Background(), `query { teams { nodes { id } } }`, nil); err == nil {
+		t.Error("Expected error for query without $after")
+	}
+}
+
+func TestFindConnection(t *testing.T) {
+	value, err := decodeJSON([]byte(`{
+		"team": {
+			"name": "Engineering",
+			"issues": {
+				"nodes": [{"id": "1", "children": {"nodes": [], "pageInfo": {}}}],
+				"pageInfo": {"hasNextPage": true, "endCursor": "abc"}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("decodeJSON failed: %v", err)
+	}
+
+	conn := findConnection(value)
+	if conn == nil {
+		t.Fatal("Expected a connection")
+	}
+	if info := conn["pageInfo"].(map[string]interface{}); info["endCursor"] != "abc" {
+		t.Errorf("Expected the outer issues connection, got %+v", conn)
+	}
+
+	if findConnection(map[string]interface{}{"viewer": map[string]interface{}{"id": "1"}}) != nil {
+		t.Error("Expected no connection in a plain object")
+	}
+}
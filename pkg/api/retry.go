@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// RetryPolicy controls how Execute retries transient failures: network errors,
+// HTTP 429 and 5xx responses, and RATELIMITED GraphQL errors. Queries are always
+// eligible; mutations are only retried when their context is marked with WithRetrySafe.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry; it doubles on every attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff; zero means no cap. A Retry-After header
+	// from the server is followed instead, unless it asks for longer than
+	// MaxDelay, in which case the request fails rather than stalling.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used by new clients.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+	}
+}
+
+// SetRetryPolicy replaces the client's retry policy.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// RetryPolicy returns the client's retry policy.
+func (c *Client) RetryPolicy() RetryPolicy {
+	return c.retryPolicy
+}
+
+// networkError marks failures that happened before a complete response was received.
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string { return e.err.Error() }
+func (e *networkError) Unwrap() error { return e.err }
+
+type retrySafeKey struct{}
+
+// WithRetrySafe marks a mutation as idempotent so Execute may retry it.
+func WithRetrySafe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retrySafeKey{}, true)
+}
+
+func isRetrySafe(ctx context.Context) bool {
+	safe, _ := ctx.Value(retrySafeKey{}).(bool)
+	return safe
+}
+
+// isMutation reports whether a GraphQL document defines a mutation. It looks
+// for the operation keyword outside selection sets, skipping comments, strings
+// and fragment definitions, wherever the operation appears in the document.
+func isMutation(query string) bool {
+	depth := 0
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '#':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(query[i:], `"""`):
+			end := strings.Index(query[i+3:], `"""`)
+			if end < 0 {
+				return false
+			}
+			i += end + 5
+		case c == '"':
+			for i++; i < len(query) && query[i] != '"' && query[i] != '\n'; i++ {
+				if query[i] == '\\' {
+					i++
+				}
+			}
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		case c == '$' || c == '_' || unicode.IsLetter(rune(c)):
+			// Read the whole name so variables such as $mutation don't match
+			j := i + 1
+			for j < len(query) && (query[j] == '_' || unicode.IsLetter(rune(query[j])) || unicode.IsDigit(rune(query[j]))) {
+				j++
+			}
+			if depth == 0 && query[i:j] == "mutation" {
+				return true
+			}
+			i = j - 1
+		}
+	}
+	return false
+}
+
+// isRetryable reports whether a failed attempt is worth repeating.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrCassetteMiss) {
+		return false
+	}
+
+	var netErr *networkError
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && isRetryableStatus(httpErr.StatusCode) {
+		return true
+	}
+	return IsRateLimited(err)
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay returns how long to wait before retry number attempt (zero-based),
+// or false when the server's Retry-After exceeds MaxDelay and the request
+// should not be retried. Without Retry-After the delay is exponential with
+// "equal jitter", i.e. a random value between half and all of the backoff.
+func (p RetryPolicy) delay(attempt int, header http.Header) (time.Duration, bool) {
+	if d, ok := parseRetryAfter(header, time.Now()); ok {
+		return d, p.MaxDelay <= 0 || d <= p.MaxDelay
+	}
+
+	// A MaxDelay of zero means no cap; stop doubling before the backoff overflows
+	backoff := p.BaseDelay
+	for i := 0; i < attempt && backoff > 0 && backoff <= math.MaxInt64/2; i++ {
+		if p.MaxDelay > 0 && backoff >= p.MaxDelay {
+			break
+		}
+		backoff *= 2
+	}
+	if p.MaxDelay > 0 && backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1)), true
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		d := when.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newFlakyServer fails the first failures requests with status, then succeeds.
+func newFlakyServer(t *testing.T, failures, status int, header http.Header, calls *int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if *calls <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"try again"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"ok":true}}`))
+	}))
+}
+
+// recordSleeps replaces the client's sleep with one that records delays without waiting.
+func recordSleeps(client *Client) *[]time.Duration {
+	var delays []time.Duration
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return &delays
+}
+
+func TestExecuteRetriesQueries(t *testing.T) {
+	calls := 0
+	server := newFlakyServer(t, 2, http.StatusBadGateway, nil, &calls)
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	delays := recordSleeps(client)
+
+	if err := client.Execute(context.Background(), "query { viewer { id } }", nil, nil); err != nil {
+		t.Fatalf("Expected success after retries, got: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+	if len(*delays) != 2 {
+		t.Errorf("Expected 2 backoff sleeps, got %d", len(*delays))
+	}
+}
+
+func TestExecuteGivesUpAfterMaxRetries(t *testing.T) {
+	calls := 0
+	server := newFlakyServer(t, 10, http.StatusServiceUnavailable, nil, &calls)
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	recordSleeps(client)
+
+	if err := client.Execute(context.Background(), "query { viewer { id } }", nil, nil); err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestExecuteDoesNotRetryUnsafeMutations(t *testing.T) {
+	calls := 0
+	server := newFlakyServer(t, 1, http.StatusInternalServerError, nil, &calls)
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	recordSleeps(client)
+
+	mutation := `
+		mutation CreateIssue($input: IssueCreateInput!) {
+			issueCreate(input: $input) { success }
+		}
+	`
+	if err := client.Execute(context.Background(), mutation, nil, nil); err == nil {
+		t.Fatal("Expected unsafe mutation to fail without retrying")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call for unsafe mutation, got %d", calls)
+	}
+
+	calls = 0
+	if err := client.Execute(WithRetrySafe(context.Background()), mutation, nil, nil); err != nil {
+		t.Fatalf("Expected safe mutation to succeed after retry, got: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls for safe mutation, got %d", calls)
+	}
+}
+
+func TestExecuteDoesNotRetryClientErrors(t *testing.T) {
+	calls := 0
+	server := newFlakyServer(t, 1, http.StatusBadRequest, nil, &calls)
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	recordSleeps(client)
+
+	if err := client.Execute(context.Background(), "query { viewer { id } }", nil, nil); err == nil {
+		t.Fatal("Expected error for 400 response")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestExecuteHonorsRetryAfter(t *testing.T) {
+	calls := 0
+	header := http.Header{"Retry-After": []string{"7"}}
+	server := newFlakyServer(t, 1, http.StatusTooManyRequests, header, &calls)
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	delays := recordSleeps(client)
+
+	if err := client.Execute(context.Background(), "query { viewer { id } }", nil, nil); err != nil {
+		t.Fatalf("Expected success after retry, got: %v", err)
+	}
+	if len(*delays) != 1 || (*delays)[0] != 7*time.Second {
+		t.Errorf("Expected a single 7s delay, got %v", *delays)
+	}
+}
+
+func TestExecuteGivesUpOnRetryAfterBeyondMaxDelay(t *testing.T) {
+	calls := 0
+	header := http.Header{"Retry-After": []string{"86400"}}
+	server := newFlakyServer(t, 1, http.StatusTooManyRequests, header, &calls)
+	defer server.Close()
+
+	client := NewClientWithURL(server.URL, "test-key")
+	delays := recordSleeps(client)
+
+	err := client.Execute(context.Background(), "query { viewer { id } }", nil, nil)
+	if err == nil {
+		t.Fatal("Expected the rate-limit error instead of waiting a day")
+	}
+	if calls != 1 || len(*delays) != 0 {
+		t.Errorf("Expected no retry, got %d calls and delays %v", calls, *delays)
+	}
+
+	// Without a cap the server's Retry-After is followed
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 1})
+	calls = 0
+	if err := client.Execute(context.Background(), "query { viewer { id } }", nil, nil); err != nil {
+		t.Fatalf("Expected success after retry, got: %v", err)
+	}
+	if len(*delays) != 1 || (*delays)[0] != 24*time.Hour {
+		t.Errorf("Expected a single 24h delay, got %v", *delays)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, false},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+	for _, c := range cases {
+		header := http.Header{}
+		if c.value != "" {
+			header.Set("Retry-After", c.value)
+		}
+		got, ok := parseRetryAfter(header, now)
+		if got != c.want || ok != c.ok {
+			t.Errorf("parseRetryAfter(%q) = (%v, %v), want (%v, %v)", c.value, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt, ceiling := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		ceiling *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			d, _ := policy.delay(attempt, nil)
+			if d < ceiling/2 || d > ceiling {
+				t.Fatalf("delay(%d) = %v, want between %v and %v", attempt, d, ceiling/2, ceiling)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyDelayUncapped(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, BaseDelay: 100 * time.Millisecond}
+
+	for attempt, ceiling := range []time.Duration{100, 200, 400, 800, 1600} {
+		ceiling *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			d, _ := policy.delay(attempt, nil)
+			if d < ceiling/2 || d > ceiling {
+				t.Fatalf("delay(%d) = %v, want between %v and %v", attempt, d, ceiling/2, ceiling)
+			}
+		}
+	}
+	if d, _ := policy.delay(100, nil); d <= 0 {
+		t.Errorf("Expected a large attempt not to overflow, got %v", d)
+	}
+}
+
+func TestIsMutation(t *testing.T) {
+	cases := map[string]bool{
+		"query { viewer { id } }":                    false,
+		"\n\t\tmutation UpdateIssue($id: String!) {": true,
+		"# comment\nmutation { x }":                  true,
+		"{ viewer { id } }":                          false,
+		"fragment F on Issue { id }\nmutation M { issueArchive(id: \"x\") { success } }": true,
+		"fragment F on Issue { mutation }\nquery Q { issue(id: \"mutation\") { ...F } }": false,
+		"query Q($mutation: Boolean) { viewer { id } }":                                  false,
+		"query Q { a }\n# mutation { b }":                                                false,
+	}
+	for query, want := range cases {
+		if got := isMutation(query); got != want {
+			t.Errorf("isMutation(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces credentials in logged headers.
+const redacted = "[REDACTED]"
+
+// TraceEvent describes one GraphQL request as seen on the wire, or one
+// lookup served from the cache.
+type TraceEvent struct {
+	Time       time.Time              `json:"time"`
+	Operation  string                 `json:"operation"`
+	URL        string                 `json:"url,omitempty"`
+	Variables  map[string]interface{} `json:"variables,omitempty"`
+	Headers    map[string]string      `json:"headers,omitempty"`
+	Status     int                    `json:"status,omitempty"`
+	DurationMs float64                `json:"durationMs"`
+	Bytes      int                    `json:"bytes"`
+	Cached     bool                   `json:"cached,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	// RateLimit holds the rate-limit headers of the response, when it had any
+	RateLimit *RateLimitStatus `json:"rateLimit,omitempty"`
+}
+
+// Tracer reports API traffic. Log receives human-readable lines (usually
+// stderr for --debug) and File receives one JSON event per line; either may be nil.
+type Tracer struct {
+	Log  io.Writer
+	File io.Writer
+
+	mu sync.Mutex
+}
+
+// NewTracer returns a tracer writing debug lines to log and JSON events to file.
+func NewTracer(log, file io.Writer) *Tracer {
+	return &Tracer{Log: log, File: file}
+}
+
+// SetTracer enables request tracing. A nil tracer disables it.
+func (c *Client) SetTracer(tracer *Tracer) {
+	c.tracer = tracer
+}
+
+// Record writes an event to the configured outputs.
+func (t *Tracer) Record(event TraceEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.Log != nil {
+		t.writeLog(event)
+	}
+	if t.File != nil {
+		if line, err := json.Marshal(event); err == nil {
+			_, _ = t.File.Write(append(line, '\n'))
+		}
+	}
+}
+
+func (t *Tracer) writeLog(event TraceEvent) {
+	if event.Cached {
+		_, _ = fmt.Fprintf(t.Log, "[debug] %s served from cache (%d bytes)\n", event.Operation, event.Bytes)
+		return
+	}
+
+	_, _ = fmt.Fprintf(t.Log, "[debug] POST %s %s\n", event.URL, event.Operation)
+	if len(event.Headers) > 0 {
+		names := make([]string, 0, len(event.Headers))
+		for name := range event.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		parts := make([]string, len(names))
+		for i, name := range names {
+			parts[i] = name + ": " + event.Headers[name]
+		}
+		_, _ = fmt.Fprintf(t.Log, "[debug]   headers: %s\n", strings.Join(parts, ", "))
+	}
+	if len(event.Variables) > 0 {
+		vars, _ := json.Marshal(event.Variables)
+		_, _ = fmt.Fprintf(t.Log, "[debug]   variables: %s\n", vars)
+	}
+
+	duration := time.Duration(event.DurationMs * float64(time.Millisecond)).Round(time.Millisecond)
+	if event.Error != "" {
+		_, _ = fmt.Fprintf(t.Log, "[debug]   failed after %s: %s\n", duration, event.Error)
+		return
+	}
+	_, _ = fmt.Fprintf(t.Log, "[debug]   %d %s in %s, %d bytes\n",
+		event.Status, http.StatusText(event.Status), duration, event.Bytes)
+	if rl := event.RateLimit; rl != nil {
+		var parts []string
+		for _, bucket := range []struct {
+			name string
+			*RateLimit
+		}{{"requests", rl.Requests}, {"complexity", rl.Complexity}} {
+			if bucket.RateLimit != nil {
+				parts = append(parts, fmt.Sprintf("%d/%d %s left, resets %s", bucket.Remaining, bucket.Limit,
+					bucket.name, bucket.Reset.Local().Format(time.TimeOnly)))
+			}
+		}
+		_, _ = fmt.Fprintf(t.Log, "[debug]   rate limit: %s\n", strings.Join(parts, "; "))
+	}
+}
+
+var operationNamePattern = regexp.MustCompile(`^\s*(query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// operationName returns the name of the operation in query, or "query" /
+// "mutation" for anonymous operations.
+func operationName(query string) string {
+	if m := operationNamePattern.FindStringSubmatch(query); m != nil {
+		return m[2]
+	}
+	if isMutation(query) {
+		return "mutation"
+	}
+	return "query"
+}
+
+// redactHeaders flattens headers for logging, hiding credentials.
+func redactHeaders(header http.Header) map[string]string {
+	out := make(map[string]string, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if strings.EqualFold(name, "Authorization") || strings.EqualFold(name, "Cookie") {
+			value = redacted
+		}
+		out[name] = value
+	}
+	return out
+}
+
+// traceRequest records a request sent by do, with the rate-limit headers of
+// its response.
+func (c *Client) traceRequest(jsonBody []byte, header, respHeader http.Header, status, size int, start time.Time, err error) {
+	var req GraphQLRequest
+	_ = json.Unmarshal(jsonBody, &req)
+
+	event := TraceEvent{
+		Time:       start,
+		Operation:  operationName(req.Query),
+		URL:        c.baseURL,
+		Variables:  req.Variables,
+		Headers:    redactHeaders(header),
+		Status:     status,
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+		Bytes:      size,
+		RateLimit:  parseRateLimitHeaders(respHeader, start),
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	c.tracer.Record(event)
+}
+
+// traceCacheHit records a lookup answered from the cache.
+func (c *Client) traceCacheHit(query string, variables map[string]interface{}, size int) {
+	c.tracer.Record(TraceEvent{
+		Time:      time.Now(),
+		Operation: operationName(query),
+		Variables: variables,
+		Bytes:     size,
+		Cached:    true,
+	})
+}
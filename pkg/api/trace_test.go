@@ -0,0 +1,125 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTracerLogsRequestsWithoutCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"team":{"id":"team-1","key":"ENG","name":"Engineering"}}}`))
+	}))
+	defer server.Close()
+
+	var log, file bytes.Buffer
+	client := NewClientWithURL(server.URL, "lin_api_secret")
+	client.SetTracer(NewTracer(&log, &file))
+
+	if _, err := client.GetTeam(context.Background(), "ENG"); err != nil {
+		t.Fatalf("GetTeam failed: %v", err)
+	}
+
+	out := log.String()
+	for _, want := range []string{"Team", `"key":"ENG"`, "200 OK", "Authorization: [REDACTED]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected debug log to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out+file.String(), "lin_api_secret") {
+		t.Error("Expected API key to be redacted from the trace")
+	}
+
+	var event TraceEvent
+	if err := json.Unmarshal(file.Bytes(), &event); err != nil {
+		t.Fatalf("Expected one JSON event in trace file: %v\n%s", err, file.String())
+	}
+	if event.Operation != "Team" || event.Status != 200 || event.Bytes == 0 || event.Variables["key"] != "ENG" {
+		t.Errorf("Unexpected trace event: %+v", event)
+	}
+}
+
+func TestTracerRecordsRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Requests-Limit", "1500")
+		w.Header().Set("X-RateLimit-Requests-Remaining", "1432")
+		w.Header().Set("X-RateLimit-Requests-Reset", "1767225600000")
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"user-1"}}}`))
+	}))
+	defer server.Close()
+
+	var log, file bytes.Buffer
+	client := NewClientWithURL(server.URL, "key")
+	client.SetTracer(NewTracer(&log, &file))
+
+	if _, err := client.GetViewer(context.Background()); err != nil {
+		t.Fatalf("GetViewer failed: %v", err)
+	}
+	if !strings.Contains(log.String(), "rate limit: 1432/1500 requests left") {
+		t.Errorf("Expected the rate limit in the debug log, got:\n%s", log.String())
+	}
+
+	var event TraceEvent
+	if err := json.Unmarshal(file.Bytes(), &event); err != nil {
+		t.Fatalf("Expected one JSON event in trace file: %v", err)
+	}
+	rl := event.RateLimit
+	if rl == nil || rl.Requests == nil || rl.Requests.Remaining != 1432 || rl.Requests.Limit != 1500 ||
+		!rl.Requests.Reset.Equal(time.UnixMilli(1767225600000)) || rl.Complexity != nil {
+		t.Errorf("Unexpected rate limit in trace event: %+v", rl)
+	}
+}
+
+func TestTracerRecordsCacheHitsAndFailures(t *testing.T) {
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"bad"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"organization":{"id":"org-1","name":"Acme"}}}`))
+	}))
+	defer server.Close()
+
+	var log bytes.Buffer
+	client := NewClientWithURL(server.URL, "key")
+	client.SetTracer(NewTracer(&log, nil))
+	client.SetCache(NewCache(t.TempDir(), time.Hour))
+
+	if _, err := client.GetOrganization(context.Background()); err == nil {
+		t.Fatal("Expected error from first request")
+	}
+	if !strings.Contains(log.String(), "400 Bad Request") {
+		t.Errorf("Expected failed status in log, got:\n%s", log.String())
+	}
+
+	failing = false
+	_, _ = client.GetOrganization(context.Background())
+	log.Reset()
+	if _, err := client.GetOrganization(context.Background()); err != nil {
+		t.Fatalf("GetOrganization failed: %v", err)
+	}
+	if !strings.Contains(log.String(), "Organization served from cache") {
+		t.Errorf("Expected cache hit in log, got:\n%s", log.String())
+	}
+}
+
+func TestOperationName(t *testing.T) {
+	cases := map[string]string{
+		"query Issues($first: Int) { issues { nodes { id } } }": "Issues",
+		"\n\t\tmutation UpdateIssue($id: String!) { x }":        "UpdateIssue",
+		"{ viewer { id } }":                    "query",
+		"mutation { issueCreate { success } }": "mutation",
+	}
+	for query, want := range cases {
+		if got := operationName(query); got != want {
+			t.Errorf("operationName(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
@@ -3,16 +3,20 @@ package auth
 import (
 	"bufio"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charlietran/linctl/pkg/api"
-	"github.com/fatih/color"
+	"github.com/charlietran/linctl/pkg/output"
 )
 
+// ErrNotAuthenticated is returned when no credentials are configured.
+var ErrNotAuthenticated = errors.New("not authenticated")
+
 type User struct {
 	ID        string `json:"id"`
 	Name      string `json:"name"`
@@ -22,6 +26,18 @@ type User struct {
 
 type AuthConfig struct {
 	APIKey string `json:"api_key,omitempty"`
+
+	// OAuth2 credentials, set by LoginOAuth
+	AccessToken  string     `json:"access_token,omitempty"`
+	RefreshToken string     `json:"refresh_token,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	ClientID     string     `json:"client_id,omitempty"`
+	ClientSecret string     `json:"client_secret,omitempty"`
+	TokenURL     string     `json:"token_url,omitempty"`
+
+	// CredentialHelper fetches the API key from an external program instead
+	// of storing it in this file
+	CredentialHelper string `json:"credential_helper,omitempty"`
 }
 
 // getConfigPath returns the path to the auth config file
@@ -33,47 +49,62 @@ func getConfigPath() (string, error) {
 	return filepath.Join(homeDir, ".linctl-auth.json"), nil
 }
 
-// saveAuth saves authentication credentials
+// saveAuth saves credentials to the active profile
 func saveAuth(config AuthConfig) error {
-	configPath, err := getConfigPath()
+	return storeProfile(config, false)
+}
+
+// saveLogin saves credentials to the active profile and makes it the current one
+func saveLogin(config AuthConfig) error {
+	return storeProfile(config, true)
+}
+
+func storeProfile(config AuthConfig, makeCurrent bool) error {
+	profile, err := ActiveProfile()
 	if err != nil {
 		return err
 	}
 
-	data, err := json.MarshalIndent(config, "", "  ")
+	file, err := loadAuthFile()
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(configPath, data, 0600)
+	file.Profiles[profile] = &config
+	if makeCurrent {
+		file.CurrentProfile = profile
+	}
+	return saveAuthFile(file)
 }
 
-// loadAuth loads authentication credentials
+// loadAuth loads the active profile's credentials
 func loadAuth() (*AuthConfig, error) {
-	configPath, err := getConfigPath()
+	profile, err := ActiveProfile()
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := os.ReadFile(configPath)
+	file, err := loadAuthFile()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("not authenticated")
-		}
 		return nil, err
 	}
 
-	var config AuthConfig
-	err = json.Unmarshal(data, &config)
-	if err != nil {
-		return nil, err
+	config, ok := file.Profiles[profile]
+	if !ok {
+		if profile != DefaultProfile {
+			return nil, fmt.Errorf("%w: profile %q has no credentials", ErrNotAuthenticated, profile)
+		}
+		return nil, ErrNotAuthenticated
 	}
 
-	return &config, nil
+	return config, nil
 }
 
 // GetAuthHeader returns the authorization header value
 // Precedence: LINEAR_API_KEY env var > LINCTL_API_KEY env var > config file
+// OAuth access tokens are refreshed when they are about to expire. Profiles
+// that use a credential helper, or LINCTL_CREDENTIAL_HELPER when the profile
+// has no stored credentials, get the key from the helper on every call.
 func GetAuthHeader() (string, error) {
 	if apiKey := strings.TrimSpace(os.Getenv("LINEAR_API_KEY")); apiKey != "" {
 		return apiKey, nil
@@ -86,30 +117,70 @@ func GetAuthHeader() (string, error) {
 
 	config, err := loadAuth()
 	if err != nil {
+		if helper := credentialHelperFromEnv(); helper != "" && errors.Is(err, ErrNotAuthenticated) {
+			profile, profileErr := ActiveProfile()
+			if profileErr != nil {
+				return "", profileErr
+			}
+			return helperGetKey(helper, profile)
+		}
 		return "", err
 	}
 
+	if config.CredentialHelper != "" {
+		profile, err := ActiveProfile()
+		if err != nil {
+			return "", err
+		}
+		return helperGetKey(config.CredentialHelper, profile)
+	}
+
 	if config.APIKey != "" {
 		return config.APIKey, nil
 	}
 
-	return "", fmt.Errorf("no valid authentication found")
+	if config.AccessToken != "" {
+		if config.needsRefresh(time.Now()) {
+			if err := refreshOAuthToken(context.Background(), config); err != nil {
+				return "", err
+			}
+			if err := saveAuth(*config); err != nil {
+				return "", err
+			}
+		}
+		return "Bearer " + config.AccessToken, nil
+	}
+
+	return "", fmt.Errorf("%w: no valid authentication found", ErrNotAuthenticated)
 }
 
-// Login handles the authentication flow
-func Login(plaintext, jsonOut bool) error {
-	return loginWithAPIKey(plaintext, jsonOut)
+// ClientFunc builds an API client for an auth header. Callers pass one that
+// applies their endpoint, retry, tracing and cassette settings.
+type ClientFunc func(authHeader string) *api.Client
+
+// Login handles the authentication flow. When credentialHelper (or
+// LINCTL_CREDENTIAL_HELPER) is set, the key is handed to the helper instead of
+// being written to the auth file. The key is checked with a client from newClient.
+func Login(plaintext, jsonOut bool, credentialHelper string, newClient ClientFunc) error {
+	if credentialHelper == "" {
+		credentialHelper = credentialHelperFromEnv()
+	}
+	return loginWithAPIKey(plaintext, jsonOut, credentialHelper, newClient)
 }
 
 // loginWithAPIKey handles Personal API Key authentication
-func loginWithAPIKey(plaintext, jsonOut bool) error {
+func loginWithAPIKey(plaintext, jsonOut bool, credentialHelper string, newClient ClientFunc) error {
 	if !plaintext && !jsonOut {
-		fmt.Println("\n" + color.New(color.FgYellow).Sprint("📝 Personal API Key Authentication"))
+		fmt.Println("\n" + output.Yellow.Sprint(output.IconMemo.Prefix("Personal API Key Authentication")))
 		fmt.Println("Get your API key from: https://linear.app/settings/api")
 
-		// Get the config path to show to the user
-		configPath, _ := getConfigPath()
-		fmt.Printf("Your credentials will be stored in: %s\n", color.New(color.FgCyan).Sprint(configPath))
+		// Tell the user where the key will end up
+		if credentialHelper != "" {
+			fmt.Printf("Your key will be stored by credential helper: %s\n", output.Cyan.Sprint(credentialHelper))
+		} else {
+			configPath, _ := getConfigPath()
+			fmt.Printf("Your credentials will be stored in: %s\n", output.Cyan.Sprint(configPath))
+		}
 		fmt.Print("\nEnter your Personal API Key: ")
 	}
 
@@ -125,39 +196,50 @@ func loginWithAPIKey(plaintext, jsonOut bool) error {
 	}
 
 	// Test the API key
-	client := api.NewClient(apiKey)
+	client := newClient(apiKey)
 	user, err := client.GetViewer(context.Background())
 	if err != nil {
-		return fmt.Errorf("invalid API key: %v", err)
+		return fmt.Errorf("invalid API key: %w", err)
 	}
 
-	// Save the API key
+	// Save the API key, or only a reference to the helper that keeps it
 	config := AuthConfig{
 		APIKey: apiKey,
 	}
-	err = saveAuth(config)
+	if credentialHelper != "" {
+		profile, err := ActiveProfile()
+		if err != nil {
+			return err
+		}
+		if err := helperStoreKey(credentialHelper, profile, apiKey); err != nil {
+			return err
+		}
+		config = AuthConfig{CredentialHelper: credentialHelper}
+	}
+	err = saveLogin(config)
 	if err != nil {
 		return err
 	}
 
 	if !plaintext && !jsonOut {
 		fmt.Printf("\n%s Authenticated as %s (%s)\n",
-			color.New(color.FgGreen).Sprint("✅"),
-			color.New(color.FgCyan).Sprint(user.Name),
-			color.New(color.FgCyan).Sprint(user.Email))
+			output.Green.Sprint(output.IconSuccess),
+			output.Cyan.Sprint(user.Name),
+			output.Cyan.Sprint(user.Email))
 	}
 
 	return nil
 }
 
-// GetCurrentUser returns the current authenticated user
-func GetCurrentUser() (*User, error) {
+// GetCurrentUser returns the current authenticated user, fetched with a client
+// from newClient
+func GetCurrentUser(newClient ClientFunc) (*User, error) {
 	authHeader, err := GetAuthHeader()
 	if err != nil {
 		return nil, err
 	}
 
-	client := api.NewClient(authHeader)
+	client := newClient(authHeader)
 	apiUser, err := client.GetViewer(context.Background())
 	if err != nil {
 		return nil, err
@@ -172,17 +254,27 @@ func GetCurrentUser() (*User, error) {
 	}, nil
 }
 
-// Logout clears stored credentials
+// Logout clears the active profile's stored credentials
 func Logout() error {
-	configPath, err := getConfigPath()
+	profile, err := ActiveProfile()
 	if err != nil {
 		return err
 	}
 
-	err = os.Remove(configPath)
-	if err != nil && !os.IsNotExist(err) {
+	file, err := loadAuthFile()
+	if err != nil {
 		return err
 	}
 
-	return nil
+	if config, ok := file.Profiles[profile]; ok && config.CredentialHelper != "" {
+		if err := helperEraseKey(config.CredentialHelper, profile); err != nil {
+			return err
+		}
+	}
+
+	delete(file.Profiles, profile)
+	if file.CurrentProfile == profile {
+		file.CurrentProfile = ""
+	}
+	return saveAuthFile(file)
 }
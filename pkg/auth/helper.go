@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// credentialHost identifies Linear to credential helpers, like git's host attribute.
+const credentialHost = "api.linear.app"
+
+// Credential helpers follow git's model. The helper is run with one of these
+// actions as its last argument and receives key=value attributes on stdin.
+const (
+	helperGet   = "get"
+	helperStore = "store"
+	helperErase = "erase"
+)
+
+// helperCommand builds the command for a credential helper setting:
+//   - "!cmd args" runs cmd through the shell
+//   - an absolute or relative path runs that program
+//   - a bare name runs linctl-credential-<name> from PATH
+func helperCommand(helper, action string) (*exec.Cmd, error) {
+	helper = strings.TrimSpace(helper)
+	if helper == "" {
+		return nil, fmt.Errorf("credential helper is empty")
+	}
+
+	if strings.HasPrefix(helper, "!") {
+		script := strings.TrimSpace(helper[1:])
+		return exec.Command("sh", "-c", script+` "$@"`, script, action), nil
+	}
+
+	fields := strings.Fields(helper)
+	name := fields[0]
+	if !filepath.IsAbs(name) && !strings.ContainsRune(name, filepath.Separator) {
+		name = "linctl-credential-" + name
+	}
+	args := append(fields[1:], action)
+	return exec.Command(name, args...), nil
+}
+
+// runCredentialHelper runs helper with action, passing attrs on stdin, and returns its stdout.
+func runCredentialHelper(helper, action string, attrs map[string]string) ([]byte, error) {
+	cmd, err := helperCommand(helper, action)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdin bytes.Buffer
+	for _, key := range []string{"protocol", "host", "profile", "username", "password"} {
+		if value, ok := attrs[key]; ok {
+			fmt.Fprintf(&stdin, "%s=%s\n", key, value)
+		}
+	}
+	stdin.WriteString("\n")
+
+	var stdout bytes.Buffer
+	cmd.Stdin = &stdin
+	cmd.Stdout = &stdout
+	// Helpers may need to prompt, e.g. for a GPG passphrase
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("credential helper %q %s failed: %w", helper, action, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+func helperAttrs(profile string) map[string]string {
+	return map[string]string{
+		"protocol": "https",
+		"host":     credentialHost,
+		"profile":  profile,
+	}
+}
+
+// helperGetKey asks the helper for the API key of profile. The helper may
+// answer with a password=<key> line, or print just the key.
+func helperGetKey(helper, profile string) (string, error) {
+	out, err := runCredentialHelper(helper, helperGet, helperAttrs(profile))
+	if err != nil {
+		return "", err
+	}
+
+	key := parseHelperOutput(out)
+	if key == "" {
+		return "", fmt.Errorf("%w: credential helper %q returned no key for profile %q", ErrNotAuthenticated, helper, profile)
+	}
+	return key, nil
+}
+
+// helperStoreKey hands the API key of profile to the helper for safekeeping.
+func helperStoreKey(helper, profile, key string) error {
+	attrs := helperAttrs(profile)
+	attrs["password"] = key
+	_, err := runCredentialHelper(helper, helperStore, attrs)
+	return err
+}
+
+// helperEraseKey asks the helper to forget the API key of profile.
+func helperEraseKey(helper, profile string) error {
+	_, err := runCredentialHelper(helper, helperErase, helperAttrs(profile))
+	return err
+}
+
+func parseHelperOutput(out []byte) string {
+	var first string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if value, ok := strings.CutPrefix(line, "password="); ok {
+			return strings.TrimSpace(value)
+		}
+		if first == "" {
+			first = line
+		}
+	}
+
+	// Plain output such as `pass show linear` prints only the key
+	if first != "" && !strings.Contains(first, "=") {
+		return first
+	}
+	return ""
+}
+
+// credentialHelperFromEnv returns the helper configured through LINCTL_CREDENTIAL_HELPER.
+func credentialHelperFromEnv() string {
+	return strings.TrimSpace(os.Getenv("LINCTL_CREDENTIAL_HELPER"))
+}
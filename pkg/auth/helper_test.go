@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFileHelper creates a credential helper script that keeps keys in a
+// file per profile inside dir, and returns its path.
+func writeFileHelper(t *testing.T, dir string) string {
+	t.Helper()
+	script := `#!/bin/sh
+profile=default
+password=
+while IFS= read -r line && [ -n "$line" ]; do
+  case "$line" in
+    profile=*) profile="${line#profile=}" ;;
+    password=*) password="${line#password=}" ;;
+  esac
+done
+store="` + dir + `/$profile.key"
+case "$1" in
+  get) [ -f "$store" ] && printf 'password=%s\n' "$(cat "$store")" ;;
+  store) printf '%s' "$password" > "$store" ;;
+  erase) rm -f "$store" ;;
+esac
+exit 0
+`
+	path := filepath.Join(dir, "helper.sh")
+	if err := os.WriteFile(path, []byte(script), 0700); err != nil {
+		t.Fatalf("failed to write helper: %v", err)
+	}
+	return path
+}
+
+func TestCredentialHelperStoreGetErase(t *testing.T) {
+	setupAuthHome(t)
+	t.Setenv("LINCTL_CREDENTIAL_HELPER", "")
+	dir := t.TempDir()
+	helper := writeFileHelper(t, dir)
+
+	if err := helperStoreKey(helper, "acme", "lin_api_secret"); err != nil {
+		t.Fatalf("store failed: %v", err)
+	}
+	if err := saveLogin(AuthConfig{CredentialHelper: helper}); err != nil {
+		t.Fatalf("saveLogin failed: %v", err)
+	}
+
+	// The key itself never reaches the auth file
+	data, err := os.ReadFile(filepath.Join(os.Getenv("HOME"), ".linctl-auth.json"))
+	if err != nil {
+		t.Fatalf("failed to read auth file: %v", err)
+	}
+	if strings.Contains(string(data), "lin_api_secret") {
+		t.Error("Expected auth file not to contain the API key")
+	}
+
+	SetProfile("acme")
+	if err := saveLogin(AuthConfig{CredentialHelper: helper}); err != nil {
+		t.Fatalf("saveLogin failed: %v", err)
+	}
+	header, err := GetAuthHeader()
+	if err != nil {
+		t.Fatalf("GetAuthHeader failed: %v", err)
+	}
+	if header != "lin_api_secret" {
+		t.Errorf("Expected key from helper, got %q", header)
+	}
+
+	if err := Logout(); err != nil {
+		t.Fatalf("Logout failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "acme.key")); !os.IsNotExist(err) {
+		t.Errorf("Expected helper to erase the key, got %v", err)
+	}
+}
+
+func TestCredentialHelperFromEnv(t *testing.T) {
+	setupAuthHome(t)
+	t.Setenv("LINCTL_CREDENTIAL_HELPER", "!echo lin_api_from_shell")
+
+	header, err := GetAuthHeader()
+	if err != nil {
+		t.Fatalf("GetAuthHeader failed: %v", err)
+	}
+	// The shell helper prints its arguments; "get" is appended as the action
+	if header != "lin_api_from_shell get" {
+		t.Errorf("Unexpected key from shell helper: %q", header)
+	}
+}
+
+func TestParseHelperOutput(t *testing.T) {
+	cases := map[string]string{
+		"password=lin_api_1\n":                   "lin_api_1",
+		"protocol=https\npassword=lin_api_2\n\n": "lin_api_2",
+		"lin_api_3\n":                            "lin_api_3",
+		"\n":                                     "",
+		"username=bot\n":                         "",
+	}
+	for out, want := range cases {
+		if got := parseHelperOutput([]byte(out)); got != want {
+			t.Errorf("parseHelperOutput(%q) = %q, want %q", out, got, want)
+		}
+	}
+}
+
+func TestHelperCommand(t *testing.T) {
+	cmd, err := helperCommand("vault --mount linear", "get")
+	if err != nil {
+		t.Fatalf("helperCommand failed: %v", err)
+	}
+	if filepath.Base(cmd.Path) != "linctl-credential-vault" && !strings.HasSuffix(cmd.Args[0], "linctl-credential-vault") {
+		t.Errorf("Expected linctl-credential-vault, got %v", cmd.Args)
+	}
+	if got := strings.Join(cmd.Args[1:], " "); got != "--mount linear get" {
+		t.Errorf("Unexpected args: %q", got)
+	}
+
+	if _, err := helperCommand("  ", "get"); err == nil {
+		t.Error("Expected error for empty helper")
+	}
+}
@@ -0,0 +1,319 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultAuthorizeURL = "https://linear.app/oauth/authorize"
+	DefaultTokenURL     = "https://api.linear.app/oauth/token"
+	DefaultOAuthScopes  = "read,write"
+
+	// refreshMargin is how long before expiry an access token is refreshed.
+	refreshMargin = time.Minute
+	// callbackTimeout bounds how long login waits for the browser redirect.
+	callbackTimeout = 5 * time.Minute
+)
+
+// tokenClient sends token exchange and refresh requests. Its timeout keeps a
+// stalled token endpoint from hanging every command that refreshes a token.
+var tokenClient = &http.Client{Timeout: 30 * time.Second}
+
+// OAuthConfig configures the OAuth2 authorization-code flow.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	AuthorizeURL string
+	TokenURL     string
+	Scopes       string
+	// Port for the loopback callback server; 0 picks a free port.
+	Port int
+	// OpenBrowser opens the authorization URL. When nil the URL is only printed.
+	OpenBrowser func(url string) error
+	// Out receives instructions for the user.
+	Out io.Writer
+}
+
+func (c *OAuthConfig) applyDefaults() {
+	if c.AuthorizeURL == "" {
+		c.AuthorizeURL = DefaultAuthorizeURL
+	}
+	if c.TokenURL == "" {
+		c.TokenURL = DefaultTokenURL
+	}
+	if c.Scopes == "" {
+		c.Scopes = DefaultOAuthScopes
+	}
+	if c.Out == nil {
+		c.Out = io.Discard
+	}
+}
+
+// tokenResponse is the body returned by the token endpoint.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+	Error        string `json:"error"`
+	ErrorDesc    string `json:"error_description"`
+}
+
+// LoginOAuth runs the OAuth2 authorization-code flow with PKCE: it starts a
+// loopback callback server, sends the user to the authorize endpoint, exchanges
+// the returned code for tokens and stores them.
+func LoginOAuth(ctx context.Context, cfg OAuthConfig) error {
+	cfg.applyDefaults()
+	if cfg.ClientID == "" {
+		return fmt.Errorf("an OAuth client ID is required")
+	}
+
+	verifier, err := randomString(32)
+	if err != nil {
+		return err
+	}
+	state, err := randomString(16)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", cfg.Port))
+	if err != nil {
+		return fmt.Errorf("failed to start callback server: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+
+	authURL, err := buildAuthorizeURL(cfg, redirectURI, state, pkceChallenge(verifier))
+	if err != nil {
+		_ = listener.Close()
+		return err
+	}
+
+	type callbackResult struct {
+		code string
+		err  error
+	}
+	results := make(chan callbackResult, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var result callbackResult
+		switch {
+		case query.Get("state") != state:
+			result.err = fmt.Errorf("OAuth state mismatch")
+		case query.Get("error") != "":
+			result.err = fmt.Errorf("authorization denied: %s", query.Get("error"))
+		case query.Get("code") == "":
+			result.err = fmt.Errorf("authorization response did not include a code")
+		default:
+			result.code = query.Get("code")
+		}
+
+		if result.err != nil {
+			http.Error(w, result.err.Error(), http.StatusBadRequest)
+		} else {
+			_, _ = fmt.Fprintln(w, "linctl is now authenticated with Linear. You can close this window.")
+		}
+
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Close() }()
+
+	_, _ = fmt.Fprintf(cfg.Out, "Open this URL in your browser to authorize linctl:\n\n  %s\n\n", authURL)
+	if cfg.OpenBrowser != nil {
+		if err := cfg.OpenBrowser(authURL); err != nil {
+			_, _ = fmt.Fprintf(cfg.Out, "Could not open a browser automatically: %v\n", err)
+		}
+	}
+	_, _ = fmt.Fprintln(cfg.Out, "Waiting for authorization...")
+
+	ctx, cancel := context.WithTimeout(ctx, callbackTimeout)
+	defer cancel()
+
+	var result callbackResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for authorization: %w", ctx.Err())
+	}
+	if result.err != nil {
+		return result.err
+	}
+
+	token, err := requestToken(ctx, cfg.TokenURL, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {result.code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {cfg.ClientID},
+		"client_secret": {cfg.ClientSecret},
+		"code_verifier": {verifier},
+	})
+	if err != nil {
+		return err
+	}
+
+	config := AuthConfig{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		TokenURL:     cfg.TokenURL,
+	}
+	config.setToken(token, time.Now())
+	return saveLogin(config)
+}
+
+// buildAuthorizeURL adds the authorization request parameters to the authorize endpoint.
+func buildAuthorizeURL(cfg OAuthConfig, redirectURI, state, challenge string) (string, error) {
+	u, err := url.Parse(cfg.AuthorizeURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorize URL: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", cfg.ClientID)
+	q.Set("redirect_uri", redirectURI)
+	q.Set("scope", cfg.Scopes)
+	q.Set("state", state)
+	q.Set("code_challenge", challenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// refreshOAuthToken exchanges the stored refresh token for a new access token.
+func refreshOAuthToken(ctx context.Context, config *AuthConfig) error {
+	tokenURL := config.TokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultTokenURL
+	}
+
+	token, err := requestToken(ctx, tokenURL, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {config.RefreshToken},
+		"client_id":     {config.ClientID},
+		"client_secret": {config.ClientSecret},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to refresh OAuth token: %w", err)
+	}
+
+	config.setToken(token, time.Now())
+	return nil
+}
+
+// requestToken posts a form to the token endpoint and decodes the response.
+func requestToken(ctx context.Context, tokenURL string, form url.Values) (*tokenResponse, error) {
+	for key, values := range form {
+		if len(values) == 1 && values[0] == "" {
+			form.Del(key)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := tokenClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || token.Error != "" {
+		msg := token.ErrorDesc
+		if msg == "" {
+			msg = token.Error
+		}
+		if msg == "" {
+			msg = string(body)
+		}
+		return nil, fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode, msg)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response did not include an access token")
+	}
+
+	return &token, nil
+}
+
+// setToken stores a token response in the config. A refresh response without a
+// new refresh token keeps the existing one.
+func (c *AuthConfig) setToken(token *tokenResponse, now time.Time) {
+	c.APIKey = ""
+	c.AccessToken = token.AccessToken
+	if token.RefreshToken != "" {
+		c.RefreshToken = token.RefreshToken
+	}
+	c.ExpiresAt = nil
+	if token.ExpiresIn > 0 {
+		expiresAt := now.Add(time.Duration(token.ExpiresIn) * time.Second)
+		c.ExpiresAt = &expiresAt
+	}
+}
+
+// needsRefresh reports whether the access token is expired or about to expire.
+func (c *AuthConfig) needsRefresh(now time.Time) bool {
+	return c.RefreshToken != "" && c.ExpiresAt != nil && now.Add(refreshMargin).After(*c.ExpiresAt)
+}
+
+// pkceChallenge derives the S256 code challenge for a verifier.
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// randomString returns n random bytes encoded as unpadded base64url.
+func randomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// OpenBrowser opens url in the user's default browser.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newTokenServer stands in for Linear's token endpoint. It checks the PKCE
+// verifier against the challenge sent to the authorize endpoint.
+func newTokenServer(t *testing.T, challenge *string, grants *[]url.Values) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse token request: %v", err)
+		}
+		*grants = append(*grants, r.PostForm)
+
+		resp := map[string]interface{}{"token_type": "Bearer", "expires_in": 3600}
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			if pkceChallenge(r.PostForm.Get("code_verifier")) != *challenge {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+				return
+			}
+			resp["access_token"] = "access-1"
+			resp["refresh_token"] = "refresh-1"
+		case "refresh_token":
+			resp["access_token"] = "access-2"
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestLoginOAuthPKCE(t *testing.T) {
+	setupAuthHome(t)
+
+	var challenge string
+	var grants []url.Values
+	tokenServer := newTokenServer(t, &challenge, &grants)
+	defer tokenServer.Close()
+
+	// The "browser" approves immediately by following the redirect with a code
+	openBrowser := func(authURL string) error {
+		u, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+		q := u.Query()
+		if q.Get("code_challenge_method") != "S256" {
+			t.Errorf("Expected S256 challenge method, got %q", q.Get("code_challenge_method"))
+		}
+		if q.Get("client_id") != "client-123" || q.Get("scope") != "read,write" {
+			t.Errorf("Unexpected authorize parameters: %v", q)
+		}
+		challenge = q.Get("code_challenge")
+
+		go func() {
+			resp, err := http.Get(q.Get("redirect_uri") + "?code=code-abc&state=" + url.QueryEscape(q.Get("state")))
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	err := LoginOAuth(context.Background(), OAuthConfig{
+		ClientID:     "client-123",
+		AuthorizeURL: "http://authorize.test/oauth/authorize",
+		TokenURL:     tokenServer.URL,
+		OpenBrowser:  openBrowser,
+	})
+	if err != nil {
+		t.Fatalf("LoginOAuth failed: %v", err)
+	}
+
+	if len(grants) != 1 || grants[0].Get("code") != "code-abc" {
+		t.Fatalf("Expected one authorization_code exchange with code-abc, got %v", grants)
+	}
+
+	header, err := GetAuthHeader()
+	if err != nil {
+		t.Fatalf("GetAuthHeader failed: %v", err)
+	}
+	if header != "Bearer access-1" {
+		t.Errorf("Expected 'Bearer access-1', got %q", header)
+	}
+}
+
+func TestLoginOAuthRejectsStateMismatch(t *testing.T) {
+	setupAuthHome(t)
+
+	openBrowser := func(authURL string) error {
+		u, _ := url.Parse(authURL)
+		go func() {
+			resp, err := http.Get(u.Query().Get("redirect_uri") + "?code=code-abc&state=forged")
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	err := LoginOAuth(context.Background(), OAuthConfig{
+		ClientID:    "client-123",
+		TokenURL:    "http://127.0.0.1:1/unused",
+		OpenBrowser: openBrowser,
+	})
+	if err == nil {
+		t.Fatal("Expected state mismatch error")
+	}
+}
+
+func TestGetAuthHeaderRefreshesExpiredToken(t *testing.T) {
+	setupAuthHome(t)
+
+	var challenge string
+	var grants []url.Values
+	tokenServer := newTokenServer(t, &challenge, &grants)
+	defer tokenServer.Close()
+
+	expired := time.Now().Add(-time.Minute)
+	if err := saveAuth(AuthConfig{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    &expired,
+		ClientID:     "client-123",
+		TokenURL:     tokenServer.URL,
+	}); err != nil {
+		t.Fatalf("saveAuth failed: %v", err)
+	}
+
+	header, err := GetAuthHeader()
+	if err != nil {
+		t.Fatalf("GetAuthHeader failed: %v", err)
+	}
+	if header != "Bearer access-2" {
+		t.Errorf("Expected refreshed token, got %q", header)
+	}
+	if len(grants) != 1 || grants[0].Get("refresh_token") != "refresh-1" {
+		t.Errorf("Expected one refresh_token grant, got %v", grants)
+	}
+
+	// The refreshed token is persisted and the refresh token kept
+	config, err := loadAuth()
+	if err != nil {
+		t.Fatalf("loadAuth failed: %v", err)
+	}
+	if config.AccessToken != "access-2" || config.RefreshToken != "refresh-1" {
+		t.Errorf("Unexpected stored tokens: %+v", config)
+	}
+	if config.ExpiresAt == nil || !config.ExpiresAt.After(time.Now()) {
+		t.Errorf("Expected a future expiry, got %v", config.ExpiresAt)
+	}
+
+	// A fresh token is used as-is
+	if _, err := GetAuthHeader(); err != nil {
+		t.Fatalf("GetAuthHeader failed: %v", err)
+	}
+	if len(grants) != 1 {
+		t.Errorf("Expected no further refresh, got %d grants", len(grants))
+	}
+}
+
+func TestTokenRefreshTimesOut(t *testing.T) {
+	setupAuthHome(t)
+
+	release := make(chan struct{})
+	stalled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer stalled.Close()
+	defer close(release)
+
+	saved := tokenClient
+	tokenClient = &http.Client{Timeout: 50 * time.Millisecond}
+	defer func() { tokenClient = saved }()
+
+	expired := time.Now().Add(-time.Minute)
+	if err := saveAuth(AuthConfig{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    &expired,
+		ClientID:     "client-123",
+		TokenURL:     stalled.URL,
+	}); err != nil {
+		t.Fatalf("saveAuth failed: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := GetAuthHeader()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Expected a stalled token endpoint to fail the refresh")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the token refresh to time out")
+	}
+}
+
+func TestPKCEChallenge(t *testing.T) {
+	// Example from RFC 7636, Appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	if got := pkceChallenge(verifier); got != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Errorf("Unexpected challenge: %s", got)
+	}
+}
@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultProfile is used when no profile is selected and for credentials
+// stored before profiles existed.
+const DefaultProfile = "default"
+
+// profileOverride is set from the global --profile flag.
+var profileOverride string
+
+// authFile is the on-disk layout of ~/.linctl-auth.json.
+type authFile struct {
+	CurrentProfile string                 `json:"current_profile,omitempty"`
+	Profiles       map[string]*AuthConfig `json:"profiles,omitempty"`
+
+	// Credentials written by older versions live at the top level; they are
+	// moved into the default profile when the file is next saved.
+	AuthConfig
+}
+
+// Profile describes a stored auth profile.
+type Profile struct {
+	Name    string `json:"name"`
+	Method  string `json:"method"`
+	Current bool   `json:"current"`
+}
+
+// SetProfile selects the profile used by this process, overriding
+// LINCTL_PROFILE and the stored current profile. An empty name clears it.
+func SetProfile(name string) {
+	profileOverride = strings.TrimSpace(name)
+}
+
+// ActiveProfile returns the profile in use: the --profile flag, then the
+// LINCTL_PROFILE environment variable, then the profile chosen with
+// `auth switch` or the last login, then "default".
+func ActiveProfile() (string, error) {
+	if profileOverride != "" {
+		return profileOverride, nil
+	}
+	if env := strings.TrimSpace(os.Getenv("LINCTL_PROFILE")); env != "" {
+		return env, nil
+	}
+
+	file, err := loadAuthFile()
+	if err != nil {
+		return "", err
+	}
+	if file.CurrentProfile != "" {
+		return file.CurrentProfile, nil
+	}
+	return DefaultProfile, nil
+}
+
+// ListProfiles returns all stored profiles sorted by name.
+func ListProfiles() ([]Profile, error) {
+	file, err := loadAuthFile()
+	if err != nil {
+		return nil, err
+	}
+	active, err := ActiveProfile()
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]Profile, 0, len(file.Profiles))
+	for name, config := range file.Profiles {
+		profiles = append(profiles, Profile{
+			Name:    name,
+			Method:  config.method(),
+			Current: name == active,
+		})
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// SwitchProfile makes name the current profile for future invocations.
+func SwitchProfile(name string) error {
+	file, err := loadAuthFile()
+	if err != nil {
+		return err
+	}
+	if _, ok := file.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+
+	file.CurrentProfile = name
+	return saveAuthFile(file)
+}
+
+// method describes how a profile authenticates.
+func (c *AuthConfig) method() string {
+	switch {
+	case c.CredentialHelper != "":
+		return "credential-helper"
+	case c.AccessToken != "":
+		return "oauth"
+	case c.APIKey != "":
+		return "api-key"
+	}
+	return "none"
+}
+
+// loadAuthFile reads the auth file, returning an empty one if it does not exist.
+func loadAuthFile() (*authFile, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	file := &authFile{}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			file.Profiles = map[string]*AuthConfig{}
+			return file, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+	if file.Profiles == nil {
+		file.Profiles = map[string]*AuthConfig{}
+	}
+
+	// Migrate credentials from the single-account layout
+	if legacy := file.AuthConfig; legacy.method() != "none" {
+		if _, exists := file.Profiles[DefaultProfile]; !exists {
+			file.Profiles[DefaultProfile] = &legacy
+		}
+		file.AuthConfig = AuthConfig{}
+	}
+
+	return file, nil
+}
+
+// saveAuthFile writes the auth file, removing it once no profiles remain.
+func saveAuthFile(file *authFile) error {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return err
+	}
+
+	if len(file.Profiles) == 0 {
+		if err := os.Remove(configPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(configPath, data, 0600)
+}
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charlietran/linctl/pkg/api"
+)
+
+// setupAuthHome points the auth file at a temporary home directory and clears
+// every override that could leak in from the environment.
+func setupAuthHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("LINEAR_API_KEY", "")
+	t.Setenv("LINCTL_API_KEY", "")
+	t.Setenv("LINCTL_PROFILE", "")
+	SetProfile("")
+	t.Cleanup(func() { SetProfile("") })
+	return home
+}
+
+func TestLegacyAuthFileMigratesToDefaultProfile(t *testing.T) {
+	home := setupAuthHome(t)
+
+	legacy := []byte(`{"api_key": "lin_api_legacy"}`)
+	if err := os.WriteFile(filepath.Join(home, ".linctl-auth.json"), legacy, 0600); err != nil {
+		t.Fatalf("failed to write legacy auth file: %v", err)
+	}
+
+	header, err := GetAuthHeader()
+	if err != nil {
+		t.Fatalf("GetAuthHeader failed: %v", err)
+	}
+	if header != "lin_api_legacy" {
+		t.Errorf("Expected legacy key, got %q", header)
+	}
+
+	profiles, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles failed: %v", err)
+	}
+	if len(profiles) != 1 || profiles[0].Name != DefaultProfile || !profiles[0].Current || profiles[0].Method != "api-key" {
+		t.Errorf("Expected a single current default profile, got %+v", profiles)
+	}
+}
+
+func TestProfilesSwitchAndOverride(t *testing.T) {
+	setupAuthHome(t)
+
+	if err := saveLogin(AuthConfig{APIKey: "key-default"}); err != nil {
+		t.Fatalf("saveLogin failed: %v", err)
+	}
+
+	SetProfile("acme")
+	if err := saveLogin(AuthConfig{APIKey: "key-acme"}); err != nil {
+		t.Fatalf("saveLogin failed: %v", err)
+	}
+	SetProfile("")
+
+	// Logging in to a profile makes it current
+	if header, _ := GetAuthHeader(); header != "key-acme" {
+		t.Errorf("Expected acme key after login, got %q", header)
+	}
+
+	if err := SwitchProfile(DefaultProfile); err != nil {
+		t.Fatalf("SwitchProfile failed: %v", err)
+	}
+	if header, _ := GetAuthHeader(); header != "key-default" {
+		t.Errorf("Expected default key after switch, got %q", header)
+	}
+
+	// LINCTL_PROFILE overrides the stored current profile, and --profile overrides both
+	t.Setenv("LINCTL_PROFILE", "acme")
+	if header, _ := GetAuthHeader(); header != "key-acme" {
+		t.Errorf("Expected acme key from LINCTL_PROFILE, got %q", header)
+	}
+	SetProfile(DefaultProfile)
+	if header, _ := GetAuthHeader(); header != "key-default" {
+		t.Errorf("Expected default key from --profile, got %q", header)
+	}
+
+	if err := SwitchProfile("missing"); err == nil {
+		t.Error("Expected error switching to a missing profile")
+	}
+}
+
+func TestLogoutRemovesOnlyActiveProfile(t *testing.T) {
+	home := setupAuthHome(t)
+
+	if err := saveLogin(AuthConfig{APIKey: "key-default"}); err != nil {
+		t.Fatalf("saveLogin failed: %v", err)
+	}
+	SetProfile("acme")
+	if err := saveLogin(AuthConfig{APIKey: "key-acme"}); err != nil {
+		t.Fatalf("saveLogin failed: %v", err)
+	}
+
+	if err := Logout(); err != nil {
+		t.Fatalf("Logout failed: %v", err)
+	}
+	if _, err := GetAuthHeader(); err == nil {
+		t.Error("Expected acme profile to be logged out")
+	}
+
+	SetProfile("")
+	if header, _ := GetAuthHeader(); header != "key-default" {
+		t.Errorf("Expected default profile to remain, got %q", header)
+	}
+
+	// Removing the last profile removes the file
+	if err := Logout(); err != nil {
+		t.Fatalf("Logout failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".linctl-auth.json")); !os.IsNotExist(err) {
+		t.Errorf("Expected auth file to be removed, got %v", err)
+	}
+}
+
+func TestGetCurrentUserUsesGivenClient(t *testing.T) {
+	setupAuthHome(t)
+	t.Setenv("LINEAR_API_KEY", "lin_api_test")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "lin_api_test" {
+			t.Errorf("Expected the key as auth header, got %q", got)
+		}
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"id":"u1","name":"Ada","email":"ada@example.com"}}}`))
+	}))
+	defer server.Close()
+
+	user, err := GetCurrentUser(func(authHeader string) *api.Client {
+		return api.NewClientWithURL(server.URL, authHeader)
+	})
+	if err != nil {
+		t.Fatalf("GetCurrentUser failed: %v", err)
+	}
+	if user.Name != "Ada" {
+		t.Errorf("Expected Ada from the configured endpoint, got %+v", user)
+	}
+}
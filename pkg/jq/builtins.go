@@ -0,0 +1,1205 @@
+package jq
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type builtin func(args []node, in interface{}, vars *scope) ([]interface{}, error)
+
+func (c *callNode) key() string {
+	return fmt.Sprintf("%s/%d", c.name, len(c.args))
+}
+
+var builtins map[string]builtin
+
+func init() {
+	builtins = map[string]builtin{
+		"empty/0": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return nil, nil
+		},
+		"error/0": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return nil, &valueError{value: in}
+		},
+		"error/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return each(args[0], in, vars, func(msg interface{}) ([]interface{}, error) {
+				return nil, &valueError{value: msg}
+			})
+		},
+		"not/0":    simple(func(v interface{}) (interface{}, error) { return !truthy(v), nil }),
+		"length/0": simple(length),
+		"utf8bytelength/0": simple(func(v interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errorf("%s only strings have UTF-8 byte length", describe(v))
+			}
+			return float64(len(s)), nil
+		}),
+		"keys/0":          simple(keys),
+		"keys_unsorted/0": simple(keys),
+		"values/0":        filter(func(v interface{}) bool { return v != nil }),
+		"nulls/0":         filter(func(v interface{}) bool { return v == nil }),
+		"booleans/0":      filter(func(v interface{}) bool { return typeName(v) == "boolean" }),
+		"numbers/0":       filter(func(v interface{}) bool { return typeName(v) == "number" }),
+		"strings/0":       filter(func(v interface{}) bool { return typeName(v) == "string" }),
+		"arrays/0":        filter(func(v interface{}) bool { return typeName(v) == "array" }),
+		"objects/0":       filter(func(v interface{}) bool { return typeName(v) == "object" }),
+		"iterables/0": filter(func(v interface{}) bool {
+			t := typeName(v)
+			return t == "array" || t == "object"
+		}),
+		"scalars/0": filter(func(v interface{}) bool {
+			t := typeName(v)
+			return t != "array" && t != "object"
+		}),
+		"type/0":     simple(func(v interface{}) (interface{}, error) { return typeName(v), nil }),
+		"tostring/0": simple(func(v interface{}) (interface{}, error) { return tostring(v), nil }),
+		"tojson/0":   simple(func(v interface{}) (interface{}, error) { return encode(v), nil }),
+		"fromjson/0": simple(func(v interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errorf("%s cannot be parsed as JSON", describe(v))
+			}
+			var out interface{}
+			if err := jsonUnmarshal(s, &out); err != nil {
+				return nil, errorf("%s cannot be parsed as JSON: %v", describe(v), err)
+			}
+			return out, nil
+		}),
+		"tonumber/0": simple(func(v interface{}) (interface{}, error) {
+			switch t := v.(type) {
+			case float64:
+				return t, nil
+			case string:
+				f, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+				if err != nil {
+					return nil, errorf("cannot parse %q as a number", t)
+				}
+				return f, nil
+			}
+			return nil, errorf("%s cannot be parsed as a number", describe(v))
+		}),
+		"ascii_downcase/0": stringFunc(func(s string) interface{} { return asciiCase(s, false) }),
+		"ascii_upcase/0":   stringFunc(func(s string) interface{} { return asciiCase(s, true) }),
+		"floor/0":          mathFunc(math.Floor),
+		"ceil/0":           mathFunc(math.Ceil),
+		"round/0":          mathFunc(math.Round),
+		"sqrt/0":           mathFunc(math.Sqrt),
+		"abs/0":            mathFunc(math.Abs),
+		"add/0": simple(func(v interface{}) (interface{}, error) {
+			items, err := iterate(v)
+			if err != nil {
+				return nil, err
+			}
+			var acc interface{}
+			for _, item := range items {
+				if acc, err = arithmetic("+", acc, item); err != nil {
+					return nil, err
+				}
+			}
+			return acc, nil
+		}),
+		"any/0": simple(func(v interface{}) (interface{}, error) {
+			items, err := iterate(v)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				if truthy(item) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}),
+		"all/0": simple(func(v interface{}) (interface{}, error) {
+			items, err := iterate(v)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				if !truthy(item) {
+					return false, nil
+				}
+			}
+			return true, nil
+		}),
+		"any/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return quantify(args[0], in, vars, true)
+		},
+		"all/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return quantify(args[0], in, vars, false)
+		},
+		"flatten/0": simple(func(v interface{}) (interface{}, error) { return flatten(v, -1) }),
+		"flatten/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			depth, ok := args[0].(float64)
+			if !ok || depth < 0 {
+				return nil, errorf("flatten depth must not be negative")
+			}
+			return flatten(v, int(depth))
+		}),
+		"range/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return each(args[0], in, vars, func(to interface{}) ([]interface{}, error) {
+				return numberRange(0.0, to, 1.0)
+			})
+		},
+		"range/2": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return each(args[0], in, vars, func(from interface{}) ([]interface{}, error) {
+				return each(args[1], in, vars, func(to interface{}) ([]interface{}, error) {
+					return numberRange(from, to, 1.0)
+				})
+			})
+		},
+		"range/3": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return each(args[0], in, vars, func(from interface{}) ([]interface{}, error) {
+				return each(args[1], in, vars, func(to interface{}) ([]interface{}, error) {
+					return each(args[2], in, vars, func(by interface{}) ([]interface{}, error) {
+						return numberRange(from, to, by)
+					})
+				})
+			})
+		},
+		"sort/0": simple(func(v interface{}) (interface{}, error) {
+			return sortBy(v, func(item interface{}) (interface{}, error) { return item, nil })
+		}),
+		"sort_by/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			out, err := sortBy(in, keyFunc(args[0], vars))
+			return single(out, err)
+		},
+		"group_by/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			out, err := groupBy(in, keyFunc(args[0], vars))
+			return single(out, err)
+		},
+		"unique/0": simple(func(v interface{}) (interface{}, error) {
+			return uniqueBy(v, func(item interface{}) (interface{}, error) { return item, nil })
+		}),
+		"unique_by/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			out, err := uniqueBy(in, keyFunc(args[0], vars))
+			return single(out, err)
+		},
+		"min/0": simple(func(v interface{}) (interface{}, error) {
+			return extreme(v, func(item interface{}) (interface{}, error) { return item, nil }, -1)
+		}),
+		"max/0": simple(func(v interface{}) (interface{}, error) {
+			return extreme(v, func(item interface{}) (interface{}, error) { return item, nil }, 1)
+		}),
+		"min_by/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			out, err := extreme(in, keyFunc(args[0], vars), -1)
+			return single(out, err)
+		},
+		"max_by/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			out, err := extreme(in, keyFunc(args[0], vars), 1)
+			return single(out, err)
+		},
+		"reverse/0": simple(func(v interface{}) (interface{}, error) {
+			switch t := v.(type) {
+			case nil:
+				return []interface{}{}, nil
+			case string:
+				runes := []rune(t)
+				for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+					runes[i], runes[j] = runes[j], runes[i]
+				}
+				return string(runes), nil
+			case []interface{}:
+				out := make([]interface{}, len(t))
+				for i, item := range t {
+					out[len(t)-1-i] = item
+				}
+				return out, nil
+			}
+			return nil, errorf("cannot reverse %s", describe(v))
+		}),
+		"first/0": simple(func(v interface{}) (interface{}, error) { return index(v, 0.0) }),
+		"last/0":  simple(func(v interface{}) (interface{}, error) { return index(v, -1.0) }),
+		"nth/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			return index(v, args[0])
+		}),
+		"first/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			out, err := eval(args[0], in, vars)
+			if err != nil || len(out) == 0 {
+				return nil, err
+			}
+			return out[:1], nil
+		},
+		"last/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			out, err := eval(args[0], in, vars)
+			if err != nil || len(out) == 0 {
+				return nil, err
+			}
+			return out[len(out)-1:], nil
+		},
+		"limit/2": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return each(args[0], in, vars, func(n interface{}) ([]interface{}, error) {
+				count, ok := n.(float64)
+				if !ok {
+					return nil, errorf("limit count must be a number")
+				}
+				if count <= 0 {
+					return nil, nil
+				}
+				out, err := eval(args[1], in, vars)
+				if err != nil {
+					return nil, err
+				}
+				if len(out) > int(count) {
+					out = out[:int(count)]
+				}
+				return out, nil
+			})
+		},
+		"isempty/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			out, err := eval(args[0], in, vars)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{len(out) == 0}, nil
+		},
+		"map/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			items, err := iterate(in)
+			if err != nil {
+				return nil, err
+			}
+			out := []interface{}{}
+			for _, item := range items {
+				r, err := eval(args[0], item, vars)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, r...)
+			}
+			return []interface{}{out}, nil
+		},
+		"map_values/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			switch t := in.(type) {
+			case []interface{}:
+				out := []interface{}{}
+				for _, item := range t {
+					r, err := eval(args[0], item, vars)
+					if err != nil {
+						return nil, err
+					}
+					if len(r) > 0 {
+						out = append(out, r[0])
+					}
+				}
+				return []interface{}{out}, nil
+			case map[string]interface{}:
+				out := make(map[string]interface{}, len(t))
+				for k, item := range t {
+					r, err := eval(args[0], item, vars)
+					if err != nil {
+						return nil, err
+					}
+					if len(r) > 0 {
+						out[k] = r[0]
+					}
+				}
+				return []interface{}{out}, nil
+			}
+			return nil, errorf("cannot iterate over %s", describe(in))
+		},
+		"select/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return each(args[0], in, vars, func(c interface{}) ([]interface{}, error) {
+				if truthy(c) {
+					return []interface{}{in}, nil
+				}
+				return nil, nil
+			})
+		},
+		"recurse/0": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			var out []interface{}
+			recurse(in, &out)
+			return out, nil
+		},
+		"recurse/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			var out []interface{}
+			var walk func(v interface{}) error
+			walk = func(v interface{}) error {
+				out = append(out, v)
+				children, err := eval(args[0], v, vars)
+				if err != nil {
+					return err
+				}
+				for _, child := range children {
+					if err := walk(child); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			return out, walk(in)
+		},
+		"has/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			switch t := v.(type) {
+			case map[string]interface{}:
+				if k, ok := args[0].(string); ok {
+					_, found := t[k]
+					return found, nil
+				}
+			case []interface{}:
+				if k, ok := args[0].(float64); ok {
+					return k >= 0 && int(k) < len(t), nil
+				}
+			}
+			return nil, errorf("cannot check whether %s has a %s key", typeName(v), typeName(args[0]))
+		}),
+		"contains/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			if typeName(v) != typeName(args[0]) {
+				return nil, errorf("%s and %s cannot have their containment checked", describe(v), describe(args[0]))
+			}
+			return contains(v, args[0]), nil
+		}),
+		"inside/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			if typeName(v) != typeName(args[0]) {
+				return nil, errorf("%s and %s cannot have their containment checked", describe(args[0]), describe(v))
+			}
+			return contains(args[0], v), nil
+		}),
+		"path/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return pathsOf(args[0], in, vars)
+		},
+		"paths/0": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return filterPaths(in, func(interface{}) (bool, error) { return true, nil })
+		},
+		"paths/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return filterPaths(in, func(v interface{}) (bool, error) {
+				out, err := eval(args[0], v, vars)
+				for _, keep := range out {
+					if truthy(keep) {
+						return true, err
+					}
+				}
+				return false, err
+			})
+		},
+		"leaf_paths/0": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			// Like jq, this is paths(scalars), which skips null and false
+			return filterPaths(in, func(v interface{}) (bool, error) {
+				t := typeName(v)
+				return t != "array" && t != "object" && truthy(v), nil
+			})
+		},
+		"getpath/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			path, err := toPath(args[0])
+			if err != nil {
+				return nil, err
+			}
+			return getPath(v, path)
+		}),
+		"setpath/2": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			path, err := toPath(args[0])
+			if err != nil {
+				return nil, err
+			}
+			return setPath(v, path, args[1])
+		}),
+		"delpaths/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			paths, ok := args[0].([]interface{})
+			if !ok {
+				return nil, errorf("delpaths needs an array of paths, got %s", describe(args[0]))
+			}
+			return deletePaths(v, paths)
+		}),
+		"del/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			paths, err := pathsOf(args[0], in, vars)
+			if err != nil {
+				return nil, err
+			}
+			return single(deletePaths(in, paths))
+		},
+		"to_entries/0":   simple(toEntries),
+		"from_entries/0": simple(fromEntries),
+		"with_entries/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			entries, err := toEntries(in)
+			if err != nil {
+				return nil, err
+			}
+			var mapped []interface{}
+			for _, entry := range entries.([]interface{}) {
+				r, err := eval(args[0], entry, vars)
+				if err != nil {
+					return nil, err
+				}
+				mapped = append(mapped, r...)
+			}
+			out, err := fromEntries(mapped)
+			return single(out, err)
+		},
+		"join/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			sep, ok := args[0].(string)
+			if !ok {
+				return nil, errorf("join separator must be a string")
+			}
+			items, err := iterate(v)
+			if err != nil {
+				return nil, err
+			}
+			parts := make([]string, len(items))
+			for i, item := range items {
+				switch t := item.(type) {
+				case nil:
+				case string:
+					parts[i] = t
+				case float64, bool:
+					parts[i] = encode(t)
+				default:
+					return nil, errorf("cannot join with %s", describe(item))
+				}
+			}
+			return strings.Join(parts, sep), nil
+		}),
+		"split/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			sep, sepOK := args[0].(string)
+			if !ok || !sepOK {
+				return nil, errorf("split input and separator must be strings")
+			}
+			return splitString(s, sep), nil
+		}),
+		"splits/1": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return each(args[0], in, vars, func(re interface{}) ([]interface{}, error) {
+				return regexSplit(in, re, nil)
+			})
+		},
+		"splits/2": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return each(args[0], in, vars, func(re interface{}) ([]interface{}, error) {
+				return each(args[1], in, vars, func(flags interface{}) ([]interface{}, error) {
+					return regexSplit(in, re, flags)
+				})
+			})
+		},
+		"indices/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			return indices(v, args[0])
+		}),
+		"index/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			found, err := indices(v, args[0])
+			if err != nil || found == nil || len(found.([]interface{})) == 0 {
+				return nil, err
+			}
+			return found.([]interface{})[0], nil
+		}),
+		"rindex/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			found, err := indices(v, args[0])
+			if err != nil || found == nil || len(found.([]interface{})) == 0 {
+				return nil, err
+			}
+			all := found.([]interface{})
+			return all[len(all)-1], nil
+		}),
+		"ltrimstr/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			prefix, prefixOK := args[0].(string)
+			if ok && prefixOK {
+				return strings.TrimPrefix(s, prefix), nil
+			}
+			return v, nil
+		}),
+		"rtrimstr/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			suffix, suffixOK := args[0].(string)
+			if ok && suffixOK {
+				return strings.TrimSuffix(s, suffix), nil
+			}
+			return v, nil
+		}),
+		"startswith/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			prefix, prefixOK := args[0].(string)
+			if !ok || !prefixOK {
+				return nil, errorf("startswith() requires string inputs")
+			}
+			return strings.HasPrefix(s, prefix), nil
+		}),
+		"endswith/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			s, ok := v.(string)
+			suffix, suffixOK := args[0].(string)
+			if !ok || !suffixOK {
+				return nil, errorf("endswith() requires string inputs")
+			}
+			return strings.HasSuffix(s, suffix), nil
+		}),
+		"test/1": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			return regexTest(v, args[0], nil)
+		}),
+		"test/2": withArgs(func(v interface{}, args []interface{}) (interface{}, error) {
+			return regexTest(v, args[0], args[1])
+		}),
+		"sub/2": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return substitute(args, in, vars, false)
+		},
+		"sub/3": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return substitute(args, in, vars, false)
+		},
+		"gsub/2": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return substitute(args, in, vars, true)
+		},
+		"gsub/3": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return substitute(args, in, vars, true)
+		},
+		"env/0": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return []interface{}{environ()}, nil
+		},
+		"now/0": func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+			return []interface{}{float64(time.Now().UnixNano()) / 1e9}, nil
+		},
+		"fromdateiso8601/0": simple(fromDate),
+		"fromdate/0":        simple(fromDate),
+		"todateiso8601/0":   simple(toDate),
+		"todate/0":          simple(toDate),
+	}
+}
+
+// simple adapts a function of the input alone.
+func simple(fn func(interface{}) (interface{}, error)) builtin {
+	return func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+		return single(fn(in))
+	}
+}
+
+// withArgs adapts a function of the input and argument values, calling it
+// for every combination of argument outputs.
+func withArgs(fn func(interface{}, []interface{}) (interface{}, error)) builtin {
+	return func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+		combos := [][]interface{}{{}}
+		for _, arg := range args {
+			values, err := eval(arg, in, vars)
+			if err != nil {
+				return nil, err
+			}
+			var next [][]interface{}
+			for _, combo := range combos {
+				for _, v := range values {
+					next = append(next, append(append([]interface{}{}, combo...), v))
+				}
+			}
+			combos = next
+		}
+		var out []interface{}
+		for _, combo := range combos {
+			r, err := fn(in, combo)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, r)
+		}
+		return out, nil
+	}
+}
+
+func filter(keep func(interface{}) bool) builtin {
+	return func(args []node, in interface{}, vars *scope) ([]interface{}, error) {
+		if keep(in) {
+			return []interface{}{in}, nil
+		}
+		return nil, nil
+	}
+}
+
+func stringFunc(fn func(string) interface{}) builtin {
+	return simple(func(v interface{}) (interface{}, error) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, errorf("%s is not a string", describe(v))
+		}
+		return fn(s), nil
+	})
+}
+
+func mathFunc(fn func(float64) float64) builtin {
+	return simple(func(v interface{}) (interface{}, error) {
+		f, ok := v.(float64)
+		if !ok {
+			return nil, errorf("%s is not a number", describe(v))
+		}
+		return fn(f), nil
+	})
+}
+
+func single(v interface{}, err error) ([]interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{v}, nil
+}
+
+// keyFunc evaluates f against an item and collects its outputs into the sort key.
+func keyFunc(f node, vars *scope) func(interface{}) (interface{}, error) {
+	return func(item interface{}) (interface{}, error) {
+		out, err := eval(f, item, vars)
+		if err != nil {
+			return nil, err
+		}
+		if out == nil {
+			out = []interface{}{}
+		}
+		return out, nil
+	}
+}
+
+type keyed struct {
+	key  interface{}
+	item interface{}
+}
+
+func keyItems(v interface{}, key func(interface{}) (interface{}, error)) ([]keyed, error) {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, errorf("%s cannot be sorted, as it is not an array", describe(v))
+	}
+	out := make([]keyed, len(items))
+	for i, item := range items {
+		k, err := key(item)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = keyed{key: k, item: item}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return compare(out[i].key, out[j].key) < 0 })
+	return out, nil
+}
+
+func sortBy(v interface{}, key func(interface{}) (interface{}, error)) (interface{}, error) {
+	items, err := keyItems(v, key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]interface{}, len(items))
+	for i, k := range items {
+		out[i] = k.item
+	}
+	return out, nil
+}
+
+func groupBy(v interface{}, key func(interface{}) (interface{}, error)) (interface{}, error) {
+	items, err := keyItems(v, key)
+	if err != nil {
+		return nil, err
+	}
+	groups := []interface{}{}
+	for i, k := range items {
+		if i == 0 || compare(items[i-1].key, k.key) != 0 {
+			groups = append(groups, []interface{}{})
+		}
+		last := len(groups) - 1
+		groups[last] = append(groups[last].([]interface{}), k.item)
+	}
+	return groups, nil
+}
+
+func uniqueBy(v interface{}, key func(interface{}) (interface{}, error)) (interface{}, error) {
+	items, err := keyItems(v, key)
+	if err != nil {
+		return nil, err
+	}
+	out := []interface{}{}
+	for i, k := range items {
+		if i == 0 || compare(items[i-1].key, k.key) != 0 {
+			out = append(out, k.item)
+		}
+	}
+	return out, nil
+}
+
+func extreme(v interface{}, key func(interface{}) (interface{}, error), sign int) (interface{}, error) {
+	items, err := keyItems(v, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	if sign < 0 {
+		return items[0].item, nil
+	}
+	return items[len(items)-1].item, nil
+}
+
+func quantify(cond node, in interface{}, vars *scope, any bool) ([]interface{}, error) {
+	items, err := iterate(in)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		out, err := eval(cond, item, vars)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range out {
+			if truthy(c) == any {
+				return []interface{}{any}, nil
+			}
+		}
+	}
+	return []interface{}{!any}, nil
+}
+
+func length(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case nil:
+		return 0.0, nil
+	case float64:
+		return math.Abs(t), nil
+	case string:
+		return float64(utf8.RuneCountInString(t)), nil
+	case []interface{}:
+		return float64(len(t)), nil
+	case map[string]interface{}:
+		return float64(len(t)), nil
+	}
+	return nil, errorf("%s has no length", describe(v))
+}
+
+func keys(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := []interface{}{}
+		for _, k := range sortedKeys(t) {
+			out = append(out, k)
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i := range t {
+			out[i] = float64(i)
+		}
+		return out, nil
+	}
+	return nil, errorf("%s has no keys", describe(v))
+}
+
+func flatten(v interface{}, depth int) (interface{}, error) {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, errorf("cannot flatten %s", describe(v))
+	}
+	out := []interface{}{}
+	for _, item := range items {
+		if inner, ok := item.([]interface{}); ok && depth != 0 {
+			flat, _ := flatten(inner, depth-1)
+			out = append(out, flat.([]interface{})...)
+		} else {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+func numberRange(from, to, by interface{}) ([]interface{}, error) {
+	f, fok := from.(float64)
+	t, tok := to.(float64)
+	b, bok := by.(float64)
+	if !fok || !tok || !bok {
+		return nil, errorf("range bounds must be numbers")
+	}
+	var out []interface{}
+	switch {
+	case b > 0:
+		for x := f; x < t; x += b {
+			out = append(out, x)
+		}
+	case b < 0:
+		for x := f; x > t; x += b {
+			out = append(out, x)
+		}
+	}
+	return out, nil
+}
+
+func contains(a, b interface{}) bool {
+	switch av := a.(type) {
+	case string:
+		return strings.Contains(av, b.(string))
+	case []interface{}:
+		for _, bi := range b.([]interface{}) {
+			found := false
+			for _, ai := range av {
+				if typeName(ai) == typeName(bi) && contains(ai, bi) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		for k, bi := range b.(map[string]interface{}) {
+			ai, ok := av[k]
+			if !ok || typeName(ai) != typeName(bi) || !contains(ai, bi) {
+				return false
+			}
+		}
+		return true
+	}
+	return compare(a, b) == 0
+}
+
+func toEntries(v interface{}) (interface{}, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errorf("%s has no keys", describe(v))
+	}
+	out := []interface{}{}
+	for _, k := range sortedKeys(m) {
+		out = append(out, map[string]interface{}{"key": k, "value": m[k]})
+	}
+	return out, nil
+}
+
+func fromEntries(v interface{}) (interface{}, error) {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, errorf("cannot use %s as object entries", describe(v))
+	}
+	out := make(map[string]interface{}, len(items))
+	for _, item := range items {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errorf("cannot use %s as an object entry", describe(item))
+		}
+		var key interface{}
+		for _, name := range []string{"key", "k", "name", "Name", "Key", "K"} {
+			if k, ok := entry[name]; ok && k != nil && k != false {
+				key = k
+				break
+			}
+		}
+		var value interface{}
+		for _, name := range []string{"value", "v", "Value", "V"} {
+			if v, ok := entry[name]; ok {
+				value = v
+				break
+			}
+		}
+		switch k := key.(type) {
+		case string:
+			out[k] = value
+		case float64, bool:
+			out[encode(k)] = value
+		default:
+			return nil, errorf("cannot use %s as an object key", describe(key))
+		}
+	}
+	return out, nil
+}
+
+// filterPaths returns the paths below v whose value satisfies keep.
+func filterPaths(v interface{}, keep func(interface{}) (bool, error)) ([]interface{}, error) {
+	var out []interface{}
+	for _, p := range recursePaths(pathValue{path: []interface{}{}, value: v})[1:] {
+		ok, err := keep(p.value)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, p.path)
+		}
+	}
+	return out, nil
+}
+
+// indices returns the positions of x in v: character offsets of a
+// substring, or the indexes where an element or a run of elements starts.
+func indices(v, x interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		sub, ok := x.(string)
+		if !ok {
+			return nil, errorf("cannot find %s in a string", describe(x))
+		}
+		out := []interface{}{}
+		if sub == "" {
+			return out, nil
+		}
+		runes, subRunes := []rune(t), []rune(sub)
+		for i := 0; i+len(subRunes) <= len(runes); i++ {
+			if string(runes[i:i+len(subRunes)]) == sub {
+				out = append(out, float64(i))
+			}
+		}
+		return out, nil
+	case []interface{}:
+		run, ok := x.([]interface{})
+		if !ok {
+			run = []interface{}{x}
+		}
+		out := []interface{}{}
+		if len(run) == 0 {
+			return out, nil
+		}
+		for i := 0; i+len(run) <= len(t); i++ {
+			match := true
+			for j := range run {
+				if compare(t[i+j], run[j]) != 0 {
+					match = false
+					break
+				}
+			}
+			if match {
+				out = append(out, float64(i))
+			}
+		}
+		return out, nil
+	}
+	return nil, errorf("cannot search in %s", describe(v))
+}
+
+func regexSplit(v, re, flags interface{}) ([]interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, errorf("%s cannot be split, as it is not a string", describe(v))
+	}
+	compiled, _, err := compileRegex(re, flags)
+	if err != nil {
+		return nil, err
+	}
+	parts := compiled.Split(s, -1)
+	out := make([]interface{}, len(parts))
+	for i, part := range parts {
+		out[i] = part
+	}
+	return out, nil
+}
+
+func splitString(s, sep string) []interface{} {
+	out := []interface{}{}
+	if s == "" {
+		return out
+	}
+	for _, part := range strings.Split(s, sep) {
+		out = append(out, part)
+	}
+	return out
+}
+
+func asciiCase(s string, upper bool) string {
+	b := []byte(s)
+	for i, c := range b {
+		if upper && c >= 'a' && c <= 'z' {
+			b[i] = c - 32
+		} else if !upper && c >= 'A' && c <= 'Z' {
+			b[i] = c + 32
+		}
+	}
+	return string(b)
+}
+
+// compileRegex compiles a jq regular expression with optional flags:
+// i (case-insensitive), x (extended), s (single line), g and n are accepted.
+func compileRegex(re, flags interface{}) (*regexp.Regexp, bool, error) {
+	pattern, ok := re.(string)
+	if !ok {
+		return nil, false, errorf("%s cannot be matched, as it is not a string", describe(re))
+	}
+	global := false
+	prefix := ""
+	if flags != nil {
+		f, ok := flags.(string)
+		if !ok {
+			return nil, false, errorf("%s is not a string", describe(flags))
+		}
+		for _, c := range f {
+			switch c {
+			case 'g':
+				global = true
+			case 'i', 's':
+				prefix += string(c)
+			case 'x':
+				pattern = regexp.MustCompile(`\s+|#.*`).ReplaceAllString(pattern, "")
+			case 'n':
+			default:
+				return nil, false, errorf("%s is not a valid modifier string", f)
+			}
+		}
+	}
+	if prefix != "" {
+		pattern = "(?" + prefix + ")" + pattern
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, false, errorf("%s (at offset 0) is not a valid regex: %v", pattern, err)
+	}
+	return compiled, global, nil
+}
+
+func regexTest(v, re, flags interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, errorf("%s cannot be matched, as it is not a string", describe(v))
+	}
+	compiled, _, err := compileRegex(re, flags)
+	if err != nil {
+		return nil, err
+	}
+	return compiled.MatchString(s), nil
+}
+
+// substitute implements sub and gsub. The replacement is evaluated with the
+// named captures of each match as its input, as in jq.
+func substitute(args []node, in interface{}, vars *scope, global bool) ([]interface{}, error) {
+	s, ok := in.(string)
+	if !ok {
+		return nil, errorf("%s cannot be matched, as it is not a string", describe(in))
+	}
+	re, err := evalOne(args[0], in, vars)
+	if err != nil {
+		return nil, err
+	}
+	var flags interface{}
+	if len(args) == 3 {
+		if flags, err = evalOne(args[2], in, vars); err != nil {
+			return nil, err
+		}
+	}
+	compiled, g, err := compileRegex(re, flags)
+	if err != nil {
+		return nil, err
+	}
+	global = global || g
+
+	var b strings.Builder
+	last := 0
+	for _, m := range compiled.FindAllStringSubmatchIndex(s, -1) {
+		captures := map[string]interface{}{}
+		for i, name := range compiled.SubexpNames() {
+			if name == "" {
+				continue
+			}
+			if m[2*i] >= 0 {
+				captures[name] = s[m[2*i]:m[2*i+1]]
+			} else {
+				captures[name] = nil
+			}
+		}
+		repl, err := evalOne(args[1], captures, vars)
+		if err != nil {
+			return nil, err
+		}
+		rs, ok := repl.(string)
+		if !ok {
+			return nil, errorf("%s cannot be added to a string", describe(repl))
+		}
+		b.WriteString(s[last:m[0]])
+		b.WriteString(rs)
+		last = m[1]
+		if !global {
+			break
+		}
+	}
+	b.WriteString(s[last:])
+	return []interface{}{b.String()}, nil
+}
+
+func fromDate(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, errorf("%s cannot be parsed as a date", describe(v))
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, errorf("date %q does not match format %q", s, "%Y-%m-%dT%H:%M:%SZ")
+	}
+	return float64(t.Unix()), nil
+}
+
+func toDate(v interface{}) (interface{}, error) {
+	f, ok := v.(float64)
+	if !ok {
+		return nil, errorf("%s cannot be formatted as a date", describe(v))
+	}
+	return time.Unix(int64(f), 0).UTC().Format("2006-01-02T15:04:05Z"), nil
+}
+
+// formats are the names accepted after @.
+var formats = map[string]bool{
+	"text": true, "json": true, "csv": true, "tsv": true, "html": true,
+	"uri": true, "sh": true, "base64": true, "base64d": true,
+}
+
+// applyFormat implements @text, @json, @csv, @tsv, @html, @uri, @sh, @base64 and @base64d.
+func applyFormat(name string, v interface{}) (string, error) {
+	switch name {
+	case "text":
+		return tostring(v), nil
+	case "json":
+		return encode(v), nil
+	case "csv", "tsv":
+		items, ok := v.([]interface{})
+		if !ok {
+			return "", errorf("%s cannot be %s-formatted, only an array can be", describe(v), name)
+		}
+		fields := make([]string, len(items))
+		for i, item := range items {
+			switch t := item.(type) {
+			case nil:
+			case string:
+				if name == "csv" {
+					fields[i] = `"` + strings.ReplaceAll(t, `"`, `""`) + `"`
+				} else {
+					fields[i] = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`).Replace(t)
+				}
+			case float64, bool:
+				fields[i] = encode(t)
+			default:
+				return "", errorf("%s is not valid in a %s row", describe(item), name)
+			}
+		}
+		if name == "csv" {
+			return strings.Join(fields, ","), nil
+		}
+		return strings.Join(fields, "\t"), nil
+	case "html":
+		return strings.NewReplacer("<", "&lt;", ">", "&gt;", "&", "&amp;", "'", "&#39;", `"`, "&quot;").Replace(tostring(v)), nil
+	case "uri":
+		var b strings.Builder
+		for _, c := range []byte(tostring(v)) {
+			if isIdentChar(c) || c == '-' || c == '.' || c == '~' {
+				b.WriteByte(c)
+			} else {
+				fmt.Fprintf(&b, "%%%02X", c)
+			}
+		}
+		return b.String(), nil
+	case "sh":
+		quote := func(item interface{}) (string, error) {
+			switch t := item.(type) {
+			case string:
+				return "'" + strings.ReplaceAll(t, "'", `'\''`) + "'", nil
+			case []interface{}, map[string]interface{}:
+				return "", errorf("%s can not be escaped for shell", describe(item))
+			}
+			return encode(item), nil
+		}
+		if items, ok := v.([]interface{}); ok {
+			parts := make([]string, len(items))
+			for i, item := range items {
+				q, err := quote(item)
+				if err != nil {
+					return "", err
+				}
+				parts[i] = q
+			}
+			return strings.Join(parts, " "), nil
+		}
+		return quote(v)
+	case "base64":
+		return base64.StdEncoding.EncodeToString([]byte(tostring(v))), nil
+	case "base64d":
+		decoded, err := base64.StdEncoding.DecodeString(tostring(v))
+		if err != nil {
+			return "", errorf("%s is not valid base64 data", describe(v))
+		}
+		return string(decoded), nil
+	}
+	return "", errorf("%s is not a valid format", name)
+}
@@ -0,0 +1,798 @@
+// Package jq evaluates a subset of the jq language over decoded JSON values,
+// so command output can be filtered without an external jq binary.
+//
+// Supported: paths (.a.b, .[0], .[1:3], .[], .., ?), pipes, commas, literals,
+// array and object construction, string interpolation, arithmetic,
+// comparisons, and/or, //, if/elif/else, try/catch, reduce, foreach,
+// "as $x" bindings, the assignment operators (=, |=, +=, ...), @formats and
+// the common builtins (map, select, sort_by, group_by, to_entries, test,
+// del, paths, ...). Destructuring, "def", label/break, input/inputs and
+// modules are not.
+package jq
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Query is a parsed jq expression.
+type Query struct {
+	root node
+}
+
+// Parse compiles a jq expression.
+func Parse(src string) (*Query, error) {
+	root, err := parse(src, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &Query{root: root}, nil
+}
+
+// Run evaluates the expression against input, which must be made of decoded
+// JSON values (nil, bool, float64, string, []interface{} and
+// map[string]interface{}), and returns every result.
+func (q *Query) Run(input interface{}) ([]interface{}, error) {
+	return eval(q.root, input, nil)
+}
+
+// scope holds the variables bound with "as $name".
+type scope struct {
+	name   string
+	value  interface{}
+	parent *scope
+}
+
+func (s *scope) lookup(name string) (interface{}, bool) {
+	for ; s != nil; s = s.parent {
+		if s.name == name {
+			return s.value, true
+		}
+	}
+	return nil, false
+}
+
+func (s *scope) bind(name string, value interface{}) *scope {
+	return &scope{name: name, value: value, parent: s}
+}
+
+// valueError is raised by error/1 and caught by try/catch with its value intact.
+type valueError struct {
+	value interface{}
+}
+
+func (e *valueError) Error() string {
+	if s, ok := e.value.(string); ok {
+		return s
+	}
+	return encode(e.value) + " (not a string)"
+}
+
+func errorf(format string, args ...interface{}) error {
+	return &valueError{value: fmt.Sprintf(format, args...)}
+}
+
+func eval(n node, in interface{}, vars *scope) ([]interface{}, error) {
+	switch n := n.(type) {
+	case *identityNode:
+		return []interface{}{in}, nil
+
+	case *recurseNode:
+		var out []interface{}
+		recurse(in, &out)
+		return out, nil
+
+	case *literalNode:
+		return []interface{}{n.value}, nil
+
+	case *varNode:
+		if n.name == "ENV" {
+			return []interface{}{environ()}, nil
+		}
+		v, ok := vars.lookup(n.name)
+		if !ok {
+			return nil, errorf("$%s is not defined", n.name)
+		}
+		return []interface{}{v}, nil
+
+	case *formatNode:
+		s, err := applyFormat(n.name, in)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{s}, nil
+
+	case *fieldNode:
+		return each(n.target, in, vars, func(v interface{}) ([]interface{}, error) {
+			r, err := index(v, n.name)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{r}, nil
+		})
+
+	case *indexNode:
+		return each(n.target, in, vars, func(v interface{}) ([]interface{}, error) {
+			keys, err := eval(n.index, in, vars)
+			if err != nil {
+				return nil, err
+			}
+			var out []interface{}
+			for _, k := range keys {
+				r, err := index(v, k)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, r)
+			}
+			return out, nil
+		})
+
+	case *sliceNode:
+		return each(n.target, in, vars, func(v interface{}) ([]interface{}, error) {
+			from, to := interface{}(nil), interface{}(nil)
+			if n.from != nil {
+				r, err := evalOne(n.from, in, vars)
+				if err != nil {
+					return nil, err
+				}
+				from = r
+			}
+			if n.to != nil {
+				r, err := evalOne(n.to, in, vars)
+				if err != nil {
+					return nil, err
+				}
+				to = r
+			}
+			r, err := slice(v, from, to)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{r}, nil
+		})
+
+	case *iterNode:
+		return each(n.target, in, vars, iterate)
+
+	case *stringNode:
+		results := []string{""}
+		for _, seg := range n.segments {
+			if seg.expr == nil {
+				for i := range results {
+					results[i] += seg.literal
+				}
+				continue
+			}
+			values, err := eval(seg.expr, in, vars)
+			if err != nil {
+				return nil, err
+			}
+			var next []string
+			for _, prefix := range results {
+				for _, v := range values {
+					var s string
+					if n.format != "" {
+						s, err = applyFormat(n.format, v)
+						if err != nil {
+							return nil, err
+						}
+					} else {
+						s = tostring(v)
+					}
+					next = append(next, prefix+s)
+				}
+			}
+			results = next
+		}
+		out := make([]interface{}, len(results))
+		for i, s := range results {
+			out[i] = s
+		}
+		return out, nil
+
+	case *arrayNode:
+		if n.body == nil {
+			return []interface{}{[]interface{}{}}, nil
+		}
+		items, err := eval(n.body, in, vars)
+		if err != nil {
+			return nil, err
+		}
+		if items == nil {
+			items = []interface{}{}
+		}
+		return []interface{}{items}, nil
+
+	case *objectNode:
+		return evalObject(n, in, vars)
+
+	case *pipeNode:
+		return each(n.left, in, vars, func(v interface{}) ([]interface{}, error) {
+			return eval(n.right, v, vars)
+		})
+
+	case *commaNode:
+		left, err := eval(n.left, in, vars)
+		if err != nil {
+			return nil, err
+		}
+		right, err := eval(n.right, in, vars)
+		if err != nil {
+			return nil, err
+		}
+		return append(left, right...), nil
+
+	case *bindNode:
+		values, err := eval(n.source, in, vars)
+		if err != nil {
+			return nil, err
+		}
+		var out []interface{}
+		for _, v := range values {
+			r, err := eval(n.body, in, vars.bind(n.name, v))
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, r...)
+		}
+		return out, nil
+
+	case *binaryNode:
+		return evalBinary(n, in, vars)
+
+	case *negNode:
+		return each(n.operand, in, vars, func(v interface{}) ([]interface{}, error) {
+			f, ok := v.(float64)
+			if !ok {
+				return nil, errorf("%s cannot be negated", describe(v))
+			}
+			return []interface{}{-f}, nil
+		})
+
+	case *ifNode:
+		return each(n.cond, in, vars, func(c interface{}) ([]interface{}, error) {
+			if truthy(c) {
+				return eval(n.then, in, vars)
+			}
+			if n.els == nil {
+				return []interface{}{in}, nil
+			}
+			return eval(n.els, in, vars)
+		})
+
+	case *tryNode:
+		out, err := eval(n.body, in, vars)
+		if err == nil {
+			return out, nil
+		}
+		if n.catch == nil {
+			return out, nil
+		}
+		var msg interface{} = err.Error()
+		if ve, ok := err.(*valueError); ok {
+			msg = ve.value
+		}
+		return eval(n.catch, msg, vars)
+
+	case *reduceNode:
+		return each(n.init, in, vars, func(acc interface{}) ([]interface{}, error) {
+			items, err := eval(n.source, in, vars)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				r, err := eval(n.update, acc, vars.bind(n.name, item))
+				if err != nil {
+					return nil, err
+				}
+				if len(r) == 0 {
+					acc = nil
+				} else {
+					acc = r[len(r)-1]
+				}
+			}
+			return []interface{}{acc}, nil
+		})
+
+	case *foreachNode:
+		return each(n.init, in, vars, func(state interface{}) ([]interface{}, error) {
+			items, err := eval(n.source, in, vars)
+			if err != nil {
+				return nil, err
+			}
+			var out []interface{}
+			for _, item := range items {
+				inner := vars.bind(n.name, item)
+				states, err := eval(n.update, state, inner)
+				if err != nil {
+					return nil, err
+				}
+				for _, s := range states {
+					state = s
+					if n.extract == nil {
+						out = append(out, s)
+						continue
+					}
+					r, err := eval(n.extract, s, inner)
+					if err != nil {
+						return nil, err
+					}
+					out = append(out, r...)
+				}
+			}
+			return out, nil
+		})
+
+	case *assignNode:
+		return evalAssign(n, in, vars)
+
+	case *callNode:
+		return builtins[n.key()](n.args, in, vars)
+	}
+	return nil, fmt.Errorf("jq: unhandled expression %T", n)
+}
+
+// each evaluates target and calls fn with every result, concatenating the outputs.
+func each(target node, in interface{}, vars *scope, fn func(interface{}) ([]interface{}, error)) ([]interface{}, error) {
+	values, err := eval(target, in, vars)
+	if err != nil {
+		return nil, err
+	}
+	var out []interface{}
+	for _, v := range values {
+		r, err := fn(v)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r...)
+	}
+	return out, nil
+}
+
+// evalOne evaluates an expression that must produce exactly one value.
+func evalOne(n node, in interface{}, vars *scope) (interface{}, error) {
+	values, err := eval(n, in, vars)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != 1 {
+		return nil, errorf("expected a single value, got %d", len(values))
+	}
+	return values[0], nil
+}
+
+func evalObject(n *objectNode, in interface{}, vars *scope) ([]interface{}, error) {
+	results := []map[string]interface{}{{}}
+	for _, entry := range n.entries {
+		keys, err := eval(entry.key, in, vars)
+		if err != nil {
+			return nil, err
+		}
+		values, err := eval(entry.value, in, vars)
+		if err != nil {
+			return nil, err
+		}
+		var next []map[string]interface{}
+		for _, base := range results {
+			for _, k := range keys {
+				key, ok := k.(string)
+				if !ok {
+					return nil, errorf("object keys must be strings, not %s", describe(k))
+				}
+				for _, v := range values {
+					obj := make(map[string]interface{}, len(base)+1)
+					for bk, bv := range base {
+						obj[bk] = bv
+					}
+					obj[key] = v
+					next = append(next, obj)
+				}
+			}
+		}
+		results = next
+	}
+	out := make([]interface{}, len(results))
+	for i, obj := range results {
+		out[i] = obj
+	}
+	return out, nil
+}
+
+func evalBinary(n *binaryNode, in interface{}, vars *scope) ([]interface{}, error) {
+	switch n.op {
+	case "and", "or":
+		return each(n.left, in, vars, func(l interface{}) ([]interface{}, error) {
+			if n.op == "and" && !truthy(l) {
+				return []interface{}{false}, nil
+			}
+			if n.op == "or" && truthy(l) {
+				return []interface{}{true}, nil
+			}
+			return each(n.right, in, vars, func(r interface{}) ([]interface{}, error) {
+				return []interface{}{truthy(r)}, nil
+			})
+		})
+	case "//":
+		left, err := eval(n.left, in, vars)
+		var out []interface{}
+		if err == nil {
+			for _, v := range left {
+				if truthy(v) {
+					out = append(out, v)
+				}
+			}
+		}
+		if len(out) > 0 {
+			return out, nil
+		}
+		return eval(n.right, in, vars)
+	}
+
+	// Like jq, the right operand is the outer loop.
+	return each(n.right, in, vars, func(r interface{}) ([]interface{}, error) {
+		return each(n.left, in, vars, func(l interface{}) ([]interface{}, error) {
+			v, err := arithmetic(n.op, l, r)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{v}, nil
+		})
+	})
+}
+
+func arithmetic(op string, l, r interface{}) (interface{}, error) {
+	switch op {
+	case "==":
+		return compare(l, r) == 0, nil
+	case "!=":
+		return compare(l, r) != 0, nil
+	case "<":
+		return compare(l, r) < 0, nil
+	case "<=":
+		return compare(l, r) <= 0, nil
+	case ">":
+		return compare(l, r) > 0, nil
+	case ">=":
+		return compare(l, r) >= 0, nil
+	}
+
+	lf, lnum := l.(float64)
+	rf, rnum := r.(float64)
+	switch op {
+	case "+":
+		switch {
+		case l == nil:
+			return r, nil
+		case r == nil:
+			return l, nil
+		case lnum && rnum:
+			return lf + rf, nil
+		}
+		switch lv := l.(type) {
+		case string:
+			if rv, ok := r.(string); ok {
+				return lv + rv, nil
+			}
+		case []interface{}:
+			if rv, ok := r.([]interface{}); ok {
+				out := make([]interface{}, 0, len(lv)+len(rv))
+				return append(append(out, lv...), rv...), nil
+			}
+		case map[string]interface{}:
+			if rv, ok := r.(map[string]interface{}); ok {
+				out := make(map[string]interface{}, len(lv)+len(rv))
+				for k, v := range lv {
+					out[k] = v
+				}
+				for k, v := range rv {
+					out[k] = v
+				}
+				return out, nil
+			}
+		}
+		return nil, errorf("%s and %s cannot be added", describe(l), describe(r))
+	case "-":
+		if lnum && rnum {
+			return lf - rf, nil
+		}
+		if lv, ok := l.([]interface{}); ok {
+			if rv, ok := r.([]interface{}); ok {
+				out := []interface{}{}
+				for _, item := range lv {
+					keep := true
+					for _, remove := range rv {
+						if compare(item, remove) == 0 {
+							keep = false
+							break
+						}
+					}
+					if keep {
+						out = append(out, item)
+					}
+				}
+				return out, nil
+			}
+		}
+		return nil, errorf("%s and %s cannot be subtracted", describe(l), describe(r))
+	case "*":
+		if lnum && rnum {
+			return lf * rf, nil
+		}
+		if s, ok := l.(string); ok && rnum {
+			if rf <= 0 {
+				return nil, nil
+			}
+			return strings.Repeat(s, int(math.Ceil(rf))), nil
+		}
+		if lm, ok := l.(map[string]interface{}); ok {
+			if rm, ok := r.(map[string]interface{}); ok {
+				return deepMerge(lm, rm), nil
+			}
+		}
+		return nil, errorf("%s and %s cannot be multiplied", describe(l), describe(r))
+	case "/":
+		if lnum && rnum {
+			if rf == 0 {
+				return nil, errorf("%s and %s cannot be divided because the divisor is zero", describe(l), describe(r))
+			}
+			return lf / rf, nil
+		}
+		if ls, ok := l.(string); ok {
+			if rs, ok := r.(string); ok {
+				return splitString(ls, rs), nil
+			}
+		}
+		return nil, errorf("%s and %s cannot be divided", describe(l), describe(r))
+	case "%":
+		if lnum && rnum {
+			if int64(rf) == 0 {
+				return nil, errorf("%s and %s cannot be divided because the divisor is zero", describe(l), describe(r))
+			}
+			return float64(int64(lf) % int64(rf)), nil
+		}
+		return nil, errorf("%s and %s cannot be divided", describe(l), describe(r))
+	}
+	return nil, fmt.Errorf("jq: unknown operator %s", op)
+}
+
+func deepMerge(l, r map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(l)+len(r))
+	for k, v := range l {
+		out[k] = v
+	}
+	for k, v := range r {
+		lm, lok := out[k].(map[string]interface{})
+		rm, rok := v.(map[string]interface{})
+		if lok && rok {
+			out[k] = deepMerge(lm, rm)
+		} else {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func index(v, key interface{}) (interface{}, error) {
+	switch container := v.(type) {
+	case nil:
+		switch key.(type) {
+		case string, float64, nil:
+			return nil, nil
+		}
+	case map[string]interface{}:
+		if k, ok := key.(string); ok {
+			return container[k], nil
+		}
+	case []interface{}:
+		if k, ok := key.(float64); ok {
+			i := int(math.Floor(k))
+			if i < 0 {
+				i += len(container)
+			}
+			if i < 0 || i >= len(container) {
+				return nil, nil
+			}
+			return container[i], nil
+		}
+		if k, ok := key.(map[string]interface{}); ok {
+			return slice(v, k["start"], k["end"])
+		}
+	}
+	if s, ok := key.(string); ok {
+		return nil, errorf("cannot index %s with %q", typeName(v), s)
+	}
+	return nil, errorf("cannot index %s with %s", typeName(v), typeName(key))
+}
+
+func slice(v, from, to interface{}) (interface{}, error) {
+	var length int
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		length = len(t)
+	case string:
+		length = utf8.RuneCountInString(t)
+	default:
+		return nil, errorf("cannot slice %s", typeName(v))
+	}
+
+	start, end, err := sliceBounds(length, map[string]interface{}{"start": from, "end": to})
+	if err != nil {
+		return nil, err
+	}
+
+	if s, ok := v.(string); ok {
+		runes := []rune(s)
+		return string(runes[start:end]), nil
+	}
+	return append([]interface{}{}, v.([]interface{})[start:end]...), nil
+}
+
+func iterate(v interface{}) ([]interface{}, error) {
+	switch t := v.(type) {
+	case []interface{}:
+		return append([]interface{}{}, t...), nil
+	case map[string]interface{}:
+		keys := sortedKeys(t)
+		out := make([]interface{}, len(keys))
+		for i, k := range keys {
+			out[i] = t[k]
+		}
+		return out, nil
+	}
+	return nil, errorf("cannot iterate over %s", describe(v))
+}
+
+func recurse(v interface{}, out *[]interface{}) {
+	*out = append(*out, v)
+	if children, err := iterate(v); err == nil {
+		for _, child := range children {
+			recurse(child, out)
+		}
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func truthy(v interface{}) bool {
+	return v != nil && v != false
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// describe renders a value for error messages, like jq's "number (1)".
+func describe(v interface{}) string {
+	s := encode(v)
+	if runes := []rune(s); len(runes) > 30 {
+		s = string(runes[:27]) + "..."
+	}
+	return fmt.Sprintf("%s (%s)", typeName(v), s)
+}
+
+// encode renders v as compact JSON without escaping HTML characters.
+func encode(v interface{}) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func jsonUnmarshal(s string, v *interface{}) error {
+	return json.Unmarshal([]byte(s), v)
+}
+
+func tostring(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return encode(v)
+}
+
+var typeOrder = map[string]int{"null": 0, "boolean": 1, "number": 3, "string": 4, "array": 5, "object": 6}
+
+// compare orders values the way jq does: null < false < true < numbers <
+// strings < arrays < objects.
+func compare(a, b interface{}) int {
+	ra, rb := typeOrder[typeName(a)], typeOrder[typeName(b)]
+	if a == true {
+		ra = 2
+	}
+	if b == true {
+		rb = 2
+	}
+	if ra != rb {
+		return cmpInt(ra, rb)
+	}
+
+	switch av := a.(type) {
+	case float64:
+		bv := b.(float64)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(av, b.(string))
+	case []interface{}:
+		bv := b.([]interface{})
+		for i := 0; i < len(av) && i < len(bv); i++ {
+			if c := compare(av[i], bv[i]); c != 0 {
+				return c
+			}
+		}
+		return cmpInt(len(av), len(bv))
+	case map[string]interface{}:
+		bv := b.(map[string]interface{})
+		ak, bk := sortedKeys(av), sortedKeys(bv)
+		for i := 0; i < len(ak) && i < len(bk); i++ {
+			if c := strings.Compare(ak[i], bk[i]); c != 0 {
+				return c
+			}
+		}
+		if c := cmpInt(len(ak), len(bk)); c != 0 {
+			return c
+		}
+		for _, k := range ak {
+			if c := compare(av[k], bv[k]); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func environ() map[string]interface{} {
+	env := make(map[string]interface{})
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	return env
+}
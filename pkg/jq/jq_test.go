@@ -0,0 +1,190 @@
+package jq
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const issuesJSON = `[
+	{"identifier": "ENG-1", "title": "Login fails", "priority": 1, "estimate": null,
+	 "state": {"name": "In Progress", "type": "started"},
+	 "assignee": {"name": "Ada", "email": "ada@example.com"},
+	 "labels": {"nodes": [{"name": "bug"}, {"name": "auth"}]}},
+	{"identifier": "ENG-2", "title": "Add <dark> mode", "priority": 3, "estimate": 2,
+	 "state": {"name": "Todo", "type": "unstarted"},
+	 "assignee": null,
+	 "labels": {"nodes": []}},
+	{"identifier": "ENG-3", "title": "Fix \"quotes\"", "priority": 1, "estimate": 5,
+	 "state": {"name": "Done", "type": "completed"},
+	 "assignee": {"name": "Grace", "email": "grace@example.com"},
+	 "labels": {"nodes": [{"name": "bug"}]}}
+]`
+
+func run(t *testing.T, expr string) []string {
+	t.Helper()
+	var input interface{}
+	if err := json.Unmarshal([]byte(issuesJSON), &input); err != nil {
+		t.Fatalf("bad fixture: %v", err)
+	}
+	q, err := Parse(expr)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", expr, err)
+	}
+	results, err := q.Run(input)
+	if err != nil {
+		t.Fatalf("Run(%q) failed: %v", expr, err)
+	}
+	out := make([]string, len(results))
+	for i, r := range results {
+		out[i] = encode(r)
+	}
+	return out
+}
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{`.[0].identifier`, `"ENG-1"`},
+		{`.[].identifier`, `"ENG-1" "ENG-2" "ENG-3"`},
+		{`.[-1].state.name`, `"Done"`},
+		{`.[1:].[0].identifier`, `"ENG-2"`},
+		{`length`, `3`},
+		{`map(.priority) | add`, `5`},
+		{`[.[] | select(.priority == 1) | .identifier]`, `["ENG-1","ENG-3"]`},
+		{`[.[] | select(.assignee == null) | .identifier]`, `["ENG-2"]`},
+		{`.[] | select(.labels.nodes | any(.name == "auth")) | .title`, `"Login fails"`},
+		{`map({id: .identifier, who: (.assignee.name // "unassigned")}) | .[1]`, `{"id":"ENG-2","who":"unassigned"}`},
+		{`[.[] | .assignee?.email] | map(values)`, `["ada@example.com","grace@example.com"]`},
+		{`group_by(.priority) | map({priority: .[0].priority, count: length})`, `[{"count":2,"priority":1},{"count":1,"priority":3}]`},
+		{`sort_by(.estimate) | map(.identifier)`, `["ENG-1","ENG-2","ENG-3"]`},
+		{`max_by(.estimate).identifier`, `"ENG-3"`},
+		{`.[0] | "\(.identifier): \(.state.name)"`, `"ENG-1: In Progress"`},
+		{`.[] | [.identifier, .title, .priority] | @csv`, `"\"ENG-1\",\"Login fails\",1" "\"ENG-2\",\"Add <dark> mode\",3" "\"ENG-3\",\"Fix \"\"quotes\"\"\",1"`},
+		{`.[0] | [.identifier, .state.name] | @tsv`, `"ENG-1\tIn Progress"`},
+		{`.[0].labels.nodes | map(.name) | join(", ")`, `"bug, auth"`},
+		{`.[0] | keys`, `["assignee","estimate","identifier","labels","priority","state","title"]`},
+		{`.[0] | {identifier, state: .state.name}`, `{"identifier":"ENG-1","state":"In Progress"}`},
+		{`.[0].state | to_entries | map("\(.key)=\(.value)") | join("&")`, `"name=In Progress&type=started"`},
+		{`reduce .[] as $i (0; . + $i.priority)`, `5`},
+		{`.[0].priority as $p | map(select(.priority == $p)) | length`, `2`},
+		{`if .[0].estimate then "has" elif .[1].estimate then "second" else "none" end`, `"second"`},
+		{`[.[].title | test("^fix"; "i")]`, `[false,false,true]`},
+		{`.[0].title | sub("(?<word>fails)"; "\(.word | ascii_upcase)")`, `"Login FAILS"`},
+		{`"a-b-c" | gsub("-"; "+")`, `"a+b+c"`},
+		{`[limit(2; .[].identifier)]`, `["ENG-1","ENG-2"]`},
+		{`first(.[] | select(.priority > 1)).identifier`, `"ENG-2"`},
+		{`[.[].priority] | unique`, `[1,3]`},
+		{`[range(3)]`, `[0,1,2]`},
+		{`[1, [2, [3]]] | flatten`, `[1,2,3]`},
+		{`{"a": 1} + {"b": 2} | has("b")`, `true`},
+		{`[.. | .name? | strings] | length`, `8`},
+		{`try error("boom") catch .`, `"boom"`},
+		{`[.[] | .title | contains("mode")]`, `[false,true,false]`},
+		{`"2024-01-02T03:04:05.000Z" | fromdateiso8601 | todate`, `"2024-01-02T03:04:05Z"`},
+		{`"ENG-42" | ltrimstr("ENG-") | tonumber + 1`, `43`},
+		{`.[1].title | @html`, `"Add &lt;dark&gt; mode"`},
+		{`"a b/c" | @uri`, `"a%20b%2Fc"`},
+		{`[.[] | .estimate // 0] | add / length`, `2.3333333333333335`},
+		{`foreach .[] as $i (0; . + 1; [$i.identifier, .])`, `["ENG-1",1] ["ENG-2",2] ["ENG-3",3]`},
+		{`1, 2 | . * 10`, `10 20`},
+		{`(1, 2) + (10, 20)`, `11 12 21 22`},
+		{`[.[] | .priority] | min, max`, `1 3`},
+		{`.[0].missing.deeper`, `null`},
+		{`[.[] | .state.type | ascii_upcase]`, `["STARTED","UNSTARTED","COMPLETED"]`},
+		{`.[0].title | .[0:5]`, `"Login"`},
+		{`[.[].identifier] - ["ENG-2"]`, `["ENG-1","ENG-3"]`},
+		{`"x" * 3`, `"xxx"`},
+		{`not`, `false`},
+		{`[.[] | .title | split(" ") | length]`, `[2,3,2]`},
+		{`.[0] | del(.assignee, .labels, .state) | keys`, `["estimate","identifier","priority","title"]`},
+		{`map(del(.labels.nodes[] | select(.name == "bug"))) | map(.labels.nodes | length)`, `[1,0,0]`},
+		{`del(.[0, 2]) | map(.identifier)`, `["ENG-2"]`},
+		{`del(.[1:]) | length`, `1`},
+		{`.[0].state | [paths]`, `[["name"],["type"]]`},
+		{`.[0].labels | [paths(type == "string")]`, `[["nodes",0,"name"],["nodes",1,"name"]]`},
+		{`[.[0] | leaf_paths] | length`, `9`},
+		{`[path(.[] | select(.priority == 1) | .title)]`, `[[0,"title"],[2,"title"]]`},
+		{`[path(..)] | length`, `44`},
+		{`getpath([0, "state", "name"]), getpath([5, "missing"])`, `"In Progress" null`},
+		{`null | setpath(["a", 1]; true)`, `{"a":[null,true]}`},
+		{`{"a": 1, "b": 2} | delpaths([["a"], ["c"]])`, `{"b":2}`},
+		{`map(.priority |= . + 1) | map(.priority)`, `[2,4,2]`},
+		{`.[0].state.name |= ascii_upcase | .[0].state.name`, `"IN PROGRESS"`},
+		{`map(.estimate //= 0) | map(.estimate)`, `[0,2,5]`},
+		{`.[].priority += 10 | map(.priority)`, `[11,13,11]`},
+		{`.[0].labels.nodes[].name = "x" | .[0].labels.nodes`, `[{"name":"x"},{"name":"x"}]`},
+		{`.[0].tags = (1, 2) | .[0].tags`, `1 2`},
+		{`[1, 2, 3] | .[] |= empty`, `[]`},
+		{`[1, 2, 3] | .[1:] = ["x"]`, `[1,"x"]`},
+		{`{"a": {"b": 10}} | .a.b *= 2 | .a.b -= 1 | .a.b /= 2 | .a.b %= 4`, `{"a":{"b":1}}`},
+		{`.[0] | .priority == 1 or false`, `true`},
+		{`"a, b, c" | [splits(", *")]`, `["a","b","c"]`},
+		{`"a-b-a" | index("a"), rindex("a"), indices("-")`, `0 4 [1,3]`},
+		{`[1, 2, 1, 2] | indices([1, 2]), index(2)`, `[0,2] 1`},
+	}
+	for _, tc := range cases {
+		got := strings.Join(run(t, tc.expr), " ")
+		if got != tc.want {
+			t.Errorf("%s\n got: %s\nwant: %s", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{`.foo |`, "column 7: unexpected end of expression"},
+		{`map(.a`, `expected ")"`},
+		{`frobnicate(.)`, "unknown function frobnicate/1"},
+		{`def f: .; f`, `"def" is not supported`},
+		{`.a = 1 = 2`, `unexpected "="`},
+		{`@nope`, "unknown format @nope"},
+		{`"unterminated`, "unterminated string"},
+		{`if . then 1`, `expected "end"`},
+		{`{a: 1`, `expected "}"`},
+		{`.[] as x | .`, "expected a $variable"},
+	}
+	for _, tc := range cases {
+		_, err := Parse(tc.expr)
+		if err == nil {
+			t.Errorf("Expected an error for %q", tc.expr)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Parse(%q) error = %q, want it to contain %q", tc.expr, err, tc.want)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{`.[0].title.x`, `cannot index string with "x"`},
+		{`.[0].priority + "a"`, `number (1) and string ("a") cannot be added`},
+		{`.[0].priority[]`, `cannot iterate over number (1)`},
+		{`$undefined`, `$undefined is not defined`},
+		{`error("custom")`, `custom`},
+		{`path(.[0].priority + 1)`, `invalid path expression with result number (2)`},
+		{`.[0].title[0] = 1`, `cannot index string with number`},
+		{`del(.[0].title.x)`, `cannot index string with "x"`},
+	}
+	var input interface{}
+	_ = json.Unmarshal([]byte(issuesJSON), &input)
+	for _, tc := range cases {
+		q, err := Parse(tc.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tc.expr, err)
+		}
+		_, err = q.Run(input)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Run(%q) error = %v, want it to contain %q", tc.expr, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package jq
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF    tokenKind = iota
+	tokIdent            // map, select, if, and, ...
+	tokField            // .name
+	tokVar              // $name
+	tokNumber           // 1, 2.5, 1e3
+	tokString           // "text \(.interpolated)"
+	tokFormat           // @csv
+	tokPunct            // . .. [ ] { } ( ) | , : ; ? and operators
+)
+
+// stringPart is a literal run of a string, or the source of an interpolation.
+type stringPart struct {
+	literal string
+	expr    string
+	isExpr  bool
+	offset  int
+}
+
+type token struct {
+	kind   tokenKind
+	text   string
+	num    float64
+	parts  []stringPart
+	offset int
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return "string"
+	}
+	return strconv.Quote(t.text)
+}
+
+// SyntaxError reports an expression that could not be parsed.
+type SyntaxError struct {
+	Offset  int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at column %d: %s", e.Offset+1, e.Message)
+}
+
+// twoCharPuncts are the operators made of two characters; they are tried
+// before single characters so "//" is not read as two divisions.
+var twoCharPuncts = []string{"//=", "..", "==", "!=", "<=", ">=", "//", "|=", "+=", "-=", "*=", "/=", "%="}
+
+func lex(src string, base int) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '"':
+			parts, end, err := lexString(src, i, base)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokString, parts: parts, offset: base + i})
+			i = end
+			continue
+		case c == '.' && i+1 < len(src) && isIdentStart(src[i+1]):
+			j := i + 1
+			for j < len(src) && isIdentChar(src[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokField, text: src[i+1 : j], offset: base + i})
+			i = j
+			continue
+		case c == '$' || c == '@':
+			j := i + 1
+			for j < len(src) && isIdentChar(src[j]) {
+				j++
+			}
+			if j == i+1 {
+				return nil, &SyntaxError{Offset: base + i, Message: fmt.Sprintf("expected a name after %q", c)}
+			}
+			kind := tokVar
+			if c == '@' {
+				kind = tokFormat
+			}
+			tokens = append(tokens, token{kind: kind, text: src[i+1 : j], offset: base + i})
+			i = j
+			continue
+		case isDigit(c) || (c == '.' && i+1 < len(src) && isDigit(src[i+1])):
+			j := i
+			for j < len(src) && (isDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			if j < len(src) && (src[j] == 'e' || src[j] == 'E') {
+				j++
+				if j < len(src) && (src[j] == '+' || src[j] == '-') {
+					j++
+				}
+				for j < len(src) && isDigit(src[j]) {
+					j++
+				}
+			}
+			num, err := strconv.ParseFloat(src[i:j], 64)
+			if err != nil {
+				return nil, &SyntaxError{Offset: base + i, Message: fmt.Sprintf("invalid number %q", src[i:j])}
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[i:j], num: num, offset: base + i})
+			i = j
+			continue
+		case isIdentStart(c):
+			j := i
+			for j < len(src) && isIdentChar(src[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[i:j], offset: base + i})
+			i = j
+			continue
+		}
+
+		matched := false
+		for _, p := range twoCharPuncts {
+			if strings.HasPrefix(src[i:], p) {
+				tokens = append(tokens, token{kind: tokPunct, text: p, offset: base + i})
+				i += len(p)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		if strings.IndexByte(".[]{}()|,:;?=<>+-*/%", c) >= 0 {
+			tokens = append(tokens, token{kind: tokPunct, text: string(c), offset: base + i})
+			i++
+			continue
+		}
+		r, _ := utf8.DecodeRuneInString(src[i:])
+		return nil, &SyntaxError{Offset: base + i, Message: fmt.Sprintf("unexpected character %q", r)}
+	}
+	return append(tokens, token{kind: tokEOF, offset: base + len(src)}), nil
+}
+
+// lexString reads the string literal starting at src[start], splitting it
+// into literal text and \( ... ) interpolations.
+func lexString(src string, start, base int) ([]stringPart, int, error) {
+	var parts []stringPart
+	var b strings.Builder
+	i := start + 1
+	for i < len(src) {
+		c := src[i]
+		switch c {
+		case '"':
+			if b.Len() > 0 || len(parts) == 0 {
+				parts = append(parts, stringPart{literal: b.String()})
+			}
+			return parts, i + 1, nil
+		case '\\':
+			if i+1 >= len(src) {
+				return nil, 0, &SyntaxError{Offset: base + i, Message: "unterminated string"}
+			}
+			esc := src[i+1]
+			switch esc {
+			case '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'u':
+				if i+6 > len(src) {
+					return nil, 0, &SyntaxError{Offset: base + i, Message: "invalid \\u escape"}
+				}
+				code, err := strconv.ParseUint(src[i+2:i+6], 16, 32)
+				if err != nil {
+					return nil, 0, &SyntaxError{Offset: base + i, Message: "invalid \\u escape"}
+				}
+				b.WriteRune(rune(code))
+				i += 4
+			case '(':
+				end, err := matchParen(src, i+1, base)
+				if err != nil {
+					return nil, 0, err
+				}
+				if b.Len() > 0 {
+					parts = append(parts, stringPart{literal: b.String()})
+					b.Reset()
+				}
+				parts = append(parts, stringPart{expr: src[i+2 : end], isExpr: true, offset: base + i + 2})
+				i = end + 1
+				continue
+			default:
+				return nil, 0, &SyntaxError{Offset: base + i, Message: fmt.Sprintf("invalid escape \\%c", esc)}
+			}
+			i += 2
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return nil, 0, &SyntaxError{Offset: base + start, Message: "unterminated string"}
+}
+
+// matchParen returns the index of the parenthesis closing the one at src[open],
+// skipping over nested strings.
+func matchParen(src string, open, base int) (int, error) {
+	depth := 0
+	for i := open; i < len(src); i++ {
+		switch src[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		case '"':
+			_, end, err := lexString(src, i, base)
+			if err != nil {
+				return 0, err
+			}
+			i = end - 1
+		}
+	}
+	return 0, &SyntaxError{Offset: base + open, Message: "unterminated string interpolation"}
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool { return isIdentStart(c) || isDigit(c) }
@@ -0,0 +1,689 @@
+package jq
+
+import (
+	"fmt"
+)
+
+// node is an element of a parsed expression.
+type node interface{}
+
+type (
+	identityNode struct{}
+	recurseNode  struct{}
+	literalNode  struct{ value interface{} }
+	varNode      struct{ name string }
+	formatNode   struct{ name string }
+	fieldNode    struct {
+		target node
+		name   string
+	}
+	indexNode  struct{ target, index node }
+	sliceNode  struct{ target, from, to node }
+	iterNode   struct{ target node }
+	stringNode struct {
+		segments []stringSegment
+		format   string
+	}
+	arrayNode  struct{ body node }
+	objectNode struct{ entries []objectEntry }
+	pipeNode   struct{ left, right node }
+	commaNode  struct{ left, right node }
+	bindNode   struct {
+		source node
+		name   string
+		body   node
+	}
+	binaryNode struct {
+		op          string
+		left, right node
+	}
+	negNode    struct{ operand node }
+	ifNode     struct{ cond, then, els node }
+	tryNode    struct{ body, catch node }
+	reduceNode struct {
+		source       node
+		name         string
+		init, update node
+	}
+	foreachNode struct {
+		source                node
+		name                  string
+		init, update, extract node
+	}
+	assignNode struct {
+		op            string
+		target, value node
+	}
+	callNode struct {
+		name string
+		args []node
+	}
+)
+
+// stringSegment is literal text, or an interpolated expression when expr is set.
+type stringSegment struct {
+	literal string
+	expr    node
+}
+
+type objectEntry struct {
+	key   node
+	value node
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parse(src string, base int) (node, error) {
+	tokens, err := lex(src, base)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	n, err := p.parsePipe(true)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.unexpected(t)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isPunct(text string) bool {
+	t := p.peek()
+	return t.kind == tokPunct && t.text == text
+}
+
+func (p *parser) isKeyword(word string) bool {
+	t := p.peek()
+	return t.kind == tokIdent && t.text == word
+}
+
+func (p *parser) expectPunct(text string) error {
+	if !p.isPunct(text) {
+		return p.errorf(p.peek(), "expected %q, found %s", text, p.peek().describe())
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) expectKeyword(word string) error {
+	if !p.isKeyword(word) {
+		return p.errorf(p.peek(), "expected %q, found %s", word, p.peek().describe())
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return &SyntaxError{Offset: t.offset, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) unexpected(t token) error {
+	return p.errorf(t, "unexpected %s", t.describe())
+}
+
+// parsePipe parses a full expression. Commas are only allowed when
+// allowComma is set, so object values can stop at the next entry.
+func (p *parser) parsePipe(allowComma bool) (node, error) {
+	var left node
+	var err error
+	if allowComma {
+		left, err = p.parseComma()
+	} else {
+		left, err = p.parseAlternative()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if p.isKeyword("as") {
+		p.next()
+		v := p.next()
+		if v.kind != tokVar {
+			return nil, p.errorf(v, "expected a $variable after \"as\", found %s", v.describe())
+		}
+		if err := p.expectPunct("|"); err != nil {
+			return nil, err
+		}
+		body, err := p.parsePipe(allowComma)
+		if err != nil {
+			return nil, err
+		}
+		return &bindNode{source: left, name: v.text, body: body}, nil
+	}
+
+	if p.isPunct("|") {
+		p.next()
+		right, err := p.parsePipe(allowComma)
+		if err != nil {
+			return nil, err
+		}
+		return &pipeNode{left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseComma() (node, error) {
+	left, err := p.parseAlternative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct(",") {
+		p.next()
+		right, err := p.parseAlternative()
+		if err != nil {
+			return nil, err
+		}
+		left = &commaNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAlternative() (node, error) {
+	left, err := p.parseAssign()
+	if err != nil {
+		return nil, err
+	}
+	if p.isPunct("//") {
+		p.next()
+		right, err := p.parseAlternative()
+		if err != nil {
+			return nil, err
+		}
+		return &binaryNode{op: "//", left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+var assignOps = []string{"=", "|=", "+=", "-=", "*=", "/=", "%=", "//="}
+
+// parseAssign parses an optional assignment. As in jq, it binds more loosely
+// than "or" but more tightly than "//", and does not chain.
+func (p *parser) parseAssign() (node, error) {
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range assignOps {
+		if p.isPunct(op) {
+			p.next()
+			right, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return &assignNode{op: op, target: left, value: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.isPunct(op) {
+			p.next()
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			return &binaryNode{op: op, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("+") || p.isPunct("-") {
+		op := p.next().text
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isPunct("*") || p.isPunct("/") || p.isPunct("%") {
+		op := p.next().text
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isPunct("-") {
+		p.next()
+		operand, err := p.parsePostfix()
+		if err != nil {
+			return nil, err
+		}
+		return &negNode{operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+// parsePostfix parses a term followed by any number of .field, [index],
+// [from:to], [] and ? suffixes.
+func (p *parser) parsePostfix() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokField:
+			p.next()
+			n = &fieldNode{target: n, name: t.text}
+		case t.kind == tokPunct && t.text == "." && p.tokens[p.pos+1].kind == tokString:
+			p.next()
+			name, err := p.parseString(p.next(), "")
+			if err != nil {
+				return nil, err
+			}
+			n = &indexNode{target: n, index: name}
+		case t.kind == tokPunct && t.text == "." && p.tokens[p.pos+1].kind == tokPunct && p.tokens[p.pos+1].text == "[":
+			p.next()
+		case t.kind == tokPunct && t.text == "[":
+			p.next()
+			n, err = p.parseBracketSuffix(n)
+			if err != nil {
+				return nil, err
+			}
+		case t.kind == tokPunct && t.text == "?":
+			p.next()
+			n = &tryNode{body: n}
+		default:
+			return n, nil
+		}
+	}
+}
+
+// parseBracketSuffix parses what follows "[" after a term: "]", "expr]",
+// "from:to]", "from:]" or ":to]".
+func (p *parser) parseBracketSuffix(target node) (node, error) {
+	if p.isPunct("]") {
+		p.next()
+		return &iterNode{target: target}, nil
+	}
+	var from node
+	if !p.isPunct(":") {
+		var err error
+		from, err = p.parsePipe(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.isPunct(":") {
+		p.next()
+		var to node
+		if !p.isPunct("]") {
+			var err error
+			to, err = p.parsePipe(true)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+		return &sliceNode{target: target, from: from, to: to}, nil
+	}
+	if err := p.expectPunct("]"); err != nil {
+		return nil, err
+	}
+	return &indexNode{target: target, index: from}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokEOF:
+		return nil, p.errorf(t, "unexpected end of expression")
+	case tokNumber:
+		return &literalNode{value: t.num}, nil
+	case tokString:
+		return p.parseString(t, "")
+	case tokField:
+		return &fieldNode{target: &identityNode{}, name: t.text}, nil
+	case tokVar:
+		return &varNode{name: t.text}, nil
+	case tokFormat:
+		if !formats[t.text] {
+			return nil, p.errorf(t, "unknown format @%s", t.text)
+		}
+		if p.peek().kind == tokString {
+			return p.parseString(p.next(), t.text)
+		}
+		return &formatNode{name: t.text}, nil
+	case tokIdent:
+		return p.parseKeywordOrCall(t)
+	}
+
+	switch t.text {
+	case ".":
+		if p.peek().kind == tokString {
+			name, err := p.parseString(p.next(), "")
+			if err != nil {
+				return nil, err
+			}
+			return &indexNode{target: &identityNode{}, index: name}, nil
+		}
+		return &identityNode{}, nil
+	case "..":
+		return &recurseNode{}, nil
+	case "(":
+		n, err := p.parsePipe(true)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case "[":
+		if p.isPunct("]") {
+			p.next()
+			return &arrayNode{}, nil
+		}
+		body, err := p.parsePipe(true)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+		return &arrayNode{body: body}, nil
+	case "{":
+		return p.parseObject()
+	}
+	return nil, p.unexpected(t)
+}
+
+func (p *parser) parseKeywordOrCall(t token) (node, error) {
+	switch t.text {
+	case "true":
+		return &literalNode{value: true}, nil
+	case "false":
+		return &literalNode{value: false}, nil
+	case "null":
+		return &literalNode{value: nil}, nil
+	case "if":
+		return p.parseIf()
+	case "try":
+		body, err := p.parsePostfix()
+		if err != nil {
+			return nil, err
+		}
+		n := &tryNode{body: body}
+		if p.isKeyword("catch") {
+			p.next()
+			n.catch, err = p.parsePostfix()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return n, nil
+	case "reduce", "foreach":
+		return p.parseFold(t.text)
+	case "def", "label", "import", "include":
+		return nil, p.errorf(t, "%q is not supported", t.text)
+	case "then", "elif", "else", "end", "as", "catch", "and", "or":
+		return nil, p.unexpected(t)
+	}
+
+	call := &callNode{name: t.text}
+	if p.isPunct("(") {
+		p.next()
+		for {
+			arg, err := p.parsePipe(true)
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if p.isPunct(";") {
+				p.next()
+				continue
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	if _, ok := builtins[call.key()]; !ok {
+		return nil, p.errorf(t, "unknown function %s", call.key())
+	}
+	return call, nil
+}
+
+func (p *parser) parseIf() (node, error) {
+	cond, err := p.parsePipe(true)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("then"); err != nil {
+		return nil, err
+	}
+	then, err := p.parsePipe(true)
+	if err != nil {
+		return nil, err
+	}
+	n := &ifNode{cond: cond, then: then}
+	switch {
+	case p.isKeyword("elif"):
+		p.next()
+		n.els, err = p.parseIf()
+		return n, err
+	case p.isKeyword("else"):
+		p.next()
+		n.els, err = p.parsePipe(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectKeyword("end"); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// parseFold parses "reduce SOURCE as $x (INIT; UPDATE)" and
+// "foreach SOURCE as $x (INIT; UPDATE; EXTRACT)".
+func (p *parser) parseFold(keyword string) (node, error) {
+	source, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("as"); err != nil {
+		return nil, err
+	}
+	v := p.next()
+	if v.kind != tokVar {
+		return nil, p.errorf(v, "expected a $variable after \"as\", found %s", v.describe())
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	var parts []node
+	for {
+		part, err := p.parsePipe(true)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+		if !p.isPunct(";") {
+			break
+		}
+		p.next()
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+
+	if keyword == "reduce" {
+		if len(parts) != 2 {
+			return nil, p.errorf(v, "reduce expects (init; update)")
+		}
+		return &reduceNode{source: source, name: v.text, init: parts[0], update: parts[1]}, nil
+	}
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, p.errorf(v, "foreach expects (init; update) or (init; update; extract)")
+	}
+	n := &foreachNode{source: source, name: v.text, init: parts[0], update: parts[1]}
+	if len(parts) == 3 {
+		n.extract = parts[2]
+	}
+	return n, nil
+}
+
+func (p *parser) parseObject() (node, error) {
+	obj := &objectNode{}
+	if p.isPunct("}") {
+		p.next()
+		return obj, nil
+	}
+	for {
+		t := p.next()
+		var entry objectEntry
+		switch {
+		case t.kind == tokIdent:
+			entry.key = &literalNode{value: t.text}
+			entry.value = &fieldNode{target: &identityNode{}, name: t.text}
+		case t.kind == tokVar:
+			entry.key = &literalNode{value: t.text}
+			entry.value = &varNode{name: t.text}
+		case t.kind == tokString:
+			key, err := p.parseString(t, "")
+			if err != nil {
+				return nil, err
+			}
+			entry.key = key
+			entry.value = &indexNode{target: &identityNode{}, index: key}
+		case t.kind == tokNumber:
+			entry.key = &literalNode{value: t.text}
+		case t.kind == tokPunct && t.text == "(":
+			key, err := p.parsePipe(true)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			entry.key = key
+		default:
+			return nil, p.errorf(t, "unexpected %s in object", t.describe())
+		}
+
+		if p.isPunct(":") {
+			p.next()
+			value, err := p.parseObjectValue()
+			if err != nil {
+				return nil, err
+			}
+			entry.value = value
+		} else if entry.value == nil {
+			return nil, p.errorf(p.peek(), "expected \":\" after object key")
+		}
+		obj.entries = append(obj.entries, entry)
+
+		if p.isPunct(",") {
+			p.next()
+			continue
+		}
+		if err := p.expectPunct("}"); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+}
+
+// parseObjectValue parses an object value, which may contain pipes but not
+// commas, since a comma starts the next entry.
+func (p *parser) parseObjectValue() (node, error) {
+	return p.parsePipe(false)
+}
+
+// parseString turns a string token into a literal, or an interpolation node
+// when it contains \( ... ). A format such as @csv applies to interpolated values.
+func (p *parser) parseString(t token, format string) (node, error) {
+	if len(t.parts) == 1 && !t.parts[0].isExpr && format == "" {
+		return &literalNode{value: t.parts[0].literal}, nil
+	}
+	n := &stringNode{format: format}
+	for _, part := range t.parts {
+		if !part.isExpr {
+			n.segments = append(n.segments, stringSegment{literal: part.literal})
+			continue
+		}
+		expr, err := parse(part.expr, part.offset)
+		if err != nil {
+			return nil, err
+		}
+		n.segments = append(n.segments, stringSegment{expr: expr})
+	}
+	return n, nil
+}
@@ -0,0 +1,569 @@
+package jq
+
+import (
+	"math"
+	"sort"
+)
+
+// pathValue is a location in the input and the value found there.
+type pathValue struct {
+	path  []interface{}
+	value interface{}
+}
+
+func (p pathValue) child(key, value interface{}) pathValue {
+	path := make([]interface{}, len(p.path), len(p.path)+1)
+	copy(path, p.path)
+	return pathValue{path: append(path, key), value: value}
+}
+
+// evalPaths evaluates n as a path expression, such as .a[0] or
+// .[] | select(.done), starting at cur. It backs path(), del() and the
+// assignment operators.
+func evalPaths(n node, cur pathValue, vars *scope) ([]pathValue, error) {
+	switch n := n.(type) {
+	case *identityNode:
+		return []pathValue{cur}, nil
+
+	case *recurseNode:
+		return recursePaths(cur), nil
+
+	case *fieldNode:
+		return eachPath(n.target, cur, vars, func(p pathValue) ([]pathValue, error) {
+			v, err := index(p.value, n.name)
+			if err != nil {
+				return nil, err
+			}
+			return []pathValue{p.child(n.name, v)}, nil
+		})
+
+	case *indexNode:
+		return eachPath(n.target, cur, vars, func(p pathValue) ([]pathValue, error) {
+			keys, err := eval(n.index, cur.value, vars)
+			if err != nil {
+				return nil, err
+			}
+			var out []pathValue
+			for _, k := range keys {
+				v, err := index(p.value, k)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, p.child(k, v))
+			}
+			return out, nil
+		})
+
+	case *sliceNode:
+		return eachPath(n.target, cur, vars, func(p pathValue) ([]pathValue, error) {
+			key := map[string]interface{}{"start": nil, "end": nil}
+			if n.from != nil {
+				r, err := evalOne(n.from, cur.value, vars)
+				if err != nil {
+					return nil, err
+				}
+				key["start"] = r
+			}
+			if n.to != nil {
+				r, err := evalOne(n.to, cur.value, vars)
+				if err != nil {
+					return nil, err
+				}
+				key["end"] = r
+			}
+			v, err := slice(p.value, key["start"], key["end"])
+			if err != nil {
+				return nil, err
+			}
+			return []pathValue{p.child(key, v)}, nil
+		})
+
+	case *iterNode:
+		return eachPath(n.target, cur, vars, func(p pathValue) ([]pathValue, error) {
+			switch t := p.value.(type) {
+			case nil:
+				return nil, nil
+			case []interface{}:
+				out := make([]pathValue, len(t))
+				for i, item := range t {
+					out[i] = p.child(float64(i), item)
+				}
+				return out, nil
+			case map[string]interface{}:
+				keys := sortedKeys(t)
+				out := make([]pathValue, len(keys))
+				for i, k := range keys {
+					out[i] = p.child(k, t[k])
+				}
+				return out, nil
+			}
+			return nil, errorf("cannot iterate over %s", describe(p.value))
+		})
+
+	case *pipeNode:
+		return eachPath(n.left, cur, vars, func(p pathValue) ([]pathValue, error) {
+			return evalPaths(n.right, p, vars)
+		})
+
+	case *commaNode:
+		left, err := evalPaths(n.left, cur, vars)
+		if err != nil {
+			return nil, err
+		}
+		right, err := evalPaths(n.right, cur, vars)
+		if err != nil {
+			return nil, err
+		}
+		return append(left, right...), nil
+
+	case *bindNode:
+		values, err := eval(n.source, cur.value, vars)
+		if err != nil {
+			return nil, err
+		}
+		var out []pathValue
+		for _, v := range values {
+			r, err := evalPaths(n.body, cur, vars.bind(n.name, v))
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, r...)
+		}
+		return out, nil
+
+	case *ifNode:
+		conds, err := eval(n.cond, cur.value, vars)
+		if err != nil {
+			return nil, err
+		}
+		var out []pathValue
+		for _, c := range conds {
+			var r []pathValue
+			switch {
+			case truthy(c):
+				r, err = evalPaths(n.then, cur, vars)
+			case n.els == nil:
+				r = []pathValue{cur}
+			default:
+				r, err = evalPaths(n.els, cur, vars)
+			}
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, r...)
+		}
+		return out, nil
+
+	case *tryNode:
+		out, err := evalPaths(n.body, cur, vars)
+		if err != nil && n.catch != nil {
+			return nil, errorf("try/catch cannot be used as a path expression")
+		}
+		return out, nil
+
+	case *binaryNode:
+		if n.op == "//" {
+			left, err := evalPaths(n.left, cur, vars)
+			var out []pathValue
+			for _, p := range left {
+				if truthy(p.value) {
+					out = append(out, p)
+				}
+			}
+			if err == nil && len(out) > 0 {
+				return out, nil
+			}
+			return evalPaths(n.right, cur, vars)
+		}
+
+	case *callNode:
+		switch n.key() {
+		case "empty/0":
+			return nil, nil
+		case "error/0", "error/1":
+			_, err := eval(n, cur.value, vars)
+			return nil, err
+		case "select/1":
+			conds, err := eval(n.args[0], cur.value, vars)
+			if err != nil {
+				return nil, err
+			}
+			var out []pathValue
+			for _, c := range conds {
+				if truthy(c) {
+					out = append(out, cur)
+				}
+			}
+			return out, nil
+		case "recurse/0":
+			return recursePaths(cur), nil
+		case "recurse/1":
+			var out []pathValue
+			var walk func(p pathValue) error
+			walk = func(p pathValue) error {
+				out = append(out, p)
+				children, err := evalPaths(n.args[0], p, vars)
+				if err != nil {
+					return err
+				}
+				for _, child := range children {
+					if err := walk(child); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			return out, walk(cur)
+		case "first/1", "last/1":
+			out, err := evalPaths(n.args[0], cur, vars)
+			if err != nil || len(out) == 0 {
+				return nil, err
+			}
+			if n.name == "first" {
+				return out[:1], nil
+			}
+			return out[len(out)-1:], nil
+		case "getpath/1":
+			paths, err := eval(n.args[0], cur.value, vars)
+			if err != nil {
+				return nil, err
+			}
+			var out []pathValue
+			for _, p := range paths {
+				keys, ok := p.([]interface{})
+				if !ok {
+					return nil, errorf("path must be an array, got %s", describe(p))
+				}
+				next := cur
+				for _, k := range keys {
+					v, err := index(next.value, k)
+					if err != nil {
+						return nil, err
+					}
+					next = next.child(k, v)
+				}
+				out = append(out, next)
+			}
+			return out, nil
+		}
+	}
+
+	values, err := eval(n, cur.value, vars)
+	if err != nil || len(values) == 0 {
+		return nil, err
+	}
+	return nil, errorf("invalid path expression with result %s", describe(values[0]))
+}
+
+// eachPath evaluates target as a path expression and calls fn with every
+// resulting path, concatenating the outputs.
+func eachPath(target node, cur pathValue, vars *scope, fn func(pathValue) ([]pathValue, error)) ([]pathValue, error) {
+	paths, err := evalPaths(target, cur, vars)
+	if err != nil {
+		return nil, err
+	}
+	var out []pathValue
+	for _, p := range paths {
+		r, err := fn(p)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r...)
+	}
+	return out, nil
+}
+
+// recursePaths returns cur and the path of every value inside it, like "..".
+func recursePaths(cur pathValue) []pathValue {
+	out := []pathValue{cur}
+	switch t := cur.value.(type) {
+	case []interface{}:
+		for i, item := range t {
+			out = append(out, recursePaths(cur.child(float64(i), item))...)
+		}
+	case map[string]interface{}:
+		for _, k := range sortedKeys(t) {
+			out = append(out, recursePaths(cur.child(k, t[k]))...)
+		}
+	}
+	return out
+}
+
+// pathsOf returns the path arrays of every output of the path expression n.
+func pathsOf(n node, in interface{}, vars *scope) ([]interface{}, error) {
+	paths, err := evalPaths(n, pathValue{path: []interface{}{}, value: in}, vars)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]interface{}, len(paths))
+	for i, p := range paths {
+		out[i] = p.path
+	}
+	return out, nil
+}
+
+func toPath(p interface{}) ([]interface{}, error) {
+	keys, ok := p.([]interface{})
+	if !ok {
+		return nil, errorf("path must be an array, got %s", describe(p))
+	}
+	return keys, nil
+}
+
+func getPath(v interface{}, path []interface{}) (interface{}, error) {
+	for _, k := range path {
+		if v == nil {
+			return nil, nil
+		}
+		var err error
+		if v, err = index(v, k); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// setPath returns a copy of v with the value at path replaced by x. Missing
+// objects and arrays along the way are created.
+func setPath(v interface{}, path []interface{}, x interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return x, nil
+	}
+	key, rest := path[0], path[1:]
+	switch k := key.(type) {
+	case string:
+		m, ok := v.(map[string]interface{})
+		if !ok && v != nil {
+			return nil, errorf("cannot index %s with %q", typeName(v), k)
+		}
+		child, err := setPath(m[k], rest, x)
+		if err != nil {
+			return nil, err
+		}
+		out := make(map[string]interface{}, len(m)+1)
+		for mk, mv := range m {
+			out[mk] = mv
+		}
+		out[k] = child
+		return out, nil
+
+	case float64:
+		a, ok := v.([]interface{})
+		if !ok && v != nil {
+			return nil, errorf("cannot index %s with number", typeName(v))
+		}
+		i := int(math.Floor(k))
+		if i < 0 {
+			i += len(a)
+			if i < 0 {
+				return nil, errorf("out of bounds negative array index")
+			}
+		}
+		var old interface{}
+		if i < len(a) {
+			old = a[i]
+		}
+		child, err := setPath(old, rest, x)
+		if err != nil {
+			return nil, err
+		}
+		size := len(a)
+		if i >= size {
+			size = i + 1
+		}
+		out := make([]interface{}, size)
+		copy(out, a)
+		out[i] = child
+		return out, nil
+
+	case map[string]interface{}:
+		a, ok := v.([]interface{})
+		if !ok && v != nil {
+			return nil, errorf("cannot update a slice of %s", typeName(v))
+		}
+		start, end, err := sliceBounds(len(a), k)
+		if err != nil {
+			return nil, err
+		}
+		child, err := setPath(append([]interface{}{}, a[start:end]...), rest, x)
+		if err != nil {
+			return nil, err
+		}
+		replacement, ok := child.([]interface{})
+		if !ok {
+			return nil, errorf("a slice can only be assigned an array, got %s", describe(child))
+		}
+		out := append(append(append([]interface{}{}, a[:start]...), replacement...), a[end:]...)
+		return out, nil
+	}
+	return nil, errorf("invalid path component %s", describe(key))
+}
+
+// deletePaths returns a copy of v without the values at paths. Paths are
+// removed from the last to the first so earlier array indexes stay valid.
+func deletePaths(v interface{}, paths []interface{}) (interface{}, error) {
+	sorted := append([]interface{}{}, paths...)
+	sort.SliceStable(sorted, func(i, j int) bool { return compare(sorted[i], sorted[j]) > 0 })
+	for _, p := range sorted {
+		path, err := toPath(p)
+		if err != nil {
+			return nil, err
+		}
+		if v, err = deletePath(v, path); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func deletePath(v interface{}, path []interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+	if v == nil {
+		return nil, nil
+	}
+	key, rest := path[0], path[1:]
+	if len(rest) > 0 {
+		child, err := index(v, key)
+		if err != nil {
+			return nil, err
+		}
+		if child, err = deletePath(child, rest); err != nil {
+			return nil, err
+		}
+		return setPath(v, path[:1], child)
+	}
+
+	switch k := key.(type) {
+	case string:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, errorf("cannot delete field %q of %s", k, typeName(v))
+		}
+		out := make(map[string]interface{}, len(m))
+		for mk, mv := range m {
+			if mk != k {
+				out[mk] = mv
+			}
+		}
+		return out, nil
+
+	case float64:
+		a, ok := v.([]interface{})
+		if !ok {
+			return nil, errorf("cannot delete an element of %s", typeName(v))
+		}
+		i := int(math.Floor(k))
+		if i < 0 {
+			i += len(a)
+		}
+		if i < 0 || i >= len(a) {
+			return v, nil
+		}
+		return append(append([]interface{}{}, a[:i]...), a[i+1:]...), nil
+
+	case map[string]interface{}:
+		a, ok := v.([]interface{})
+		if !ok {
+			return nil, errorf("cannot delete a slice of %s", typeName(v))
+		}
+		start, end, err := sliceBounds(len(a), k)
+		if err != nil {
+			return nil, err
+		}
+		return append(append([]interface{}{}, a[:start]...), a[end:]...), nil
+	}
+	return nil, errorf("invalid path component %s", describe(key))
+}
+
+// sliceBounds resolves a {"start", "end"} path component against an array
+// of the given length, the same way .[start:end] does.
+func sliceBounds(length int, key map[string]interface{}) (int, int, error) {
+	bound := func(b interface{}, def int) (int, error) {
+		if b == nil {
+			return def, nil
+		}
+		f, ok := b.(float64)
+		if !ok {
+			return 0, errorf("slice bounds must be numbers")
+		}
+		i := int(math.Floor(f))
+		if i < 0 {
+			i += length
+		}
+		return min(max(i, 0), length), nil
+	}
+	start, err := bound(key["start"], 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := bound(key["end"], length)
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, max(start, end), nil
+}
+
+// evalAssign applies an assignment operator. "|=" replaces each value at
+// the left-hand paths with the update's output, or deletes it when the
+// update is empty. The others evaluate the right-hand side against the
+// original input and produce one result for each of its outputs.
+func evalAssign(n *assignNode, in interface{}, vars *scope) ([]interface{}, error) {
+	paths, err := pathsOf(n.target, in, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "|=" {
+		out := in
+		var deleted []interface{}
+		for _, p := range paths {
+			old, err := getPath(out, p.([]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			r, err := eval(n.value, old, vars)
+			if err != nil {
+				return nil, err
+			}
+			if len(r) == 0 {
+				deleted = append(deleted, p)
+				continue
+			}
+			if out, err = setPath(out, p.([]interface{}), r[0]); err != nil {
+				return nil, err
+			}
+		}
+		out, err = deletePaths(out, deleted)
+		return single(out, err)
+	}
+
+	return each(n.value, in, vars, func(x interface{}) ([]interface{}, error) {
+		out := in
+		for _, p := range paths {
+			update := x
+			if n.op != "=" {
+				old, err := getPath(out, p.([]interface{}))
+				if err != nil {
+					return nil, err
+				}
+				switch op := n.op[:len(n.op)-1]; op {
+				case "//":
+					if truthy(old) {
+						update = old
+					}
+				default:
+					if update, err = arithmetic(op, old, x); err != nil {
+						return nil, err
+					}
+				}
+			}
+			var err error
+			if out, err = setPath(out, p.([]interface{}), update); err != nil {
+				return nil, err
+			}
+		}
+		return []interface{}{out}, nil
+	})
+}
@@ -0,0 +1,161 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/charlietran/linctl/pkg/jq"
+)
+
+// Filter rewrites the data JSON would print, for --jq and --template.
+type Filter interface {
+	Apply(w io.Writer, data interface{}) error
+}
+
+var activeFilter Filter
+
+// SetFilter makes JSON print its data through f instead of as indented JSON.
+// A nil filter restores the default.
+func SetFilter(f Filter) {
+	activeFilter = f
+}
+
+type jqFilter struct {
+	query *jq.Query
+}
+
+// NewJQFilter returns a filter that evaluates a jq expression. Strings are
+// printed without quotes, like jq -r; other results as indented JSON.
+func NewJQFilter(expr string) (Filter, error) {
+	q, err := jq.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &jqFilter{query: q}, nil
+}
+
+func (f *jqFilter) Apply(w io.Writer, data interface{}) error {
+	input, err := normalize(data, false)
+	if err != nil {
+		return err
+	}
+	results, err := f.query.Run(input)
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		if s, ok := r.(string); ok {
+			fmt.Fprintln(w, s)
+			continue
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type templateFilter struct {
+	tmpl *template.Template
+}
+
+// templateFuncs are available to --template in addition to Go's builtins.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": func(sep string, items []interface{}) string {
+		parts := make([]string, len(items))
+		for i, item := range items {
+			parts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(parts, sep)
+	},
+	"pluck": func(field string, items []interface{}) []interface{} {
+		out := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			if m, ok := item.(map[string]interface{}); ok {
+				out = append(out, m[field])
+			}
+		}
+		return out
+	},
+	"truncate": func(length int, s string) string {
+		runes := []rune(s)
+		if len(runes) <= length {
+			return s
+		}
+		if length <= 3 {
+			return string(runes[:length])
+		}
+		return string(runes[:length-3]) + "..."
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// NewTemplateFilter returns a filter that executes a Go text/template.
+func NewTemplateFilter(text string) (Filter, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &templateFilter{tmpl: tmpl}, nil
+}
+
+func (f *templateFilter) Apply(w io.Writer, data interface{}) error {
+	input, err := normalize(data, true)
+	if err != nil {
+		return err
+	}
+	return f.tmpl.Execute(w, input)
+}
+
+// normalize converts data to the generic values it would decode to from the
+// JSON that JSON prints, so filters see exactly the same keys and shapes.
+// With useNumber, numbers keep their JSON text, which reads better in templates.
+func normalize(data interface{}, useNumber bool) (interface{}, error) {
+	raw, ok := data.(json.RawMessage)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(data); err != nil {
+			return nil, err
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if useNumber {
+		dec.UseNumber()
+	}
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// RawJSON prints an already encoded JSON document, indented, or through the
+// active filter or formatter.
+func RawJSON(data []byte) {
+	if _, ok := activeFormatter.(jsonFormatter); activeFilter != nil || !ok {
+		JSON(json.RawMessage(data))
+		return
+	}
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, data, "", "  "); err != nil {
+		fmt.Println(string(data))
+		return
+	}
+	fmt.Println(pretty.String())
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+type sampleIssue struct {
+	Identifier string  `json:"identifier"`
+	Title      string  `json:"title"`
+	Estimate   float64 `json:"estimate"`
+}
+
+var sampleIssues = []sampleIssue{
+	{Identifier: "ENG-1", Title: "Fix <login>", Estimate: 2},
+	{Identifier: "ENG-2", Title: "Ship it", Estimate: 1000000},
+}
+
+func TestJQFilterUsesJSONFieldNames(t *testing.T) {
+	f, err := NewJQFilter(`.[] | select(.estimate > 1) | .identifier, {title}`)
+	if err != nil {
+		t.Fatalf("NewJQFilter failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := f.Apply(&buf, sampleIssues); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	want := "ENG-1\n{\n  \"title\": \"Fix <login>\"\n}\nENG-2\n{\n  \"title\": \"Ship it\"\n}\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestTemplateFilter(t *testing.T) {
+	f, err := NewTemplateFilter(`{{range .}}{{.identifier}} {{.estimate}} {{truncate 5 .title | upper}}{{"\n"}}{{end}}{{pluck "identifier" . | join ","}}`)
+	if err != nil {
+		t.Fatalf("NewTemplateFilter failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := f.Apply(&buf, sampleIssues); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	want := "ENG-1 2 FI...\nENG-2 1000000 SH...\nENG-1,ENG-2"
+	if buf.String() != want {
+		t.Errorf("Unexpected output:\n%q", buf.String())
+	}
+}
+
+func TestFiltersAcceptRawJSON(t *testing.T) {
+	f, err := NewJQFilter(`.viewer.name`)
+	if err != nil {
+		t.Fatalf("NewJQFilter failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := f.Apply(&buf, json.RawMessage(`{"viewer": {"name": "Ada"}}`)); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if buf.String() != "Ada\n" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+}
+
+func TestNewFilterErrors(t *testing.T) {
+	if _, err := NewJQFilter(`.[`); err == nil {
+		t.Error("Expected jq syntax error")
+	}
+	if _, err := NewTemplateFilter(`{{.x`); err == nil {
+		t.Error("Expected template syntax error")
+	}
+}
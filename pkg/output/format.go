@@ -0,0 +1,379 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --format. Table and plain are rendered by each
+// command; the others are produced by a Formatter from the data --json prints.
+const (
+	FormatTable  = "table"
+	FormatPlain  = "plain"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatYAML   = "yaml"
+	FormatCSV    = "csv"
+	FormatTSV    = "tsv"
+)
+
+// Formats lists every --format value.
+var Formats = []string{FormatTable, FormatPlain, FormatJSON, FormatNDJSON, FormatYAML, FormatCSV, FormatTSV}
+
+// Formatter writes the data a command would print as JSON in another format.
+type Formatter interface {
+	Format(w io.Writer, data interface{}) error
+}
+
+// NewFormatter returns the formatter for a data format: json, ndjson, yaml, csv or tsv.
+func NewFormatter(format string) (Formatter, error) {
+	switch format {
+	case FormatJSON:
+		return jsonFormatter{}, nil
+	case FormatNDJSON:
+		return ndjsonFormatter{}, nil
+	case FormatYAML:
+		return yamlFormatter{}, nil
+	case FormatCSV:
+		return &delimitedFormatter{comma: ','}, nil
+	case FormatTSV:
+		return &delimitedFormatter{comma: '\t'}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q; valid formats are: %s", format, strings.Join(Formats, ", "))
+}
+
+var activeFormatter Formatter = jsonFormatter{}
+
+// SetFormatter changes how JSON prints data. A nil formatter restores indented JSON.
+func SetFormatter(f Formatter) {
+	if f == nil {
+		f = jsonFormatter{}
+	}
+	activeFormatter = f
+}
+
+// tabular reports whether the active format is CSV or TSV, where messages
+// cannot be mixed into the output as records.
+func tabular() bool {
+	_, ok := activeFormatter.(*delimitedFormatter)
+	return ok
+}
+
+type jsonFormatter struct{}
+
+func (jsonFormatter) Format(w io.Writer, data interface{}) error {
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(jsonData))
+	return err
+}
+
+// ndjsonFormatter writes one compact JSON document per line: one per element
+// for arrays, or a single line for anything else.
+type ndjsonFormatter struct{}
+
+func (ndjsonFormatter) Format(w io.Writer, data interface{}) error {
+	raw, err := marshal(data)
+	if err != nil {
+		return err
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		items = []json.RawMessage{raw}
+	}
+	for _, item := range items {
+		var line bytes.Buffer
+		if err := json.Compact(&line, item); err != nil {
+			return err
+		}
+		line.WriteByte('\n')
+		if _, err := w.Write(line.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// yamlFormatter writes YAML, keeping object keys in the order JSON prints them.
+type yamlFormatter struct{}
+
+func (yamlFormatter) Format(w io.Writer, data interface{}) error {
+	raw, err := marshal(data)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	node, err := yamlNode(dec)
+	if err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// yamlNode converts the next JSON value from dec into a YAML node.
+func yamlNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if t == '{' {
+			node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		for dec.More() {
+			if t == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			child, err := yamlNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t}, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(t.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: t.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(t)}, nil
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+}
+
+// delimitedFormatter writes CSV or TSV with a header row. Each element of an
+// array becomes a row and each key a column, in the order they first appear.
+// Nested objects are shown by their most recognizable field (see DisplayValue).
+type delimitedFormatter struct {
+	comma rune
+}
+
+func (f *delimitedFormatter) Format(w io.Writer, data interface{}) error {
+	if table, ok := data.(TableData); ok {
+		return f.write(w, table.Headers, table.Rows)
+	}
+
+	raw, err := marshal(data)
+	if err != nil {
+		return err
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		items = []json.RawMessage{raw}
+	}
+
+	var columns []string
+	seen := make(map[string]bool)
+	var records []map[string]interface{}
+	scalars := false
+	for _, item := range items {
+		keys, err := objectKeys(item)
+		if err != nil {
+			scalars = true
+			break
+		}
+		for _, k := range keys {
+			if !seen[k] {
+				seen[k] = true
+				columns = append(columns, k)
+			}
+		}
+		var record map[string]interface{}
+		if err := decodeNumbers(item, &record); err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+	if scalars {
+		rows := make([][]string, len(items))
+		for i, item := range items {
+			var v interface{}
+			if err := decodeNumbers(item, &v); err != nil {
+				return err
+			}
+			rows[i] = []string{DisplayValue(v)}
+		}
+		return f.write(w, []string{"value"}, rows)
+	}
+
+	rows := make([][]string, len(records))
+	for i, record := range records {
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j] = DisplayValue(record[column])
+		}
+		rows[i] = row
+	}
+	return f.write(w, columns, rows)
+}
+
+func (f *delimitedFormatter) write(w io.Writer, headers []string, rows [][]string) error {
+	if f.comma == '\t' {
+		// TSV has no quoting; tabs and newlines inside values are escaped instead.
+		escape := strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+		lines := append([][]string{headers}, rows...)
+		for _, line := range lines {
+			cells := make([]string, len(line))
+			for i, cell := range line {
+				cells[i] = escape.Replace(cell)
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(cells, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = f.comma
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// DisplayValue renders a JSON value as a single line of text: objects by their
+// most recognizable field (identifier, key, name, email, number or id),
+// connections and arrays as a comma-separated list.
+func DisplayValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case json.Number:
+		return v.String()
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return fmt.Sprintf("%d", int64(v))
+		}
+		return fmt.Sprintf("%g", v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, DisplayValue(item))
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		if nodes, ok := v["nodes"]; ok {
+			return DisplayValue(nodes)
+		}
+		for _, key := range []string{"identifier", "key", "name", "email", "number", "id"} {
+			if inner, ok := v[key]; ok && inner != nil {
+				return DisplayValue(inner)
+			}
+		}
+		if len(v) == 0 {
+			return ""
+		}
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	}
+	return fmt.Sprint(value)
+}
+
+// Record is an object whose keys are printed in a fixed order, such as the
+// order fields were requested with --fields.
+type Record struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+// MarshalJSON writes the keys in order.
+func (r Record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range r.Keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(r.Values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func marshal(data interface{}) (json.RawMessage, error) {
+	if raw, ok := data.(json.RawMessage); ok {
+		return raw, nil
+	}
+	return json.Marshal(data)
+}
+
+func decodeNumbers(raw json.RawMessage, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// objectKeys returns the keys of a JSON object in document order.
+func objectKeys(raw json.RawMessage) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, fmt.Errorf("not an object")
+	}
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, tok.(string))
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,114 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+var formatIssues = []interface{}{
+	map[string]interface{}{
+		"identifier": "ENG-1",
+		"title":      "Fix, \"quoted\" login",
+		"state":      map[string]interface{}{"id": "s1", "name": "In Progress"},
+		"labels": map[string]interface{}{"nodes": []interface{}{
+			map[string]interface{}{"name": "bug"},
+			map[string]interface{}{"name": "auth"},
+		}},
+	},
+	map[string]interface{}{
+		"identifier": "ENG-2",
+		"title":      "Tabs\there",
+		"assignee":   map[string]interface{}{"name": "Ada"},
+	},
+}
+
+func format(t *testing.T, name string, data interface{}) string {
+	t.Helper()
+	f, err := NewFormatter(name)
+	if err != nil {
+		t.Fatalf("NewFormatter(%q) failed: %v", name, err)
+	}
+	var buf bytes.Buffer
+	if err := f.Format(&buf, data); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	return buf.String()
+}
+
+func TestCSVFormatter(t *testing.T) {
+	got := format(t, FormatCSV, formatIssues)
+	want := "identifier,labels,state,title,assignee\n" +
+		"ENG-1,\"bug, auth\",In Progress,\"Fix, \"\"quoted\"\" login\",\n" +
+		"ENG-2,,,Tabs\there,Ada\n"
+	if got != want {
+		t.Errorf("Unexpected CSV:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTSVFormatterEscapesTabs(t *testing.T) {
+	got := format(t, FormatTSV, TableData{
+		Headers: []string{"ID", "Title"},
+		Rows:    [][]string{{"ENG-2", "Tabs\there\nand lines"}},
+	})
+	want := "ID\tTitle\nENG-2\tTabs\\there\\nand lines\n"
+	if got != want {
+		t.Errorf("Unexpected TSV:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestNDJSONFormatter(t *testing.T) {
+	got := format(t, FormatNDJSON, []Record{
+		{Keys: []string{"title", "identifier"}, Values: map[string]interface{}{"identifier": "ENG-1", "title": "A"}},
+		{Keys: []string{"title", "identifier"}, Values: map[string]interface{}{"identifier": "ENG-2", "title": "B"}},
+	})
+	want := "{\"title\":\"A\",\"identifier\":\"ENG-1\"}\n{\"title\":\"B\",\"identifier\":\"ENG-2\"}\n"
+	if got != want {
+		t.Errorf("Unexpected NDJSON:\n%s", got)
+	}
+
+	if got := format(t, FormatNDJSON, map[string]interface{}{"a": 1}); got != "{\"a\":1}\n" {
+		t.Errorf("Unexpected NDJSON for an object: %q", got)
+	}
+}
+
+func TestYAMLFormatterKeepsKeyOrder(t *testing.T) {
+	got := format(t, FormatYAML, Record{
+		Keys:   []string{"title", "estimate", "state", "labels"},
+		Values: map[string]interface{}{"title": "A: b", "estimate": 2, "state": map[string]interface{}{"name": "Todo"}, "labels": []string{"bug"}},
+	})
+	want := "title: 'A: b'\nestimate: 2\nstate:\n  name: Todo\nlabels:\n  - bug\n"
+	if got != want {
+		t.Errorf("Unexpected YAML:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestNewFormatterRejectsUnknown(t *testing.T) {
+	if _, err := NewFormatter("xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestDisplayValue(t *testing.T) {
+	cases := []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, ""},
+		{"In Progress", "In Progress"},
+		{float64(2), "2"},
+		{1.5, "1.5"},
+		{true, "true"},
+		{map[string]interface{}{"id": "s1", "name": "Todo", "type": "unstarted"}, "Todo"},
+		{map[string]interface{}{"id": "t1", "key": "ENG", "name": "Engineering"}, "ENG"},
+		{map[string]interface{}{"id": "i1", "identifier": "ENG-1", "title": "Parent"}, "ENG-1"},
+		{map[string]interface{}{"nodes": []interface{}{
+			map[string]interface{}{"name": "bug"},
+			map[string]interface{}{"name": "ui"},
+		}}, "bug, ui"},
+	}
+	for _, tc := range cases {
+		if got := DisplayValue(tc.value); got != tc.want {
+			t.Errorf("DisplayValue(%v) = %q, want %q", tc.value, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,470 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+)
+
+// MarkdownRenderer renders the markdown Linear stores for descriptions and
+// comments with terminal styling: headings, emphasis, code, links, quotes,
+// lists and checkboxes. Paragraphs and list items are wrapped to Width.
+type MarkdownRenderer struct {
+	// Width is the column to wrap at; 0 disables wrapping.
+	Width int
+}
+
+// Markdown renders text for the terminal, wrapped to its width.
+func Markdown(text string) string {
+	r := &MarkdownRenderer{Width: TerminalWidth()}
+	return r.Render(text)
+}
+
+var (
+	headingPattern  = regexp.MustCompile(`^ {0,3}(#{1,6})\s+(.*?)(\s+#+)?\s*$`)
+	rulePattern     = regexp.MustCompile(`^ {0,3}([-*_])(\s*([-*_])){2,}\s*$`)
+	fencePattern    = regexp.MustCompile("^(\\s*)(```+|~~~+)")
+	quotePattern    = regexp.MustCompile(`^ {0,3}>\s?(.*)$`)
+	listPattern     = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	checkboxPattern = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+)
+
+// Render returns the styled text, without a trailing newline.
+func (r *MarkdownRenderer) Render(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+
+	var out []string
+	blank := func() {
+		if len(out) > 0 && out[len(out)-1] != "" {
+			out = append(out, "")
+		}
+	}
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			out = append(out, r.wrap(parseInline(strings.Join(paragraph, " "), 0), "", "")...)
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if m := fencePattern.FindStringSubmatch(line); m != nil {
+			flush()
+			fence := m[2]
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+					break
+				}
+				code = append(code, strings.TrimPrefix(lines[i], m[1]))
+			}
+			for _, c := range code {
+				out = append(out, "  "+Yellow.Sprint(expandTabs(c)))
+			}
+			blank()
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flush()
+			blank()
+		case headingPattern.MatchString(line):
+			flush()
+			m := headingPattern.FindStringSubmatch(line)
+			style := styleBold | styleHeading
+			if len(m[1]) == 1 {
+				style |= styleUnderline
+			}
+			out = append(out, r.wrap(parseInline(m[2], style), "", "")...)
+		case rulePattern.MatchString(line):
+			flush()
+			width := r.Width
+			if width <= 0 || width > 80 {
+				width = 80
+			}
+			out = append(out, Faint.Sprint(Rule(width)))
+		case quotePattern.MatchString(line):
+			flush()
+			var quoted []string
+			for ; i < len(lines); i++ {
+				m := quotePattern.FindStringSubmatch(lines[i])
+				if m == nil {
+					break
+				}
+				quoted = append(quoted, m[1])
+			}
+			i--
+			inner := &MarkdownRenderer{Width: r.Width - 2}
+			if r.Width <= 0 {
+				inner.Width = 0
+			}
+			bar := Faint.Sprint(IconBar.String() + " ")
+			for _, q := range strings.Split(inner.Render(strings.Join(quoted, "\n")), "\n") {
+				out = append(out, bar+q)
+			}
+		case listPattern.MatchString(line):
+			flush()
+			m := listPattern.FindStringSubmatch(line)
+			item := []string{m[3]}
+			// Lazy continuation lines belong to the item.
+			for i+1 < len(lines) {
+				next := lines[i+1]
+				if strings.TrimSpace(next) == "" || listPattern.MatchString(next) || startsBlock(next) {
+					break
+				}
+				item = append(item, strings.TrimSpace(next))
+				i++
+			}
+			out = append(out, r.listItem(m[1], m[2], strings.Join(item, " "))...)
+		case strings.HasPrefix(trimmed, "|"):
+			// Tables are kept as written; wrapping would break their columns.
+			flush()
+			out = append(out, styleRuns(parseInline(trimmed, 0)))
+		default:
+			if strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\") {
+				// A hard line break ends the line here.
+				paragraph = append(paragraph, strings.TrimSuffix(trimmed, "\\"))
+				flush()
+				continue
+			}
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n")
+}
+
+// listItem renders one bullet, numbered item or checkbox, with continuation
+// lines indented under the text.
+func (r *MarkdownRenderer) listItem(indent, marker, text string) []string {
+	level := len(expandTabs(indent)) / 2
+	pad := strings.Repeat("  ", level)
+
+	var bullet string
+	style := styleFlags(0)
+	switch {
+	case checkboxPattern.MatchString(text):
+		m := checkboxPattern.FindStringSubmatch(text)
+		text = m[2]
+		if m[1] == " " {
+			bullet = IconUnchecked.String()
+		} else {
+			bullet = Green.Sprint(IconChecked)
+			style = styleFaint
+		}
+	case marker == "-" || marker == "*" || marker == "+":
+		bullet = []Icon{IconBullet, IconSubBullet, IconSquare}[min(level, 2)].String()
+	default:
+		bullet = marker
+	}
+
+	first := pad + bullet + " "
+	rest := strings.Repeat(" ", visibleWidth(first))
+	return r.wrap(parseInline(text, style), first, rest)
+}
+
+// startsBlock reports whether a line begins a heading, quote, code block,
+// rule or table rather than continuing a list item.
+func startsBlock(line string) bool {
+	return headingPattern.MatchString(line) || quotePattern.MatchString(line) ||
+		fencePattern.MatchString(line) || rulePattern.MatchString(line) ||
+		strings.HasPrefix(strings.TrimSpace(line), "|")
+}
+
+// wrap lays runs out as lines no wider than r.Width. The first line starts
+// with first and the others with rest, which must have the same width.
+func (r *MarkdownRenderer) wrap(runs []run, first, rest string) []string {
+	words := splitWords(runs)
+	if len(words) == 0 {
+		return []string{strings.TrimRight(first, " ")}
+	}
+
+	var lines []string
+	var line strings.Builder
+	line.WriteString(first)
+	width := visibleWidth(first)
+	start := width
+	for _, w := range words {
+		ww := w.width()
+		if width > start && r.Width > 0 && width+1+ww > r.Width {
+			lines = append(lines, line.String())
+			line.Reset()
+			line.WriteString(rest)
+			width = visibleWidth(rest)
+		} else if width > start {
+			line.WriteByte(' ')
+			width++
+		}
+		line.WriteString(styleRuns(w))
+		width += ww
+	}
+	return append(lines, line.String())
+}
+
+type styleFlags int
+
+const (
+	styleBold styleFlags = 1 << iota
+	styleItalic
+	styleUnderline
+	styleStrike
+	styleFaint
+	styleCode
+	styleLink
+	styleHeading
+)
+
+// run is a piece of text with a single style.
+type run struct {
+	text  string
+	style styleFlags
+}
+
+func (r run) styled() string {
+	var attrs Style
+	if r.style&styleHeading != 0 {
+		attrs = attrs.With(color.FgCyan)
+	}
+	if r.style&styleCode != 0 {
+		attrs = attrs.With(Yellow...)
+	}
+	if r.style&styleLink != 0 {
+		attrs = attrs.With(Link...)
+	}
+	if r.style&styleBold != 0 {
+		attrs = attrs.With(color.Bold)
+	}
+	if r.style&styleItalic != 0 {
+		attrs = attrs.With(color.Italic)
+	}
+	if r.style&styleUnderline != 0 {
+		attrs = attrs.With(color.Underline)
+	}
+	if r.style&styleStrike != 0 {
+		attrs = attrs.With(color.CrossedOut)
+	}
+	if r.style&styleFaint != 0 {
+		attrs = attrs.With(color.Faint)
+	}
+	if len(attrs) == 0 {
+		return r.text
+	}
+	return attrs.Sprint(r.text)
+}
+
+func styleRuns(runs []run) string {
+	var b strings.Builder
+	for _, r := range runs {
+		b.WriteString(r.styled())
+	}
+	return b.String()
+}
+
+// word is a sequence of runs with no space between them, such as "**bold**,".
+type word []run
+
+func (w word) width() int {
+	n := 0
+	for _, r := range w {
+		n += runewidth.StringWidth(r.text)
+	}
+	return n
+}
+
+// splitWords breaks runs at whitespace, keeping each piece's style.
+func splitWords(runs []run) []word {
+	var words []word
+	var current word
+	for _, r := range runs {
+		var b strings.Builder
+		for _, c := range r.text {
+			if unicode.IsSpace(c) {
+				if b.Len() > 0 {
+					current = append(current, run{text: b.String(), style: r.style})
+					b.Reset()
+				}
+				if len(current) > 0 {
+					words = append(words, current)
+					current = nil
+				}
+				continue
+			}
+			b.WriteRune(c)
+		}
+		if b.Len() > 0 {
+			current = append(current, run{text: b.String(), style: r.style})
+		}
+	}
+	if len(current) > 0 {
+		words = append(words, current)
+	}
+	return words
+}
+
+// parseInline splits text into styled runs for emphasis, strikethrough,
+// inline code, links, images and autolinks. Unmatched markers are kept as
+// literal text.
+func parseInline(text string, base styleFlags) []run {
+	var runs []run
+	var plain strings.Builder
+	emit := func(r ...run) {
+		if plain.Len() > 0 {
+			runs = append(runs, run{text: plain.String(), style: base})
+			plain.Reset()
+		}
+		runs = append(runs, r...)
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		rest := text[i:]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.ContainsRune("\\`*_{}[]()#+-.!~|<>", rune(text[i+1])):
+			plain.WriteByte(text[i+1])
+			i += 2
+			continue
+		case c == '`':
+			ticks := len(rest) - len(strings.TrimLeft(rest, "`"))
+			if end := strings.Index(rest[ticks:], rest[:ticks]); end >= 0 {
+				emit(run{text: strings.TrimSpace(rest[ticks : ticks+end]), style: base | styleCode})
+				i += 2*ticks + end
+				continue
+			}
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if end := closing(rest, rest[:2]); end > 0 {
+				emit(parseInline(rest[2:end], base|styleBold)...)
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(rest, "~~"):
+			if end := closing(rest, "~~"); end > 0 {
+				emit(parseInline(rest[2:end], base|styleStrike)...)
+				i += end + 2
+				continue
+			}
+		case c == '*' || c == '_':
+			if c == '_' && i > 0 && isWordByte(text[i-1]) {
+				break
+			}
+			if end := closing(rest, rest[:1]); end > 0 && (c == '*' || end+1 >= len(rest) || !isWordByte(rest[end+1])) {
+				emit(parseInline(rest[1:end], base|styleItalic)...)
+				i += end + 1
+				continue
+			}
+		case c == '[' || strings.HasPrefix(rest, "!["):
+			image := c == '!'
+			label := rest
+			if image {
+				label = rest[1:]
+			}
+			if closeLabel := matchingBracket(label); closeLabel > 0 && strings.HasPrefix(label[closeLabel+1:], "(") {
+				if closeURL := strings.IndexByte(label[closeLabel+1:], ')'); closeURL > 0 {
+					name := label[1:closeLabel]
+					target := strings.TrimSpace(label[closeLabel+2 : closeLabel+1+closeURL])
+					if space := strings.IndexByte(target, ' '); space > 0 {
+						target = target[:space] // drop a "title"
+					}
+					if image && name == "" {
+						name = "image"
+					}
+					linkRuns := parseInline(name, base|styleLink)
+					if image {
+						if icon := IconImage.String(); icon != "" {
+							linkRuns = append([]run{{text: icon + " ", style: base}}, linkRuns...)
+						}
+					}
+					if target != "" && target != name {
+						linkRuns = append(linkRuns, run{text: " (" + target + ")", style: base | styleFaint})
+					}
+					emit(linkRuns...)
+					i += len(rest) - len(label) + closeLabel + 2 + closeURL
+					continue
+				}
+			}
+		case c == '<':
+			if end := strings.IndexByte(rest, '>'); end > 0 {
+				target := rest[1:end]
+				if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "mailto:") {
+					emit(run{text: target, style: base | styleLink})
+					i += end + 1
+					continue
+				}
+			}
+		}
+		_, size := utf8.DecodeRuneInString(rest)
+		plain.WriteString(rest[:size])
+		i += size
+	}
+	emit()
+	return runs
+}
+
+// closing returns the index in s of the marker that closes the one s starts
+// with, or -1. The emphasized text may not start or end with a space.
+func closing(s, marker string) int {
+	n := len(marker)
+	if len(s) <= n || s[n] == ' ' {
+		return -1
+	}
+	for i := n + 1; i+n <= len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == '`' {
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				i += end + 1
+				continue
+			}
+		}
+		if strings.HasPrefix(s[i:], marker) && s[i-1] != ' ' {
+			// "**" must not match the first half of a longer run like "***".
+			if n == 1 && i+1 < len(s) && s[i+1] == marker[0] {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// matchingBracket returns the index of the "]" closing the "[" s starts with.
+func matchingBracket(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= 0x80 || unicode.IsLetter(rune(b)) || unicode.IsDigit(rune(b))
+}
+
+func expandTabs(s string) string {
+	return strings.ReplaceAll(s, "\t", "    ")
+}
@@ -0,0 +1,94 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestMarkdownRender(t *testing.T) {
+	color.NoColor = true
+
+	src := strings.Join([]string{
+		"# Login *fails*",
+		"",
+		"Users on **mobile** cannot log in after the `v2` release. See [the spec](https://example.com/spec) for details.",
+		"",
+		"- [ ] Reproduce on iOS",
+		"- [x] Check the logs",
+		"  - nested item",
+		"1. First",
+		"2. Second line that",
+		"continues here",
+		"",
+		"> quoted *text*",
+		"",
+		"```go",
+		"func main() {",
+		"\tfmt.Println(\"hi\")",
+		"}",
+		"```",
+		"",
+		"---",
+		"snake_case_name and \\*literal\\* and <https://linear.app>",
+	}, "\n")
+
+	got := (&MarkdownRenderer{Width: 40}).Render(src)
+	want := strings.Join([]string{
+		"Login fails",
+		"",
+		"Users on mobile cannot log in after the",
+		"v2 release. See the spec",
+		"(https://example.com/spec) for details.",
+		"",
+		"☐ Reproduce on iOS",
+		"☑ Check the logs",
+		"  ◦ nested item",
+		"1. First",
+		"2. Second line that continues here",
+		"",
+		"│ quoted text",
+		"",
+		"  func main() {",
+		"      fmt.Println(\"hi\")",
+		"  }",
+		"",
+		strings.Repeat("─", 40),
+		"snake_case_name and *literal* and",
+		"https://linear.app",
+	}, "\n")
+	if got != want {
+		t.Errorf("Unexpected rendering:\n%s\n\nwant:\n%s", got, want)
+	}
+}
+
+func TestMarkdownWrapsListItemsWithHangingIndent(t *testing.T) {
+	color.NoColor = true
+
+	got := (&MarkdownRenderer{Width: 20}).Render("- one two three four five six")
+	want := "• one two three four\n  five six"
+	if got != want {
+		t.Errorf("Unexpected rendering:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestMarkdownNoWrap(t *testing.T) {
+	color.NoColor = true
+
+	text := strings.Repeat("word ", 40)
+	got := (&MarkdownRenderer{}).Render(text)
+	if strings.Contains(got, "\n") {
+		t.Errorf("Expected a single line without a width, got %q", got)
+	}
+}
+
+func TestMarkdownStyles(t *testing.T) {
+	color.NoColor = false
+	defer func() { color.NoColor = true }()
+
+	got := (&MarkdownRenderer{}).Render("**bold** and `code`")
+	if !strings.Contains(got, "\x1b[1mbold") || !strings.Contains(got, "\x1b[33mcode") {
+		t.Errorf("Expected bold and code styling, got %q", got)
+	}
+}
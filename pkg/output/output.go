@@ -1,12 +1,10 @@
 package output
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 )
 
@@ -14,47 +12,77 @@ import (
 type TableData struct {
 	Headers []string
 	Rows    [][]string
+	// Shrink names the column that is truncated so the table fits the
+	// terminal width. When empty, the table is never shrunk.
+	Shrink string
 }
 
-// JSON outputs data as JSON
+// JSON outputs data as JSON, in the format set with SetFormatter, or through
+// the filter set with SetFilter
 func JSON(data interface{}) {
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+	var err error
+	if activeFilter != nil {
+		err = activeFilter.Apply(os.Stdout, data)
+	} else {
+		err = activeFormatter.Format(os.Stdout, data)
+	}
 	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		Exit(1)
+	}
+}
+
+// printMessage outputs a status message in the active format, ignoring any
+// filter so an error is never fed to a --jq expression written for the data.
+// CSV and TSV have no place for messages, so they are printed as text instead.
+func printMessage(message map[string]interface{}, text string, stderr bool) {
+	if tabular() {
+		if stderr {
+			fmt.Fprintln(os.Stderr, text)
+		} else {
+			fmt.Println(text)
+		}
+		return
+	}
+	if err := activeFormatter.Format(os.Stdout, message); err != nil {
 		fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
-		os.Exit(1)
+		Exit(1)
 	}
-	fmt.Println(string(jsonData))
 }
 
 // Error outputs an error message
 func Error(message string, plaintext, jsonOut bool) {
 	if jsonOut {
-		JSON(map[string]interface{}{
+		printMessage(map[string]interface{}{
 			"error": message,
-		})
+		}, "Error: "+message, true)
 	} else if plaintext {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", message)
 	} else {
-		fmt.Fprintf(os.Stderr, "%s %s\n", color.New(color.FgRed).Sprint("❌"), message)
+		fmt.Fprintf(os.Stderr, "%s %s\n", Red.Sprint(IconError), message)
 	}
 }
 
 // Success outputs a success message
 func Success(message string, plaintext, jsonOut bool) {
 	if jsonOut {
-		JSON(map[string]interface{}{
+		printMessage(map[string]interface{}{
 			"status":  "success",
 			"message": message,
-		})
+		}, message, false)
 	} else if plaintext {
 		fmt.Println(message)
 	} else {
-		fmt.Printf("%s %s\n", color.New(color.FgGreen).Sprint("✅"), message)
+		fmt.Printf("%s %s\n", Green.Sprint(IconSuccess), message)
 	}
 }
 
 // Table outputs data in table format
 func Table(data TableData, plaintext, jsonOut bool) {
+	if jsonOut && tabular() && activeFilter == nil {
+		JSON(data)
+		return
+	}
 	if jsonOut {
 		// Convert table data to JSON
 		jsonData := make([]map[string]interface{}, len(data.Rows))
@@ -83,6 +111,7 @@ func Table(data TableData, plaintext, jsonOut bool) {
 	}
 
 	// Rich table output
+	data = fitWidth(data, TerminalWidth())
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetHeader(data.Headers)
 	table.SetAutoWrapText(false)
@@ -100,7 +129,7 @@ func Table(data TableData, plaintext, jsonOut bool) {
 	// Add color to headers
 	coloredHeaders := make([]string, len(data.Headers))
 	for i, header := range data.Headers {
-		coloredHeaders[i] = color.New(color.FgCyan, color.Bold).Sprint(header)
+		coloredHeaders[i] = CyanBold.Sprint(header)
 	}
 	table.SetHeader(coloredHeaders)
 
@@ -113,12 +142,66 @@ func Table(data TableData, plaintext, jsonOut bool) {
 // Info outputs an informational message
 func Info(message string, plaintext, jsonOut bool) {
 	if jsonOut {
-		JSON(map[string]interface{}{
+		printMessage(map[string]interface{}{
 			"info": message,
-		})
+		}, message, true)
 	} else if plaintext {
 		fmt.Println(message)
 	} else {
-		fmt.Printf("%s %s\n", color.New(color.FgBlue).Sprint("ℹ️"), message)
+		fmt.Printf("%s %s\n", Blue.Sprint(IconInfo), message)
+	}
+}
+
+// tablePadding is the space tablewriter puts between columns.
+const tablePadding = 3
+
+// minShrinkWidth keeps a shrunk column readable on very narrow terminals.
+const minShrinkWidth = 10
+
+// fitWidth truncates the cells of the Shrink column so the rendered table is
+// no wider than width. A width of 0 leaves the table unchanged.
+func fitWidth(data TableData, width int) TableData {
+	shrink := -1
+	for i, header := range data.Headers {
+		if header == data.Shrink {
+			shrink = i
+		}
+	}
+	if width <= 0 || shrink < 0 {
+		return data
+	}
+
+	widths := make([]int, len(data.Headers))
+	for i, header := range data.Headers {
+		widths[i] = visibleWidth(header)
+	}
+	for _, row := range data.Rows {
+		for i, cell := range row {
+			if i < len(widths) && visibleWidth(cell) > widths[i] {
+				widths[i] = visibleWidth(cell)
+			}
+		}
+	}
+	// Every column, including the last, is followed by the padding.
+	total := tablePadding * len(widths)
+	for _, w := range widths {
+		total += w
+	}
+	if total <= width {
+		return data
+	}
+
+	limit := widths[shrink] - (total - width)
+	if limit < minShrinkWidth {
+		limit = minShrinkWidth
+	}
+	rows := make([][]string, len(data.Rows))
+	for i, row := range data.Rows {
+		rows[i] = append([]string(nil), row...)
+		if shrink < len(row) {
+			rows[i][shrink] = Truncate(row[shrink], limit)
+		}
 	}
+	data.Rows = rows
+	return data
 }
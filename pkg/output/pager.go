@@ -0,0 +1,82 @@
+package output
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+var (
+	pagerCmd  *exec.Cmd
+	pagerPipe *os.File
+	ttyStdout *os.File
+
+	// osExit is replaced in tests.
+	osExit = os.Exit
+)
+
+// StartPager sends everything printed to stdout from now on through the
+// pager command, such as "less -R", until StopPager is called. It does
+// nothing when stdout is not a terminal or command is empty or "cat".
+// less is started with LESS=FRX unless $LESS is set, so colors are kept and
+// output that fits on one screen is printed without waiting for a keypress.
+func StartPager(command string) error {
+	args := strings.Fields(command)
+	if len(args) == 0 || args[0] == "cat" || pagerCmd != nil || !IsTerminal() {
+		return nil
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = r
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+	if _, ok := os.LookupEnv("LV"); !ok {
+		cmd.Env = append(cmd.Env, "LV=-c")
+	}
+	if err := cmd.Start(); err != nil {
+		r.Close()
+		w.Close()
+		return err
+	}
+	r.Close()
+
+	pagerCmd = cmd
+	pagerPipe = w
+	ttyStdout = os.Stdout
+	os.Stdout = w
+	return nil
+}
+
+// StopPager restores stdout and waits for the user to quit the pager.
+func StopPager() {
+	if pagerCmd == nil {
+		return
+	}
+	pagerPipe.Close()
+	os.Stdout = ttyStdout
+	_ = pagerCmd.Wait()
+	pagerCmd, pagerPipe, ttyStdout = nil, nil, nil
+}
+
+// Exit stops the pager, so output already sent to it is shown, then exits
+// with code. Use it instead of os.Exit once the pager may have started.
+func Exit(code int) {
+	StopPager()
+	osExit(code)
+}
+
+// stdoutFile returns the file stdout writes to, looking through the pager.
+func stdoutFile() *os.File {
+	if ttyStdout != nil {
+		return ttyStdout
+	}
+	return os.Stdout
+}
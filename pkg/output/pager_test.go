@@ -0,0 +1,66 @@
+package output
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+type failingFormatter struct{}
+
+func (failingFormatter) Format(io.Writer, interface{}) error {
+	return errors.New("boom")
+}
+
+func TestJSONErrorStopsPagerBeforeExit(t *testing.T) {
+	// Stand in for a pager: cat copies its input to a file we can check.
+	paged := filepath.Join(t.TempDir(), "paged")
+	out, err := os.Create(paged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("cat")
+	cmd.Stdin = r
+	cmd.Stdout = out
+	if err := cmd.Start(); err != nil {
+		t.Skipf("cat not available: %v", err)
+	}
+	r.Close()
+	pagerCmd, pagerPipe, ttyStdout = cmd, w, os.Stdout
+	os.Stdout = w
+
+	exitCode := -1
+	osExit = func(code int) {
+		exitCode = code
+		if pagerCmd != nil {
+			t.Error("Expected the pager to be stopped before exiting")
+		}
+	}
+	defer func() { osExit = os.Exit }()
+
+	formatter := activeFormatter
+	activeFormatter = failingFormatter{}
+	defer func() { activeFormatter = formatter }()
+
+	_, _ = w.WriteString("partial output\n")
+	JSON(map[string]string{"id": "ENG-1"})
+
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if os.Stdout == w {
+		t.Error("Expected stdout to be restored")
+	}
+	got, _ := os.ReadFile(paged)
+	if string(got) != "partial output\n" {
+		t.Errorf("Expected the pager to receive the output before exit, got %q", got)
+	}
+}
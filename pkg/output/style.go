@@ -0,0 +1,160 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Color modes accepted by --color.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// SetColorMode turns terminal colors on or off. In auto mode colors are used
+// only when stdout is a terminal, $NO_COLOR is unset and $TERM is not "dumb".
+func SetColorMode(mode string) error {
+	switch strings.ToLower(mode) {
+	case "", ColorAuto:
+		_, noColor := os.LookupEnv("NO_COLOR")
+		color.NoColor = noColor || os.Getenv("TERM") == "dumb" || !IsTerminal()
+	case ColorAlways:
+		color.NoColor = false
+	case ColorNever:
+		color.NoColor = true
+	default:
+		return fmt.Errorf("invalid --color %q; use auto, always or never", mode)
+	}
+	return nil
+}
+
+// ColorEnabled reports whether styles produce escape codes.
+func ColorEnabled() bool {
+	return !color.NoColor
+}
+
+// Style is a combination of terminal colors and attributes. Styles print
+// their text unchanged when colors are disabled.
+type Style []color.Attribute
+
+// The styles commands use, named after how they look.
+var (
+	Bold       = Style{color.Bold}
+	Faint      = Style{color.FgWhite, color.Faint}
+	Red        = Style{color.FgRed}
+	RedBold    = Style{color.FgRed, color.Bold}
+	Green      = Style{color.FgGreen}
+	Yellow     = Style{color.FgYellow}
+	YellowBold = Style{color.FgYellow, color.Bold}
+	Blue       = Style{color.FgBlue}
+	Magenta    = Style{color.FgMagenta}
+	Cyan       = Style{color.FgCyan}
+	CyanBold   = Style{color.FgCyan, color.Bold}
+	White      = Style{color.FgWhite}
+	WhiteBold  = Style{color.FgWhite, color.Bold}
+	Link       = Style{color.FgBlue, color.Underline}
+)
+
+// Sprint formats its arguments like fmt.Sprint and applies the style.
+func (s Style) Sprint(a ...interface{}) string {
+	return color.New(s...).Sprint(a...)
+}
+
+// Sprintf formats like fmt.Sprintf and applies the style.
+func (s Style) Sprintf(format string, a ...interface{}) string {
+	return color.New(s...).Sprintf(format, a...)
+}
+
+// With returns a style that adds attrs to s.
+func (s Style) With(attrs ...color.Attribute) Style {
+	return append(append(Style{}, s...), attrs...)
+}
+
+var asciiMode bool
+
+// SetASCII makes icons print their plain ASCII replacements instead of
+// emoji and other symbols, for terminals and log collectors without UTF-8.
+func SetASCII(enabled bool) {
+	asciiMode = enabled
+}
+
+// ASCII reports whether ASCII mode is on.
+func ASCII() bool {
+	return asciiMode
+}
+
+// Icon is a symbol printed in rich output, with the text used in its place
+// in ASCII mode. Decorative icons have no ASCII text and are left out.
+type Icon struct {
+	Symbol string
+	Text   string
+}
+
+// The icons commands use.
+var (
+	IconSuccess    = Icon{"✅", "[ok]"}
+	IconError      = Icon{"❌", "[error]"}
+	IconInfo       = Icon{"ℹ️", "[info]"}
+	IconWarning    = Icon{"⚠️", "[warn]"}
+	IconCheck      = Icon{"✓", "+"}
+	IconCross      = Icon{"✗", "x"}
+	IconPending    = Icon{"○", "o"}
+	IconInProgress = Icon{"◐", "~"}
+	IconArrow      = Icon{"→", "->"}
+	IconReply      = Icon{"↳", "->"}
+	IconBullet     = Icon{"•", "*"}
+	IconSubBullet  = Icon{"◦", "-"}
+	IconSquare     = Icon{"▪", "+"}
+	IconRule       = Icon{"─", "-"}
+	IconBar        = Icon{"│", "|"}
+	IconUnchecked  = Icon{"☐", "[ ]"}
+	IconChecked    = Icon{"☑", "[x]"}
+	IconImage      = Icon{"🖼", ""}
+	IconLock       = Icon{"🔒", ""}
+	IconTeam       = Icon{"👥", ""}
+	IconUser       = Icon{"👤", ""}
+	IconProject    = Icon{"📁", ""}
+	IconComment    = Icon{"💬", "*"}
+	IconAttachment = Icon{"📎", "-"}
+	IconKey        = Icon{"🔐", ""}
+	IconCache      = Icon{"🗄️", ""}
+	IconClock      = Icon{"⏱️", ""}
+	IconMemo       = Icon{"📝", ""}
+)
+
+// String returns the symbol, or its ASCII text in ASCII mode.
+func (i Icon) String() string {
+	if asciiMode {
+		return i.Text
+	}
+	return i.Symbol
+}
+
+// Prefix returns text preceded by the icon and a space, or just text when
+// the icon has nothing to print.
+func (i Icon) Prefix(text string) string {
+	if s := i.String(); s != "" {
+		return s + " " + text
+	}
+	return text
+}
+
+// Rule returns a horizontal line width columns wide.
+func Rule(width int) string {
+	return strings.Repeat(IconRule.String(), width)
+}
+
+// Warn prints a warning to stderr.
+func Warn(message string) {
+	fmt.Fprintln(os.Stderr, Yellow.Sprint(IconWarning.Prefix(message)))
+}
+
+// Notice prints a status line to stderr, such as which config file is used,
+// so it never mixes with the command's output.
+func Notice(message string) {
+	fmt.Fprintln(os.Stderr, Green.Sprint(IconSuccess.Prefix(message)))
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestSetColorMode(t *testing.T) {
+	defer func() { color.NoColor = true }()
+
+	if err := SetColorMode(ColorAlways); err != nil || !ColorEnabled() {
+		t.Fatalf("Expected colors with --color always, err=%v", err)
+	}
+	if got := Green.Sprint("ok"); got != "\x1b[32mok\x1b[0m" {
+		t.Errorf("Unexpected styled text %q", got)
+	}
+
+	if err := SetColorMode(ColorNever); err != nil || ColorEnabled() {
+		t.Fatalf("Expected no colors with --color never, err=%v", err)
+	}
+	if got := Green.Sprint("ok"); got != "ok" {
+		t.Errorf("Expected plain text without colors, got %q", got)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	_ = SetColorMode(ColorAlways)
+	if err := SetColorMode(ColorAuto); err != nil || ColorEnabled() {
+		t.Errorf("Expected NO_COLOR to disable colors in auto mode, err=%v", err)
+	}
+
+	if err := SetColorMode("sometimes"); err == nil {
+		t.Error("Expected an error for an invalid mode")
+	}
+}
+
+func TestASCIIMode(t *testing.T) {
+	defer SetASCII(false)
+
+	if got := IconSuccess.Prefix("Done"); got != "✅ Done" {
+		t.Errorf("Unexpected icon text %q", got)
+	}
+
+	SetASCII(true)
+	if got := IconSuccess.Prefix("Done"); got != "[ok] Done" {
+		t.Errorf("Unexpected ASCII icon text %q", got)
+	}
+	if got := IconLock.Prefix("Yes"); got != "Yes" {
+		t.Errorf("Expected decorative icons to be dropped, got %q", got)
+	}
+	if got := Rule(3); got != "---" {
+		t.Errorf("Unexpected ASCII rule %q", got)
+	}
+
+	color.NoColor = true
+	got := (&MarkdownRenderer{}).Render("- [x] done\n- item\n\n> quote")
+	if want := "[x] done\n* item\n\n| quote"; got != want {
+		t.Errorf("Unexpected ASCII markdown:\n%q\nwant:\n%q", got, want)
+	}
+}
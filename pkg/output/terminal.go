@@ -0,0 +1,58 @@
+package output
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/mattn/go-isatty"
+	"github.com/mattn/go-runewidth"
+)
+
+// IsTerminal reports whether stdout is an interactive terminal.
+func IsTerminal() bool {
+	fd := stdoutFile().Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// IsInputTerminal reports whether stdin is an interactive terminal, so a
+// command can ask the user a question.
+func IsInputTerminal() bool {
+	fd := os.Stdin.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// TerminalWidth returns the number of columns of the terminal stdout is
+// attached to, or 0 when it is not a terminal. $COLUMNS overrides it.
+func TerminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if !IsTerminal() {
+		return 0
+	}
+	if width, ok := terminalWidth(int(stdoutFile().Fd())); ok {
+		return width
+	}
+	return 0
+}
+
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// visibleWidth returns the number of terminal columns s occupies, ignoring
+// color escape codes and counting wide characters twice.
+func visibleWidth(s string) int {
+	return runewidth.StringWidth(ansiPattern.ReplaceAllString(s, ""))
+}
+
+// Truncate shortens s to at most width terminal columns, ending it with "..."
+// when anything was cut. It never splits a multi-byte character.
+func Truncate(s string, width int) string {
+	if runewidth.StringWidth(s) <= width {
+		return s
+	}
+	if width <= 3 {
+		return runewidth.Truncate(s, width, "")
+	}
+	return runewidth.Truncate(s, width, "...")
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package output
+
+// terminalWidth is only detected on Unix; elsewhere $COLUMNS sets the width.
+func terminalWidth(fd int) (int, bool) {
+	return 0, false
+}
@@ -0,0 +1,55 @@
+package output
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	cases := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exactly ten", 11, "exactly ten"},
+		{"Fix the login button", 10, "Fix the..."},
+		{"Ünïcödé title", 8, "Ünïcö..."},
+		{"日本語のタイトル", 9, "日本語..."},
+		{"abcdef", 3, "abc"},
+	}
+	for _, tc := range cases {
+		if got := Truncate(tc.s, tc.width); got != tc.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tc.s, tc.width, got, tc.want)
+		}
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	data := TableData{
+		Headers: []string{"Title", "State"},
+		Rows: [][]string{
+			{"A title that is much too long for the terminal", "Todo"},
+			{"Short", "\x1b[32mDone\x1b[0m"},
+		},
+		Shrink: "Title",
+	}
+
+	// Each column is followed by 3 spaces of padding, so State takes 8
+	// columns and the title gets the remaining 22 minus its own padding.
+	fitted := fitWidth(data, 30)
+	if got := fitted.Rows[0][0]; got != "A title that is ..." {
+		t.Errorf("Expected the title to be shrunk, got %q", got)
+	}
+	if got := fitted.Rows[1][0]; got != "Short" {
+		t.Errorf("Expected short titles to be kept, got %q", got)
+	}
+	if data.Rows[0][0] != "A title that is much too long for the terminal" {
+		t.Error("fitWidth must not modify the caller's rows")
+	}
+
+	if got := fitWidth(data, 0).Rows[0][0]; got != data.Rows[0][0] {
+		t.Errorf("Expected no shrinking without a width, got %q", got)
+	}
+	data.Shrink = ""
+	if got := fitWidth(data, 30).Rows[0][0]; got != data.Rows[0][0] {
+		t.Errorf("Expected no shrinking without a Shrink column, got %q", got)
+	}
+}
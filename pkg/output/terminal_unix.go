@@ -0,0 +1,13 @@
+//go:build unix
+
+package output
+
+import "golang.org/x/sys/unix"
+
+func terminalWidth(fd int) (int, bool) {
+	ws, err := unix.IoctlGetWinsize(fd, unix.TIOCGWINSZ)
+	if err != nil || ws.Col == 0 {
+		return 0, false
+	}
+	return int(ws.Col), true
+}
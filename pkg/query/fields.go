@@ -0,0 +1,342 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charlietran/linctl/pkg/utils"
+)
+
+type m = map[string]interface{}
+
+// field is a name usable in a filter term. build checks the operator and
+// value of one term and returns its filter.
+type field struct {
+	name    string
+	aliases []string
+	build   func(op, value string) (leaf, error)
+}
+
+var fields = []field{
+	{name: "assignee", build: userField("assignee", true)},
+	{name: "creator", aliases: []string{"author"}, build: userField("creator", false)},
+	{name: "state", aliases: []string{"status"}, build: stringField("state", "name")},
+	{name: "team", build: stringField("team", "key")},
+	{name: "label", aliases: []string{"labels"}, build: buildLabel},
+	{name: "project", build: buildProject},
+	{name: "cycle", build: buildCycle},
+	{name: "parent", build: buildParent},
+	{name: "priority", build: buildPriority},
+	{name: "estimate", build: buildEstimate},
+	{name: "title", build: buildTitle},
+	{name: "created", build: dateField("createdAt", false)},
+	{name: "updated", build: dateField("updatedAt", false)},
+	{name: "completed", build: dateField("completedAt", true)},
+	{name: "due", build: dateField("dueDate", true)},
+}
+
+func lookupField(name string) (field, bool) {
+	for _, f := range fields {
+		if f.name == name {
+			return f, true
+		}
+		for _, alias := range f.aliases {
+			if alias == name {
+				return f, true
+			}
+		}
+	}
+	return field{}, false
+}
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func isNone(value string) bool {
+	return strings.EqualFold(value, "none")
+}
+
+func needsEquals(op string) error {
+	if op != ":" {
+		return fmt.Errorf("%q is not supported here; use : or !=", op)
+	}
+	return nil
+}
+
+// relation filters on a field of a related object. Negating it also matches
+// issues where a nullable relation is empty, since a nested filter never
+// matches a missing object.
+func relation(name string, nullable bool, match, mismatch m) leaf {
+	return func(negate bool) map[string]interface{} {
+		if !negate {
+			return m{name: match}
+		}
+		if nullable {
+			return m{"or": []interface{}{m{name: m{"null": true}}, m{name: mismatch}}}
+		}
+		return m{name: mismatch}
+	}
+}
+
+// isNull matches issues where name is empty, such as assignee:none.
+func isNull(name string) leaf {
+	return func(negate bool) map[string]interface{} {
+		return m{name: m{"null": !negate}}
+	}
+}
+
+func userField(name string, nullable bool) func(op, value string) (leaf, error) {
+	return func(op, value string) (leaf, error) {
+		if err := needsEquals(op); err != nil {
+			return nil, err
+		}
+		switch {
+		case nullable && isNone(value):
+			return isNull(name), nil
+		case strings.EqualFold(value, "me"):
+			return relation(name, nullable, m{"isMe": m{"eq": true}}, m{"isMe": m{"eq": false}}), nil
+		case strings.Contains(value, "@"):
+			return relation(name, nullable, m{"email": m{"eq": value}}, m{"email": m{"neq": value}}), nil
+		}
+		return relation(name, nullable,
+			m{"displayName": m{"eqIgnoreCase": value}},
+			m{"displayName": m{"neqIgnoreCase": value}}), nil
+	}
+}
+
+func stringField(name, key string) func(op, value string) (leaf, error) {
+	return func(op, value string) (leaf, error) {
+		if err := needsEquals(op); err != nil {
+			return nil, err
+		}
+		return relation(name, false,
+			m{key: m{"eqIgnoreCase": value}},
+			m{key: m{"neqIgnoreCase": value}}), nil
+	}
+}
+
+func buildLabel(op, value string) (leaf, error) {
+	if err := needsEquals(op); err != nil {
+		return nil, err
+	}
+	if isNone(value) {
+		return func(negate bool) map[string]interface{} {
+			if negate {
+				return m{"labels": m{"length": m{"gt": 0}}}
+			}
+			return m{"labels": m{"length": m{"eq": 0}}}
+		}, nil
+	}
+	return func(negate bool) map[string]interface{} {
+		if negate {
+			return m{"labels": m{"every": m{"name": m{"neqIgnoreCase": value}}}}
+		}
+		return m{"labels": m{"some": m{"name": m{"eqIgnoreCase": value}}}}
+	}, nil
+}
+
+func buildProject(op, value string) (leaf, error) {
+	if err := needsEquals(op); err != nil {
+		return nil, err
+	}
+	switch {
+	case isNone(value):
+		return isNull("project"), nil
+	case uuidRegexp.MatchString(value):
+		return relation("project", true, m{"id": m{"eq": value}}, m{"id": m{"neq": value}}), nil
+	}
+	return relation("project", true,
+		m{"name": m{"eqIgnoreCase": value}},
+		m{"name": m{"neqIgnoreCase": value}}), nil
+}
+
+func buildCycle(op, value string) (leaf, error) {
+	if err := needsEquals(op); err != nil {
+		return nil, err
+	}
+	flags := map[string]string{"current": "isActive", "next": "isNext", "previous": "isPrevious"}
+	if isNone(value) {
+		return isNull("cycle"), nil
+	}
+	if key, ok := flags[strings.ToLower(value)]; ok {
+		return relation("cycle", true, m{key: m{"eq": true}}, m{key: m{"eq": false}}), nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("expected current, next, previous, none or a cycle number, got %q", value)
+	}
+	return relation("cycle", true, m{"number": m{"eq": number}}, m{"number": m{"neq": number}}), nil
+}
+
+var identifierRegexp = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9]*)-(\d+)$`)
+
+func buildParent(op, value string) (leaf, error) {
+	if err := needsEquals(op); err != nil {
+		return nil, err
+	}
+	if isNone(value) {
+		return isNull("parent"), nil
+	}
+	if uuidRegexp.MatchString(value) {
+		return relation("parent", true, m{"id": m{"eq": value}}, m{"id": m{"neq": value}}), nil
+	}
+	parts := identifierRegexp.FindStringSubmatch(value)
+	if parts == nil {
+		return nil, fmt.Errorf("expected an issue identifier such as ENG-123 or none, got %q", value)
+	}
+	number, _ := strconv.Atoi(parts[2])
+	team := strings.ToUpper(parts[1])
+	return func(negate bool) map[string]interface{} {
+		if !negate {
+			return m{"parent": m{"number": m{"eq": number}, "team": m{"key": m{"eq": team}}}}
+		}
+		return m{"or": []interface{}{
+			m{"parent": m{"null": true}},
+			m{"parent": m{"number": m{"neq": number}}},
+			m{"parent": m{"team": m{"key": m{"neq": team}}}},
+		}}
+	}, nil
+}
+
+// priorityRank orders priorities from most to least urgent. "No priority"
+// ranks last, so priority<=2 means urgent or high, as in Linear's app.
+var priorityRank = []int{1, 2, 3, 4, 0}
+
+var priorityNames = map[string]int{"none": 0, "urgent": 1, "high": 2, "normal": 3, "medium": 3, "low": 4}
+
+func parsePriority(value string) (int, error) {
+	if p, ok := priorityNames[strings.ToLower(value)]; ok {
+		return p, nil
+	}
+	if p, err := strconv.Atoi(value); err == nil && p >= 0 && p <= 4 {
+		return p, nil
+	}
+	return 0, fmt.Errorf("expected 0-4 or none, urgent, high, normal, low, got %q", value)
+}
+
+func buildPriority(op, value string) (leaf, error) {
+	want, err := parsePriority(value)
+	if err != nil {
+		return nil, err
+	}
+	rank := func(p int) int {
+		for i, r := range priorityRank {
+			if r == p {
+				return i
+			}
+		}
+		return -1
+	}
+	matches := []interface{}{}
+	for _, p := range priorityRank {
+		var ok bool
+		switch op {
+		case ":":
+			ok = p == want
+		case "<":
+			ok = rank(p) < rank(want)
+		case "<=":
+			ok = rank(p) <= rank(want)
+		case ">":
+			ok = rank(p) > rank(want)
+		case ">=":
+			ok = rank(p) >= rank(want)
+		}
+		if ok {
+			matches = append(matches, p)
+		}
+	}
+	return func(negate bool) map[string]interface{} {
+		if negate {
+			return m{"priority": m{"nin": matches}}
+		}
+		return m{"priority": m{"in": matches}}
+	}, nil
+}
+
+// comparators maps an operator to Linear's comparator and to the one that
+// matches exactly the other values.
+var comparators = map[string][2]string{
+	":":  {"eq", "neq"},
+	"<":  {"lt", "gte"},
+	"<=": {"lte", "gt"},
+	">":  {"gt", "lte"},
+	">=": {"gte", "lt"},
+}
+
+// compare filters name with a comparator. Negating it also matches issues
+// where a nullable field is empty.
+func compare(name, op string, value interface{}, nullable bool) leaf {
+	return func(negate bool) map[string]interface{} {
+		c := comparators[op]
+		if !negate {
+			return m{name: m{c[0]: value}}
+		}
+		if nullable {
+			return m{"or": []interface{}{m{name: m{"null": true}}, m{name: m{c[1]: value}}}}
+		}
+		return m{name: m{c[1]: value}}
+	}
+}
+
+func buildEstimate(op, value string) (leaf, error) {
+	if isNone(value) {
+		if err := needsEquals(op); err != nil {
+			return nil, err
+		}
+		return isNull("estimate"), nil
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("expected a number or none, got %q", value)
+	}
+	return compare("estimate", op, n, true), nil
+}
+
+func buildTitle(op, value string) (leaf, error) {
+	if err := needsEquals(op); err != nil {
+		return nil, err
+	}
+	return func(negate bool) map[string]interface{} {
+		if negate {
+			return m{"title": m{"notContainsIgnoreCase": value}}
+		}
+		return m{"title": m{"containsIgnoreCase": value}}
+	}, nil
+}
+
+// dateField compares a date with a date (2024-10-01), a timestamp or a
+// relative time such as 2_weeks_ago. "due" compares calendar dates only.
+func dateField(name string, nullable bool) func(op, value string) (leaf, error) {
+	return func(op, value string) (leaf, error) {
+		if isNone(value) {
+			if !nullable {
+				return nil, fmt.Errorf("is always set")
+			}
+			if err := needsEquals(op); err != nil {
+				return nil, err
+			}
+			return isNull(name), nil
+		}
+		if op == ":" {
+			return nil, fmt.Errorf("needs a comparison such as <%s or >%s", value, value)
+		}
+		if strings.EqualFold(value, "all_time") {
+			return nil, fmt.Errorf("all_time is not a point in time")
+		}
+		at, err := utils.ParseTimeExpression(value)
+		if err != nil {
+			return nil, err
+		}
+		if name == "dueDate" {
+			t, err := time.Parse(time.RFC3339, at)
+			if err != nil {
+				return nil, err
+			}
+			at = t.Format("2006-01-02")
+		}
+		return compare(name, op, at, nullable), nil
+	}
+}
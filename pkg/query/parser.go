@@ -0,0 +1,356 @@
+// Package query parses the issue filter language used by --filter, such as
+//
+//	label:bug priority<=2 assignee:me updated>2_weeks_ago -state:Backlog
+//
+// and compiles it into the nested filter object Linear's issues query takes.
+// Terms next to each other must all match; OR, parentheses and negation with
+// "-" or NOT combine them further.
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SyntaxError reports a filter that could not be parsed, or a term whose
+// field, operator or value is not valid.
+type SyntaxError struct {
+	Offset  int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Offset+1, e.Message)
+}
+
+// Query is a parsed filter expression.
+type Query struct {
+	root   expr
+	fields map[string]bool
+}
+
+// Filter returns the Linear IssueFilter object for the query.
+func (q *Query) Filter() map[string]interface{} {
+	return q.root.compile(false)
+}
+
+// Uses reports whether any term of the query filters on field, such as
+// "state" or "created", so callers can drop their own defaults for it.
+func (q *Query) Uses(field string) bool {
+	return q.fields[field]
+}
+
+// Parse parses a filter expression.
+func Parse(src string) (*Query, error) {
+	p := &parser{src: src, fields: map[string]bool{}}
+	p.skipSpace()
+	if p.eof() {
+		return nil, &SyntaxError{Offset: 0, Message: "empty filter"}
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.eof() {
+		if p.src[p.pos] == ')' {
+			return nil, p.errorf(`unexpected ")" without a matching "("`)
+		}
+		return nil, p.errorf("unexpected %q", p.rest())
+	}
+	return &Query{root: root, fields: p.fields}, nil
+}
+
+// expr is a node of a parsed query. compile returns the filter for the node,
+// or for its negation when negate is set: Linear filters have no "not", so
+// negation is pushed down to the comparators of each term.
+type expr interface {
+	compile(negate bool) map[string]interface{}
+}
+
+type andExpr []expr
+
+func (e andExpr) compile(negate bool) map[string]interface{} {
+	if negate {
+		return orExpr(e).compileAs("or", true)
+	}
+	return orExpr(e).compileAs("and", false)
+}
+
+type orExpr []expr
+
+func (e orExpr) compile(negate bool) map[string]interface{} {
+	if negate {
+		return e.compileAs("and", true)
+	}
+	return e.compileAs("or", false)
+}
+
+func (e orExpr) compileAs(op string, negate bool) map[string]interface{} {
+	if len(e) == 1 {
+		return e[0].compile(negate)
+	}
+	items := make([]interface{}, len(e))
+	for i, child := range e {
+		items[i] = child.compile(negate)
+	}
+	return map[string]interface{}{op: items}
+}
+
+type notExpr struct{ expr }
+
+func (e notExpr) compile(negate bool) map[string]interface{} {
+	return e.expr.compile(!negate)
+}
+
+// leaf is a single field comparison, already checked and resolved.
+type leaf func(negate bool) map[string]interface{}
+
+func (l leaf) compile(negate bool) map[string]interface{} {
+	return l(negate)
+}
+
+type parser struct {
+	src    string
+	pos    int
+	fields map[string]bool
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) rest() string {
+	rest := p.src[p.pos:]
+	if i := strings.IndexAny(rest, " \t\n"); i > 0 {
+		rest = rest[:i]
+	}
+	return rest
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Offset: p.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// keyword consumes AND, OR or NOT (in any case) when it is the next word.
+func (p *parser) keyword(word string) bool {
+	end := p.pos + len(word)
+	if end > len(p.src) || !strings.EqualFold(p.src[p.pos:end], word) {
+		return false
+	}
+	if end < len(p.src) && strings.IndexByte(" \t\r\n()", p.src[end]) < 0 {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *parser) parseOr() (expr, error) {
+	var items orExpr
+	for {
+		e, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, e)
+		p.skipSpace()
+		if !p.keyword("or") {
+			break
+		}
+	}
+	if len(items) == 1 {
+		return items[0], nil
+	}
+	return items, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	var items andExpr
+	for {
+		p.skipSpace()
+		start := p.pos
+		if p.eof() || p.src[p.pos] == ')' || p.keyword("or") {
+			p.pos = start
+			if len(items) == 0 {
+				return nil, p.errorf("expected a term such as label:bug, found %s", p.found())
+			}
+			break
+		}
+		if p.keyword("and") {
+			p.skipSpace()
+			if p.eof() || p.src[p.pos] == ')' {
+				return nil, p.errorf("expected a term after AND")
+			}
+			continue
+		}
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, e)
+	}
+	if len(items) == 1 {
+		return items[0], nil
+	}
+	return items, nil
+}
+
+func (p *parser) found() string {
+	if p.eof() {
+		return "end of filter"
+	}
+	return fmt.Sprintf("%q", p.rest())
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.src[p.pos] == '-' || p.keyword("not") {
+		if p.src[p.pos] == '-' {
+			p.pos++
+		}
+		p.skipSpace()
+		if p.eof() || p.src[p.pos] == ')' {
+			return nil, p.errorf("expected a term to negate, found %s", p.found())
+		}
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{e}, nil
+	}
+	if p.src[p.pos] == '(' {
+		open := p.pos
+		p.pos++
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.eof() || p.src[p.pos] != ')' {
+			return nil, &SyntaxError{Offset: open, Message: `missing ")" for this "("`}
+		}
+		p.pos++
+		return e, nil
+	}
+	return p.parseTerm()
+}
+
+// operators are tried in order, so the two-character ones come first.
+var operators = []string{"!=", "<=", ">=", ":", "=", "<", ">"}
+
+func (p *parser) parseTerm() (expr, error) {
+	start := p.pos
+	for !p.eof() && isFieldChar(p.src[p.pos]) {
+		p.pos++
+	}
+	name := strings.ToLower(p.src[start:p.pos])
+	if name == "" {
+		return nil, p.errorf("expected a term such as label:bug, found %s", p.found())
+	}
+	field, ok := lookupField(name)
+	if !ok {
+		return nil, &SyntaxError{Offset: start, Message: fmt.Sprintf("unknown field %q (fields: %s)", name, strings.Join(fieldNames(), ", "))}
+	}
+
+	op := ""
+	for _, candidate := range operators {
+		if strings.HasPrefix(p.src[p.pos:], candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return nil, p.errorf("expected an operator such as %s: after %q", name, name)
+	}
+	p.pos += len(op)
+
+	negate := false
+	if op == "!=" {
+		op, negate = ":", true
+	}
+	if op == "=" {
+		op = ":"
+	}
+
+	var alternatives orExpr
+	for {
+		valueStart := p.pos
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			return nil, p.errorf("expected a value after %q", p.src[start:valueStart])
+		}
+		l, err := field.build(op, value)
+		if err != nil {
+			return nil, &SyntaxError{Offset: valueStart, Message: fmt.Sprintf("%s: %v", field.name, err)}
+		}
+		alternatives = append(alternatives, l)
+		if p.eof() || p.src[p.pos] != ',' {
+			break
+		}
+		p.pos++
+	}
+	p.fields[field.name] = true
+
+	var e expr = alternatives
+	if len(alternatives) == 1 {
+		e = alternatives[0]
+	}
+	if negate {
+		e = notExpr{e}
+	}
+	return e, nil
+}
+
+// parseValue reads a bare value, which ends at whitespace, a comma or ")",
+// or a double-quoted one, in which \" and \\ are escapes.
+func (p *parser) parseValue() (string, error) {
+	if p.eof() || p.src[p.pos] != '"' {
+		start := p.pos
+		for !p.eof() && strings.IndexByte(" \t\r\n,()", p.src[p.pos]) < 0 {
+			p.pos++
+		}
+		return p.src[start:p.pos], nil
+	}
+	open := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.eof() {
+		c := p.src[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			if b.Len() == 0 {
+				return "", &SyntaxError{Offset: open, Message: "empty quoted value"}
+			}
+			return b.String(), nil
+		case c == '\\' && p.pos+1 < len(p.src):
+			b.WriteByte(p.src[p.pos+1])
+			p.pos += 2
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", &SyntaxError{Offset: open, Message: "unterminated quoted value"}
+}
+
+func isFieldChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func fieldNames() []string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,114 @@
+package query
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func compile(t *testing.T, src string) string {
+	t.Helper()
+	q, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", src, err)
+	}
+	data, err := json.Marshal(q.Filter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`label:bug`, `{"labels":{"some":{"name":{"eqIgnoreCase":"bug"}}}}`},
+		{`-label:bug`, `{"labels":{"every":{"name":{"neqIgnoreCase":"bug"}}}}`},
+		{`label:none`, `{"labels":{"length":{"eq":0}}}`},
+		{`state:Todo team:eng`, `{"and":[{"state":{"name":{"eqIgnoreCase":"Todo"}}},{"team":{"key":{"eqIgnoreCase":"eng"}}}]}`},
+		{`status!=Backlog`, `{"state":{"name":{"neqIgnoreCase":"Backlog"}}}`},
+		{`project:"Q3 Launch"`, `{"project":{"name":{"eqIgnoreCase":"Q3 Launch"}}}`},
+		{`-project:"Q3 Launch"`, `{"or":[{"project":{"null":true}},{"project":{"name":{"neqIgnoreCase":"Q3 Launch"}}}]}`},
+		{`project:none`, `{"project":{"null":true}}`},
+		{`assignee:me`, `{"assignee":{"isMe":{"eq":true}}}`},
+		{`assignee:ada@example.com`, `{"assignee":{"email":{"eq":"ada@example.com"}}}`},
+		{`-assignee:none`, `{"assignee":{"null":false}}`},
+		{`creator:grace`, `{"creator":{"displayName":{"eqIgnoreCase":"grace"}}}`},
+		{`priority<=2`, `{"priority":{"in":[1,2]}}`},
+		{`priority>high`, `{"priority":{"in":[3,4,0]}}`},
+		{`-priority:urgent`, `{"priority":{"nin":[1]}}`},
+		{`estimate>=3`, `{"estimate":{"gte":3}}`},
+		{`-estimate>=3`, `{"or":[{"estimate":{"null":true}},{"estimate":{"lt":3}}]}`},
+		{`due<2024-10-01`, `{"dueDate":{"lt":"2024-10-01"}}`},
+		{`created>=2024-01-01`, `{"createdAt":{"gte":"2024-01-01T00:00:00Z"}}`},
+		{`cycle:current`, `{"cycle":{"isActive":{"eq":true}}}`},
+		{`parent:eng-12`, `{"parent":{"number":{"eq":12},"team":{"key":{"eq":"ENG"}}}}`},
+		{`title:"login fails"`, `{"title":{"containsIgnoreCase":"login fails"}}`},
+		{`label:bug,regression`, `{"or":[{"labels":{"some":{"name":{"eqIgnoreCase":"bug"}}}},{"labels":{"some":{"name":{"eqIgnoreCase":"regression"}}}}]}`},
+		{`label:bug OR label:regression`, `{"or":[{"labels":{"some":{"name":{"eqIgnoreCase":"bug"}}}},{"labels":{"some":{"name":{"eqIgnoreCase":"regression"}}}}]}`},
+		{`team:ENG AND (label:bug or priority:1)`, `{"and":[{"team":{"key":{"eqIgnoreCase":"ENG"}}},{"or":[{"labels":{"some":{"name":{"eqIgnoreCase":"bug"}}}},{"priority":{"in":[1]}}]}]}`},
+		{`NOT (state:Done OR state:Canceled)`, `{"and":[{"state":{"name":{"neqIgnoreCase":"Done"}}},{"state":{"name":{"neqIgnoreCase":"Canceled"}}}]}`},
+		{`--label:bug`, `{"labels":{"some":{"name":{"eqIgnoreCase":"bug"}}}}`},
+	}
+	for _, tt := range tests {
+		if got := compile(t, tt.src); got != tt.want {
+			t.Errorf("%s\n got: %s\nwant: %s", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestFilterRelativeTime(t *testing.T) {
+	got := compile(t, `updated>2_weeks_ago`)
+	if !strings.HasPrefix(got, `{"updatedAt":{"gt":"20`) {
+		t.Errorf("Expected a timestamp comparison, got %s", got)
+	}
+}
+
+func TestUses(t *testing.T) {
+	q, err := Parse(`label:bug (status:Todo OR -priority:1)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"label", "state", "priority"} {
+		if !q.Uses(field) {
+			t.Errorf("Expected Uses(%q)", field)
+		}
+	}
+	if q.Uses("created") {
+		t.Error("Did not expect Uses(\"created\")")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{``, "column 1: empty filter"},
+		{`lable:bug`, `column 1: unknown field "lable"`},
+		{`label:bug state`, `column 16: expected an operator such as state: after "state"`},
+		{`label:`, `column 7: expected a value after "label:"`},
+		{`project:"Q3 Launch`, "column 9: unterminated quoted value"},
+		{`(label:bug`, `column 1: missing ")" for this "("`},
+		{`label:bug)`, `column 10: unexpected ")" without a matching "("`},
+		{`label:bug OR`, "column 13: expected a term such as label:bug, found end of filter"},
+		{`priority:3000`, `column 10: priority: expected 0-4 or none`},
+		{`label<bug`, `column 7: label: "<" is not supported here; use : or !=`},
+		{`updated:yesterday`, "column 9: updated: needs a comparison"},
+		{`created:none`, "column 9: created: is always set"},
+		{`due>soon`, "column 5: due: invalid time expression"},
+		{`-`, "column 2: expected a term to negate, found end of filter"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.src)
+		if err == nil {
+			t.Errorf("Parse(%q) succeeded, want error %q", tt.src, tt.want)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) error = %q, want prefix %q", tt.src, err.Error(), tt.want)
+		}
+	}
+}
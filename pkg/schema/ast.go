@@ -0,0 +1,153 @@
+package schema
+
+import "fmt"
+
+// Position is a 1-based location in a GraphQL document.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Document is a parsed executable GraphQL document.
+type Document struct {
+	Operations []*Operation
+	Fragments  []*FragmentDefinition
+}
+
+// Operation is a query, mutation or subscription.
+type Operation struct {
+	Kind         string // "query", "mutation" or "subscription"
+	Name         string
+	Variables    []*VariableDefinition
+	Directives   []*Directive
+	SelectionSet []Selection
+	Pos          Position
+}
+
+// VariableDefinition declares an operation variable.
+type VariableDefinition struct {
+	Name    string
+	Type    *TypeExpr
+	Default *Value
+	Pos     Position
+}
+
+// TypeExpr is a type written in a document: a name, or a list of a type,
+// either of which may be non-null.
+type TypeExpr struct {
+	Name    string    // set for named types
+	Elem    *TypeExpr // set for list types
+	NonNull bool
+	Pos     Position
+}
+
+func (t *TypeExpr) String() string {
+	s := t.Name
+	if t.Elem != nil {
+		s = "[" + t.Elem.String() + "]"
+	}
+	if t.NonNull {
+		s += "!"
+	}
+	return s
+}
+
+// NamedType returns the innermost type name.
+func (t *TypeExpr) NamedType() string {
+	for t.Elem != nil {
+		t = t.Elem
+	}
+	return t.Name
+}
+
+// Selection is a *Field, *FragmentSpread or *InlineFragment.
+type Selection interface {
+	Position() Position
+}
+
+// Field selects a field, optionally under an alias.
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*ArgumentValue
+	Directives   []*Directive
+	SelectionSet []Selection
+	Pos          Position
+}
+
+// FragmentSpread includes a named fragment: ...Name.
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Pos        Position
+}
+
+// InlineFragment is ... on Type { ... } or an untyped ... { ... }.
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Pos           Position
+}
+
+func (f *Field) Position() Position          { return f.Pos }
+func (f *FragmentSpread) Position() Position { return f.Pos }
+func (f *InlineFragment) Position() Position { return f.Pos }
+
+// FragmentDefinition is fragment Name on Type { ... }.
+type FragmentDefinition struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Pos           Position
+}
+
+// Directive is @name(args).
+type Directive struct {
+	Name      string
+	Arguments []*ArgumentValue
+	Pos       Position
+}
+
+// ArgumentValue is name: value in a field or directive.
+type ArgumentValue struct {
+	Name  string
+	Value *Value
+	Pos   Position
+}
+
+// ValueKind identifies the kind of a literal or variable value.
+type ValueKind int
+
+const (
+	ValueVariable ValueKind = iota
+	ValueInt
+	ValueFloat
+	ValueString
+	ValueBoolean
+	ValueNull
+	ValueEnum
+	ValueList
+	ValueObject
+)
+
+// Value is an input value written in a document.
+type Value struct {
+	Kind   ValueKind
+	Raw    string // variable name, scalar text or enum name
+	List   []*Value
+	Fields []*ObjectField
+	Pos    Position
+}
+
+// ObjectField is name: value inside an input object literal.
+type ObjectField struct {
+	Name  string
+	Value *Value
+	Pos   Position
+}
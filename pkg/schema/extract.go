@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	gotoken "go/token"
+	"regexp"
+	"strconv"
+)
+
+// EmbeddedQuery is a GraphQL document found in a Go raw string literal.
+type EmbeddedQuery struct {
+	Source string
+	// Line is the line in the Go file where the document starts.
+	Line int
+}
+
+var documentStart = regexp.MustCompile(`^\s*(query|mutation|subscription|fragment)\b|^\s*\{`)
+
+// ExtractQueries returns the GraphQL documents held in raw string literals
+// of a Go source file, such as the queries in pkg/api/queries.go.
+func ExtractQueries(filename string, src []byte) ([]EmbeddedQuery, error) {
+	fset := gotoken.NewFileSet()
+	file, err := goparser.ParseFile(fset, filename, src, goparser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []EmbeddedQuery
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.BasicLit)
+		if !ok || lit.Kind != gotoken.STRING || lit.Value[0] != '`' {
+			return true
+		}
+		value, err := strconv.Unquote(lit.Value)
+		if err != nil || !documentStart.MatchString(value) {
+			return true
+		}
+		queries = append(queries, EmbeddedQuery{
+			Source: value,
+			Line:   fset.Position(lit.Pos()).Line,
+		})
+		return true
+	})
+	return queries, nil
+}
+
+// Offset shifts a diagnostic found in an embedded query to its position in the Go file.
+func (q EmbeddedQuery) Offset(d Diagnostic) Diagnostic {
+	if d.Pos.Line > 0 {
+		d.Pos.Line += q.Line - 1
+	}
+	return d
+}
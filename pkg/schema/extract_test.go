@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/charlietran/linctl/pkg/api"
+)
+
+func TestEmbeddedQueriesParse(t *testing.T) {
+	name, src := api.QueriesSource()
+	queries, err := ExtractQueries(name, src)
+	if err != nil {
+		t.Fatalf("ExtractQueries failed: %v", err)
+	}
+	if len(queries) < 20 {
+		t.Fatalf("Expected the queries in %s, found %d", name, len(queries))
+	}
+
+	for _, q := range queries {
+		if _, err := ParseDocument(q.Source); err != nil {
+			t.Errorf("%s:%d: %v", name, q.Line, err)
+		}
+	}
+}
+
+func TestFieldsQueriesValidate(t *testing.T) {
+	s := loadTestSchema(t)
+	for op, src := range api.FieldsQueries() {
+		if diags := s.ValidateSource(src); len(diags) > 0 {
+			t.Errorf("%s: %v", op, diags)
+		}
+	}
+}
+
+func TestEmbeddedQueryOffset(t *testing.T) {
+	src := []byte("package x\n\nconst q = `\n\tquery Q {\n\t\tviewer { nope }\n\t}\n`\n")
+	queries, err := ExtractQueries("x.go", src)
+	if err != nil || len(queries) != 1 {
+		t.Fatalf("Expected one query, got %d (%v)", len(queries), err)
+	}
+
+	s := loadTestSchema(t)
+	diags := s.ValidateSource(queries[0].Source)
+	if len(diags) != 1 {
+		t.Fatalf("Expected one diagnostic, got %v", diags)
+	}
+	if got := queries[0].Offset(diags[0]).Pos.Line; got != 5 {
+		t.Errorf("Expected diagnostic on line 5 of x.go, got %d", got)
+	}
+}
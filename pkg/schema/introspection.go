@@ -0,0 +1,281 @@
+// Package schema parses GraphQL documents and validates them offline against
+// a saved introspection result of Linear's API.
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// IntrospectionQuery fetches everything the validator needs, including
+// deprecated fields and enum values so their use can be reported.
+const IntrospectionQuery = `
+	query IntrospectionQuery {
+		__schema {
+			queryType { name }
+			mutationType { name }
+			subscriptionType { name }
+			types {
+				kind
+				name
+				fields(includeDeprecated: true) {
+					name
+					args { name type { ...TypeRef } defaultValue }
+					type { ...TypeRef }
+					isDeprecated
+					deprecationReason
+				}
+				inputFields { name type { ...TypeRef } defaultValue }
+				interfaces { name }
+				enumValues(includeDeprecated: true) { name isDeprecated deprecationReason }
+				possibleTypes { name }
+			}
+		}
+	}
+
+	fragment TypeRef on __Type {
+		kind
+		name
+		ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } }
+	}
+`
+
+// Type kinds reported by introspection.
+const (
+	KindScalar      = "SCALAR"
+	KindObject      = "OBJECT"
+	KindInterface   = "INTERFACE"
+	KindUnion       = "UNION"
+	KindEnum        = "ENUM"
+	KindInputObject = "INPUT_OBJECT"
+	KindList        = "LIST"
+	KindNonNull     = "NON_NULL"
+)
+
+// Schema is the __schema object of an introspection result.
+type Schema struct {
+	QueryType        *NamedRef `json:"queryType"`
+	MutationType     *NamedRef `json:"mutationType"`
+	SubscriptionType *NamedRef `json:"subscriptionType"`
+	Types            []*Type   `json:"types"`
+
+	types map[string]*Type
+}
+
+// NamedRef refers to a type by name.
+type NamedRef struct {
+	Name string `json:"name"`
+}
+
+// Type is a named type in the schema.
+type Type struct {
+	Kind          string             `json:"kind"`
+	Name          string             `json:"name"`
+	Fields        []*FieldDefinition `json:"fields"`
+	InputFields   []*InputValue      `json:"inputFields"`
+	Interfaces    []NamedRef         `json:"interfaces"`
+	EnumValues    []*EnumValue       `json:"enumValues"`
+	PossibleTypes []NamedRef         `json:"possibleTypes"`
+}
+
+// FieldDefinition is a field of an object or interface type.
+type FieldDefinition struct {
+	Name              string        `json:"name"`
+	Args              []*InputValue `json:"args"`
+	Type              *TypeRef      `json:"type"`
+	IsDeprecated      bool          `json:"isDeprecated"`
+	DeprecationReason string        `json:"deprecationReason"`
+}
+
+// InputValue is a field argument or an input object field.
+type InputValue struct {
+	Name         string   `json:"name"`
+	Type         *TypeRef `json:"type"`
+	DefaultValue *string  `json:"defaultValue"`
+}
+
+// EnumValue is one value of an enum type.
+type EnumValue struct {
+	Name              string `json:"name"`
+	IsDeprecated      bool   `json:"isDeprecated"`
+	DeprecationReason string `json:"deprecationReason"`
+}
+
+// TypeRef is a possibly wrapped (list / non-null) reference to a named type.
+type TypeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *TypeRef `json:"ofType"`
+}
+
+// String renders the reference in GraphQL notation, e.g. [String!]!.
+func (t *TypeRef) String() string {
+	if t == nil {
+		return ""
+	}
+	switch t.Kind {
+	case KindNonNull:
+		return t.OfType.String() + "!"
+	case KindList:
+		return "[" + t.OfType.String() + "]"
+	}
+	return t.Name
+}
+
+// NamedType returns the innermost named type.
+func (t *TypeRef) NamedType() string {
+	for t != nil && t.OfType != nil {
+		t = t.OfType
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name
+}
+
+// Parse decodes an introspection result. Both the bare data object
+// ({"__schema": ...}) and a full response ({"data": {"__schema": ...}}) are accepted.
+func Parse(data []byte) (*Schema, error) {
+	var envelope struct {
+		Schema *Schema `json:"__schema"`
+		Data   *struct {
+			Schema *Schema `json:"__schema"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	s := envelope.Schema
+	if s == nil && envelope.Data != nil {
+		s = envelope.Data.Schema
+	}
+	if s == nil || s.QueryType == nil {
+		return nil, fmt.Errorf("schema file does not contain an introspection result")
+	}
+
+	s.types = make(map[string]*Type, len(s.Types))
+	for _, t := range s.Types {
+		s.types[t.Name] = t
+	}
+	return s, nil
+}
+
+// ErrNoSchema is returned by Load when no schema has been saved at the path.
+var ErrNoSchema = errors.New("no schema")
+
+// Load reads an introspection result saved by `linctl schema fetch`. A file
+// that cannot be read is reported with its *fs.PathError, and one that cannot
+// be parsed with a plain error.
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w at %s; run `linctl schema fetch` first", ErrNoSchema, path)
+		}
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// DefaultPath returns where `linctl schema fetch` saves the schema.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".linctl-schema.json"), nil
+}
+
+// Type returns the named type, or nil.
+func (s *Schema) Type(name string) *Type {
+	return s.types[name]
+}
+
+// Field returns the named field of t, or nil.
+func (t *Type) Field(name string) *FieldDefinition {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// InputField returns the named input field of t, or nil.
+func (t *Type) InputField(name string) *InputValue {
+	for _, f := range t.InputFields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// EnumValue returns the named enum value of t, or nil.
+func (t *Type) EnumValue(name string) *EnumValue {
+	for _, v := range t.EnumValues {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// isComposite reports whether selections can be made on t.
+func (t *Type) isComposite() bool {
+	return t.Kind == KindObject || t.Kind == KindInterface || t.Kind == KindUnion
+}
+
+// isInput reports whether t may be used as a variable type.
+func (t *Type) isInput() bool {
+	return t.Kind == KindScalar || t.Kind == KindEnum || t.Kind == KindInputObject
+}
+
+// Stats summarizes a schema for display.
+type Stats struct {
+	Types      int `json:"types"`
+	Queries    int `json:"queries"`
+	Mutations  int `json:"mutations"`
+	Deprecated int `json:"deprecated"`
+}
+
+// Stats counts the types, root fields and deprecated fields of s.
+func (s *Schema) Stats() Stats {
+	stats := Stats{}
+	for _, t := range s.Types {
+		if strings.HasPrefix(t.Name, "__") {
+			continue
+		}
+		stats.Types++
+		for _, f := range t.Fields {
+			if f.IsDeprecated {
+				stats.Deprecated++
+			}
+		}
+	}
+	if root := s.Type(s.QueryType.Name); root != nil {
+		stats.Queries = len(root.Fields)
+	}
+	if s.MutationType != nil {
+		if root := s.Type(s.MutationType.Name); root != nil {
+			stats.Mutations = len(root.Fields)
+		}
+	}
+	return stats
+}
+
+// fieldNames returns the sorted field names of t, for suggestions.
+func (t *Type) fieldNames() []string {
+	names := make([]string, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+	return names
+}
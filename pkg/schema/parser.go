@@ -0,0 +1,671 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SyntaxError reports a document that is not valid GraphQL.
+type SyntaxError struct {
+	Pos     Position
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s: syntax error: %s", e.Pos, e.Message)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   Position
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of document"
+	case tokenString:
+		return "string"
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+const byteOrderMark = "\uFEFF"
+
+// lexer splits a document into tokens, skipping whitespace, commas and comments.
+type lexer struct {
+	src  string
+	off  int
+	line int
+	col  int
+}
+
+func (l *lexer) peekByte(ahead int) byte {
+	if l.off+ahead < len(l.src) {
+		return l.src[l.off+ahead]
+	}
+	return 0
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.off < len(l.src); i++ {
+		if l.src[l.off] == '\n' {
+			l.line++
+			l.col = 1
+		} else if l.src[l.off]&0xC0 != 0x80 {
+			// Count columns in runes, not bytes
+			l.col++
+		}
+		l.off++
+	}
+}
+
+func (l *lexer) errorf(pos Position, format string, args ...interface{}) error {
+	return &SyntaxError{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.off < len(l.src) {
+		c := l.src[l.off]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.off < len(l.src) && l.src[l.off] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.off:], byteOrderMark):
+			l.off += len(byteOrderMark)
+		default:
+			return l.scan()
+		}
+	}
+	return token{kind: tokenEOF, pos: Position{l.line, l.col}}, nil
+}
+
+func (l *lexer) scan() (token, error) {
+	pos := Position{l.line, l.col}
+	c := l.src[l.off]
+
+	switch {
+	case strings.ContainsRune("!$&():=@[]{}|", rune(c)):
+		l.advance(1)
+		return token{kind: tokenPunct, value: string(c), pos: pos}, nil
+	case c == '.':
+		if strings.HasPrefix(l.src[l.off:], "...") {
+			l.advance(3)
+			return token{kind: tokenPunct, value: "...", pos: pos}, nil
+		}
+		return token{}, l.errorf(pos, "unexpected %q", ".")
+	case c == '_' || isLetter(c):
+		start := l.off
+		for l.off < len(l.src) && (l.src[l.off] == '_' || isLetter(l.src[l.off]) || isDigit(l.src[l.off])) {
+			l.advance(1)
+		}
+		return token{kind: tokenName, value: l.src[start:l.off], pos: pos}, nil
+	case c == '-' || isDigit(c):
+		return l.scanNumber(pos)
+	case c == '"':
+		if strings.HasPrefix(l.src[l.off:], `"""`) {
+			return l.scanBlockString(pos)
+		}
+		return l.scanString(pos)
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.src[l.off:])
+	return token{}, l.errorf(pos, "unexpected character %q", r)
+}
+
+func (l *lexer) scanNumber(pos Position) (token, error) {
+	start := l.off
+	kind := tokenInt
+	if l.peekByte(0) == '-' {
+		l.advance(1)
+	}
+	if !isDigit(l.peekByte(0)) {
+		return token{}, l.errorf(pos, "invalid number")
+	}
+	for isDigit(l.peekByte(0)) {
+		l.advance(1)
+	}
+	if l.peekByte(0) == '.' {
+		kind = tokenFloat
+		l.advance(1)
+		if !isDigit(l.peekByte(0)) {
+			return token{}, l.errorf(pos, "invalid number")
+		}
+		for isDigit(l.peekByte(0)) {
+			l.advance(1)
+		}
+	}
+	if c := l.peekByte(0); c == 'e' || c == 'E' {
+		kind = tokenFloat
+		l.advance(1)
+		if c := l.peekByte(0); c == '+' || c == '-' {
+			l.advance(1)
+		}
+		if !isDigit(l.peekByte(0)) {
+			return token{}, l.errorf(pos, "invalid number")
+		}
+		for isDigit(l.peekByte(0)) {
+			l.advance(1)
+		}
+	}
+	return token{kind: kind, value: l.src[start:l.off], pos: pos}, nil
+}
+
+func (l *lexer) scanString(pos Position) (token, error) {
+	l.advance(1)
+	var sb strings.Builder
+	for l.off < len(l.src) {
+		c := l.src[l.off]
+		switch c {
+		case '"':
+			l.advance(1)
+			return token{kind: tokenString, value: sb.String(), pos: pos}, nil
+		case '\n':
+			return token{}, l.errorf(pos, "unterminated string")
+		case '\\':
+			esc := l.peekByte(1)
+			switch esc {
+			case '"', '\\', '/':
+				sb.WriteByte(esc)
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				var r rune
+				if l.off+6 > len(l.src) {
+					return token{}, l.errorf(pos, "invalid unicode escape")
+				}
+				if _, err := fmt.Sscanf(l.src[l.off+2:l.off+6], "%04x", &r); err != nil {
+					return token{}, l.errorf(pos, "invalid unicode escape")
+				}
+				sb.WriteRune(r)
+				l.advance(4)
+			default:
+				return token{}, l.errorf(pos, "invalid escape sequence \\%c", esc)
+			}
+			l.advance(2)
+		default:
+			sb.WriteByte(c)
+			l.advance(1)
+		}
+	}
+	return token{}, l.errorf(pos, "unterminated string")
+}
+
+func (l *lexer) scanBlockString(pos Position) (token, error) {
+	l.advance(3)
+	start := l.off
+	for l.off < len(l.src) {
+		if strings.HasPrefix(l.src[l.off:], `\"""`) {
+			l.advance(4)
+			continue
+		}
+		if strings.HasPrefix(l.src[l.off:], `"""`) {
+			value := strings.ReplaceAll(l.src[start:l.off], `\"""`, `"""`)
+			l.advance(3)
+			return token{kind: tokenString, value: value, pos: pos}, nil
+		}
+		l.advance(1)
+	}
+	return token{}, l.errorf(pos, "unterminated block string")
+}
+
+func isLetter(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+// parser is a recursive-descent parser for executable documents.
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+// ParseDocument parses an executable GraphQL document: operations and fragments.
+func ParseDocument(src string) (*Document, error) {
+	p := &parser{lex: &lexer{src: src, line: 1, col: 1}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{}
+	if p.tok.kind == tokenEOF {
+		return nil, p.lex.errorf(p.tok.pos, "document contains no operations")
+	}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.isPunct("{"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.tok.kind == tokenName && (p.tok.value == "query" || p.tok.value == "mutation" || p.tok.value == "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.tok.kind == tokenName && p.tok.value == "fragment":
+			frag, err := p.parseFragmentDefinition()
+			if err != nil {
+				return nil, err
+			}
+			doc.Fragments = append(doc.Fragments, frag)
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) isPunct(value string) bool {
+	return p.tok.kind == tokenPunct && p.tok.value == value
+}
+
+func (p *parser) unexpected() error {
+	return p.lex.errorf(p.tok.pos, "unexpected %s", p.tok.describe())
+}
+
+func (p *parser) expectPunct(value string) error {
+	if !p.isPunct(value) {
+		return p.lex.errorf(p.tok.pos, "expected %q, found %s", value, p.tok.describe())
+	}
+	return p.advance()
+}
+
+func (p *parser) expectName() (string, Position, error) {
+	if p.tok.kind != tokenName {
+		return "", p.tok.pos, p.lex.errorf(p.tok.pos, "expected name, found %s", p.tok.describe())
+	}
+	name, pos := p.tok.value, p.tok.pos
+	return name, pos, p.advance()
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	op := &Operation{Kind: "query", Pos: p.tok.pos}
+
+	if p.tok.kind == tokenName {
+		op.Kind = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokenName {
+			op.Name = p.tok.value
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		if p.isPunct("(") {
+			vars, err := p.parseVariableDefinitions()
+			if err != nil {
+				return nil, err
+			}
+			op.Variables = vars
+		}
+		directives, err := p.parseDirectives()
+		if err != nil {
+			return nil, err
+		}
+		op.Directives = directives
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.SelectionSet = selections
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*VariableDefinition, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+
+	var defs []*VariableDefinition
+	for !p.isPunct(")") {
+		pos := p.tok.pos
+		if err := p.expectPunct("$"); err != nil {
+			return nil, err
+		}
+		name, _, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		typ, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+
+		def := &VariableDefinition{Name: name, Type: typ, Pos: pos}
+		if p.isPunct("=") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if def.Default, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := p.parseDirectives(); err != nil {
+			return nil, err
+		}
+		defs = append(defs, def)
+	}
+	return defs, p.advance()
+}
+
+func (p *parser) parseType() (*TypeExpr, error) {
+	typ := &TypeExpr{Pos: p.tok.pos}
+	if p.isPunct("[") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		elem, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		typ.Elem = elem
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+	} else {
+		name, _, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		typ.Name = name
+	}
+
+	if p.isPunct("!") {
+		typ.NonNull = true
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return typ, nil
+}
+
+func (p *parser) parseDirectives() ([]*Directive, error) {
+	var directives []*Directive
+	for p.isPunct("@") {
+		pos := p.tok.pos
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, _, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.parseArguments()
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, &Directive{Name: name, Arguments: args, Pos: pos})
+	}
+	return directives, nil
+}
+
+func (p *parser) parseArguments() ([]*ArgumentValue, error) {
+	if !p.isPunct("(") {
+		return nil, nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	var args []*ArgumentValue
+	for !p.isPunct(")") {
+		name, pos, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue(false)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, &ArgumentValue{Name: name, Value: value, Pos: pos})
+	}
+	return args, p.advance()
+}
+
+func (p *parser) parseSelectionSet() ([]Selection, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+
+	var selections []Selection
+	for !p.isPunct("}") {
+		if p.tok.kind == tokenEOF {
+			return nil, p.lex.errorf(p.tok.pos, "unterminated selection set")
+		}
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+	if len(selections) == 0 {
+		return nil, p.lex.errorf(p.tok.pos, "selection set cannot be empty")
+	}
+	return selections, p.advance()
+}
+
+func (p *parser) parseSelection() (Selection, error) {
+	if p.isPunct("...") {
+		return p.parseFragment()
+	}
+
+	name, pos, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	field := &Field{Name: name, Pos: pos}
+	if p.isPunct(":") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		field.Alias = name
+		if field.Name, _, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if field.Arguments, err = p.parseArguments(); err != nil {
+		return nil, err
+	}
+	if field.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("{") {
+		if field.SelectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+func (p *parser) parseFragment() (Selection, error) {
+	pos := p.tok.pos
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokenName && p.tok.value != "on" {
+		spread := &FragmentSpread{Name: p.tok.value, Pos: pos}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		directives, err := p.parseDirectives()
+		if err != nil {
+			return nil, err
+		}
+		spread.Directives = directives
+		return spread, nil
+	}
+
+	inline := &InlineFragment{Pos: pos}
+	if p.tok.kind == tokenName && p.tok.value == "on" {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		typeName, _, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		inline.TypeCondition = typeName
+	}
+
+	var err error
+	if inline.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if inline.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return inline, nil
+}
+
+func (p *parser) parseFragmentDefinition() (*FragmentDefinition, error) {
+	pos := p.tok.pos
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	name, _, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, p.lex.errorf(pos, "fragment cannot be named \"on\"")
+	}
+	if p.tok.kind != tokenName || p.tok.value != "on" {
+		return nil, p.lex.errorf(p.tok.pos, "expected \"on\", found %s", p.tok.describe())
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	typeName, _, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	frag := &FragmentDefinition{Name: name, TypeCondition: typeName, Pos: pos}
+	if frag.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if frag.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return frag, nil
+}
+
+// parseValue parses an input value; variables are not allowed in constant
+// positions such as default values.
+func (p *parser) parseValue(constant bool) (*Value, error) {
+	tok := p.tok
+	value := &Value{Pos: tok.pos, Raw: tok.value}
+
+	switch {
+	case tok.kind == tokenPunct && tok.value == "$":
+		if constant {
+			return nil, p.lex.errorf(tok.pos, "variables are not allowed here")
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, _, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		value.Kind, value.Raw = ValueVariable, name
+		return value, nil
+	case tok.kind == tokenPunct && tok.value == "[":
+		value.Kind, value.Raw = ValueList, ""
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		for !p.isPunct("]") {
+			if p.tok.kind == tokenEOF {
+				return nil, p.lex.errorf(p.tok.pos, "unterminated list")
+			}
+			item, err := p.parseValue(constant)
+			if err != nil {
+				return nil, err
+			}
+			value.List = append(value.List, item)
+		}
+		return value, p.advance()
+	case tok.kind == tokenPunct && tok.value == "{":
+		value.Kind, value.Raw = ValueObject, ""
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		for !p.isPunct("}") {
+			name, pos, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(":"); err != nil {
+				return nil, err
+			}
+			fieldValue, err := p.parseValue(constant)
+			if err != nil {
+				return nil, err
+			}
+			value.Fields = append(value.Fields, &ObjectField{Name: name, Value: fieldValue, Pos: pos})
+		}
+		return value, p.advance()
+	case tok.kind == tokenInt:
+		value.Kind = ValueInt
+	case tok.kind == tokenFloat:
+		value.Kind = ValueFloat
+	case tok.kind == tokenString:
+		value.Kind = ValueString
+	case tok.kind == tokenName && (tok.value == "true" || tok.value == "false"):
+		value.Kind = ValueBoolean
+	case tok.kind == tokenName && tok.value == "null":
+		value.Kind = ValueNull
+	case tok.kind == tokenName:
+		value.Kind = ValueEnum
+	default:
+		return nil, p.unexpected()
+	}
+	return value, p.advance()
+}
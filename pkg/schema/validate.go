@@ -0,0 +1,608 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity distinguishes problems that break a query from advisories.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is one problem found in a document.
+type Diagnostic struct {
+	Pos      Position `json:"position"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s", d.Pos, d.Severity, d.Message)
+}
+
+// HasErrors reports whether any diagnostic is an error rather than a warning.
+func HasErrors(diagnostics []Diagnostic) bool {
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateSource parses src and validates it against s. Syntax errors are
+// returned as a single diagnostic.
+func (s *Schema) ValidateSource(src string) []Diagnostic {
+	doc, err := ParseDocument(src)
+	if err != nil {
+		if syntaxErr, ok := err.(*SyntaxError); ok {
+			return []Diagnostic{{Pos: syntaxErr.Pos, Severity: SeverityError, Message: "syntax error: " + syntaxErr.Message}}
+		}
+		return []Diagnostic{{Severity: SeverityError, Message: err.Error()}}
+	}
+	return s.Validate(doc)
+}
+
+// Validate checks doc against the schema: that every field, argument, type,
+// enum value, variable and fragment exists and is used correctly. Use of
+// deprecated fields and enum values is reported as a warning.
+func (s *Schema) Validate(doc *Document) []Diagnostic {
+	v := &validator{schema: s, fragments: map[string]*FragmentDefinition{}}
+
+	for _, frag := range doc.Fragments {
+		if _, dup := v.fragments[frag.Name]; dup {
+			v.errorf(frag.Pos, "fragment %q is defined more than once", frag.Name)
+		}
+		v.fragments[frag.Name] = frag
+	}
+
+	names := map[string]bool{}
+	for _, op := range doc.Operations {
+		if op.Name == "" && len(doc.Operations) > 1 {
+			v.errorf(op.Pos, "anonymous operations must be the only operation in a document")
+		}
+		if op.Name != "" {
+			if names[op.Name] {
+				v.errorf(op.Pos, "operation %q is defined more than once", op.Name)
+			}
+			names[op.Name] = true
+		}
+		v.validateOperation(op)
+	}
+
+	for _, frag := range doc.Fragments {
+		if !v.spreadFragments[frag.Name] {
+			v.errorf(frag.Pos, "fragment %q is never used", frag.Name)
+		}
+	}
+
+	sort.SliceStable(v.diagnostics, func(i, j int) bool {
+		a, b := v.diagnostics[i].Pos, v.diagnostics[j].Pos
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	})
+	return v.diagnostics
+}
+
+type validator struct {
+	schema      *Schema
+	fragments   map[string]*FragmentDefinition
+	diagnostics []Diagnostic
+
+	// Per operation state
+	variables       map[string]*VariableDefinition
+	usedVariables   map[string]bool
+	visiting        map[string]bool
+	spreadFragments map[string]bool
+	reported        map[string]bool
+}
+
+func (v *validator) report(pos Position, severity Severity, format string, args ...interface{}) {
+	d := Diagnostic{Pos: pos, Severity: severity, Message: fmt.Sprintf(format, args...)}
+	// Fragments are checked once per operation that spreads them; report each problem once
+	key := d.String()
+	if v.reported == nil {
+		v.reported = map[string]bool{}
+	}
+	if v.reported[key] {
+		return
+	}
+	v.reported[key] = true
+	v.diagnostics = append(v.diagnostics, d)
+}
+
+func (v *validator) errorf(pos Position, format string, args ...interface{}) {
+	v.report(pos, SeverityError, format, args...)
+}
+
+func (v *validator) warnf(pos Position, format string, args ...interface{}) {
+	v.report(pos, SeverityWarning, format, args...)
+}
+
+func (v *validator) validateOperation(op *Operation) {
+	v.variables = map[string]*VariableDefinition{}
+	v.usedVariables = map[string]bool{}
+	v.visiting = map[string]bool{}
+	if v.spreadFragments == nil {
+		v.spreadFragments = map[string]bool{}
+	}
+
+	var root *NamedRef
+	switch op.Kind {
+	case "query":
+		root = v.schema.QueryType
+	case "mutation":
+		root = v.schema.MutationType
+	case "subscription":
+		root = v.schema.SubscriptionType
+	}
+	if root == nil {
+		v.errorf(op.Pos, "schema does not support %s operations", op.Kind)
+		return
+	}
+	rootType := v.schema.Type(root.Name)
+	if rootType == nil {
+		v.errorf(op.Pos, "schema is missing the %s root type %q", op.Kind, root.Name)
+		return
+	}
+
+	for _, def := range op.Variables {
+		if _, dup := v.variables[def.Name]; dup {
+			v.errorf(def.Pos, "variable $%s is declared more than once", def.Name)
+		}
+		v.variables[def.Name] = def
+
+		t := v.schema.Type(def.Type.NamedType())
+		switch {
+		case t == nil:
+			v.errorf(def.Type.Pos, "unknown type %q for variable $%s", def.Type.NamedType(), def.Name)
+		case !t.isInput():
+			v.errorf(def.Type.Pos, "variable $%s cannot be of output type %q", def.Name, t.Name)
+		case def.Default != nil:
+			v.validateValue(def.Default, typeRefFromExpr(def.Type), "default value of $"+def.Name)
+		}
+	}
+
+	v.validateSelections(op.SelectionSet, rootType)
+
+	for _, def := range op.Variables {
+		if !v.usedVariables[def.Name] {
+			v.errorf(def.Pos, "variable $%s is never used%s", def.Name, operationLabel(op))
+		}
+	}
+}
+
+func operationLabel(op *Operation) string {
+	if op.Name == "" {
+		return ""
+	}
+	return " in operation " + op.Name
+}
+
+func (v *validator) validateSelections(selections []Selection, parent *Type) {
+	for _, sel := range selections {
+		switch sel := sel.(type) {
+		case *Field:
+			v.validateField(sel, parent)
+		case *InlineFragment:
+			v.validateDirectives(sel.Directives)
+			target := parent
+			if sel.TypeCondition != "" {
+				target = v.schema.Type(sel.TypeCondition)
+				if target == nil {
+					v.errorf(sel.Pos, "unknown type %q in inline fragment", sel.TypeCondition)
+					continue
+				}
+				if !target.isComposite() {
+					v.errorf(sel.Pos, "inline fragment cannot condition on non-composite type %q", target.Name)
+					continue
+				}
+			}
+			v.validateSelections(sel.SelectionSet, target)
+		case *FragmentSpread:
+			v.validateDirectives(sel.Directives)
+			v.spreadFragments[sel.Name] = true
+			frag, ok := v.fragments[sel.Name]
+			if !ok {
+				v.errorf(sel.Pos, "unknown fragment %q", sel.Name)
+				continue
+			}
+			if v.visiting[sel.Name] {
+				v.errorf(sel.Pos, "fragment %q spreads itself", sel.Name)
+				continue
+			}
+			target := v.schema.Type(frag.TypeCondition)
+			if target == nil {
+				v.errorf(frag.Pos, "unknown type %q in fragment %q", frag.TypeCondition, frag.Name)
+				continue
+			}
+			if !target.isComposite() {
+				v.errorf(frag.Pos, "fragment %q cannot condition on non-composite type %q", frag.Name, target.Name)
+				continue
+			}
+			v.visiting[sel.Name] = true
+			v.validateDirectives(frag.Directives)
+			v.validateSelections(frag.SelectionSet, target)
+			delete(v.visiting, sel.Name)
+		}
+	}
+}
+
+func (v *validator) validateField(field *Field, parent *Type) {
+	v.validateDirectives(field.Directives)
+
+	if field.Name == "__typename" {
+		if len(field.SelectionSet) > 0 {
+			v.errorf(field.Pos, "field \"__typename\" must not have a selection")
+		}
+		return
+	}
+
+	var def *FieldDefinition
+	switch {
+	case field.Name == "__schema" || field.Name == "__type":
+		// Introspection fields are not part of the introspected types themselves
+	case parent.Kind == KindUnion:
+		v.errorf(field.Pos, "cannot query field %q on union %q; use an inline fragment", field.Name, parent.Name)
+	default:
+		def = parent.Field(field.Name)
+		if def == nil {
+			v.errorf(field.Pos, "cannot query field %q on type %q%s", field.Name, parent.Name, suggest(field.Name, parent.fieldNames()))
+		}
+	}
+	if def == nil {
+		// Still count variable uses so one unknown field doesn't also report unused variables
+		for _, arg := range field.Arguments {
+			v.markVariables(arg.Value)
+		}
+		v.markSelectionVariables(field.SelectionSet)
+		return
+	}
+	if def.IsDeprecated {
+		v.warnf(field.Pos, "field %s.%s is deprecated%s", parent.Name, def.Name, reason(def.DeprecationReason))
+	}
+
+	v.validateArguments(field.Arguments, def.Args, fmt.Sprintf("%s.%s", parent.Name, def.Name), field.Pos)
+
+	fieldType := v.schema.Type(def.Type.NamedType())
+	if fieldType == nil {
+		// The schema file references a type it doesn't define; nothing more to check
+		v.markSelectionVariables(field.SelectionSet)
+		return
+	}
+	switch {
+	case fieldType.isComposite() && len(field.SelectionSet) == 0:
+		v.errorf(field.Pos, "field %q of type %q must have a selection of subfields", field.Name, def.Type)
+	case !fieldType.isComposite() && len(field.SelectionSet) > 0:
+		v.errorf(field.Pos, "field %q of type %q cannot have a selection", field.Name, def.Type)
+	case len(field.SelectionSet) > 0:
+		v.validateSelections(field.SelectionSet, fieldType)
+	}
+}
+
+func (v *validator) validateArguments(args []*ArgumentValue, defs []*InputValue, owner string, pos Position) {
+	given := map[string]bool{}
+	for _, arg := range args {
+		if given[arg.Name] {
+			v.errorf(arg.Pos, "argument %q is given more than once", arg.Name)
+		}
+		given[arg.Name] = true
+
+		def := findInputValue(defs, arg.Name)
+		if def == nil {
+			v.errorf(arg.Pos, "unknown argument %q on %s%s", arg.Name, owner, suggest(arg.Name, inputValueNames(defs)))
+			continue
+		}
+		v.validateValue(arg.Value, locationType(def, arg.Value), fmt.Sprintf("argument %q", arg.Name))
+	}
+
+	for _, def := range defs {
+		if def.Type.Kind == KindNonNull && def.DefaultValue == nil && !given[def.Name] {
+			v.errorf(pos, "missing required argument %q (%s) on %s", def.Name, def.Type, owner)
+		}
+	}
+}
+
+// validateDirectives checks the built-in @include and @skip directives and
+// records variables used by any directive.
+func (v *validator) validateDirectives(directives []*Directive) {
+	for _, d := range directives {
+		if d.Name == "include" || d.Name == "skip" {
+			v.validateArguments(d.Arguments, []*InputValue{{Name: "if", Type: nonNullBoolean}}, "@"+d.Name, d.Pos)
+			continue
+		}
+		for _, arg := range d.Arguments {
+			v.markVariables(arg.Value)
+		}
+	}
+}
+
+// markSelectionVariables records the variables and fragments used anywhere
+// inside selections that cannot be checked, such as those of an unknown field.
+func (v *validator) markSelectionVariables(selections []Selection) {
+	for _, sel := range selections {
+		switch sel := sel.(type) {
+		case *Field:
+			v.validateDirectives(sel.Directives)
+			for _, arg := range sel.Arguments {
+				v.markVariables(arg.Value)
+			}
+			v.markSelectionVariables(sel.SelectionSet)
+		case *InlineFragment:
+			v.validateDirectives(sel.Directives)
+			v.markSelectionVariables(sel.SelectionSet)
+		case *FragmentSpread:
+			v.validateDirectives(sel.Directives)
+			v.spreadFragments[sel.Name] = true
+			frag, ok := v.fragments[sel.Name]
+			if !ok || v.visiting[sel.Name] {
+				continue
+			}
+			v.visiting[sel.Name] = true
+			v.validateDirectives(frag.Directives)
+			v.markSelectionVariables(frag.SelectionSet)
+			delete(v.visiting, sel.Name)
+		}
+	}
+}
+
+var nonNullBoolean = &TypeRef{Kind: KindNonNull, OfType: &TypeRef{Kind: KindScalar, Name: "Boolean"}}
+
+// markVariables records the variables used anywhere inside value.
+func (v *validator) markVariables(value *Value) {
+	switch value.Kind {
+	case ValueVariable:
+		v.validateValue(value, nil, "")
+	case ValueList:
+		for _, item := range value.List {
+			v.markVariables(item)
+		}
+	case ValueObject:
+		for _, field := range value.Fields {
+			v.markVariables(field.Value)
+		}
+	}
+}
+
+// validateValue checks a literal against an input type and records variable
+// use. A variable must be declared with a type that fits ref; ref is nil when
+// only the use is being recorded.
+func (v *validator) validateValue(value *Value, ref *TypeRef, context string) {
+	if value.Kind == ValueVariable {
+		v.usedVariables[value.Raw] = true
+		def, ok := v.variables[value.Raw]
+		if !ok {
+			v.errorf(value.Pos, "variable $%s is not declared", value.Raw)
+			return
+		}
+		if ref == nil || v.schema.Type(def.Type.NamedType()) == nil {
+			return
+		}
+		varType := typeRefFromExpr(def.Type)
+		if varType.Kind != KindNonNull && def.Default != nil && def.Default.Kind != ValueNull {
+			// A default fills in for the variable, so it is never null
+			varType = &TypeRef{Kind: KindNonNull, OfType: varType}
+		}
+		if !variableFits(varType, ref) {
+			v.errorf(value.Pos, "%s expects %s, got variable $%s of type %s", context, ref, def.Name, def.Type)
+		}
+		return
+	}
+
+	if ref.Kind == KindNonNull {
+		if value.Kind == ValueNull {
+			v.errorf(value.Pos, "%s cannot be null (%s)", context, ref)
+			return
+		}
+		ref = ref.OfType
+	}
+	if value.Kind == ValueNull {
+		return
+	}
+
+	if ref.Kind == KindList {
+		if value.Kind == ValueList {
+			for _, item := range value.List {
+				v.validateValue(item, ref.OfType, context)
+			}
+			return
+		}
+		// A single value is coerced to a list of one
+		v.validateValue(value, ref.OfType, context)
+		return
+	}
+
+	t := v.schema.Type(ref.Name)
+	if t == nil {
+		return
+	}
+
+	switch t.Kind {
+	case KindInputObject:
+		if value.Kind != ValueObject {
+			v.errorf(value.Pos, "%s expects an input object of type %q", context, t.Name)
+			return
+		}
+		given := map[string]bool{}
+		for _, field := range value.Fields {
+			given[field.Name] = true
+			def := t.InputField(field.Name)
+			if def == nil {
+				v.errorf(field.Pos, "unknown field %q on input type %q%s", field.Name, t.Name, suggest(field.Name, inputValueNames(t.InputFields)))
+				continue
+			}
+			v.validateValue(field.Value, locationType(def, field.Value), fmt.Sprintf("field %q of %s", field.Name, t.Name))
+		}
+		for _, def := range t.InputFields {
+			if def.Type.Kind == KindNonNull && def.DefaultValue == nil && !given[def.Name] {
+				v.errorf(value.Pos, "missing required field %q (%s) on input type %q", def.Name, def.Type, t.Name)
+			}
+		}
+	case KindEnum:
+		if value.Kind != ValueEnum {
+			v.errorf(value.Pos, "%s expects a value of enum %q", context, t.Name)
+			return
+		}
+		enumValue := t.EnumValue(value.Raw)
+		if enumValue == nil {
+			v.errorf(value.Pos, "%q is not a value of enum %q", value.Raw, t.Name)
+			return
+		}
+		if enumValue.IsDeprecated {
+			v.warnf(value.Pos, "enum value %s.%s is deprecated%s", t.Name, enumValue.Name, reason(enumValue.DeprecationReason))
+		}
+	case KindScalar:
+		if !scalarAccepts(t.Name, value.Kind) {
+			v.errorf(value.Pos, "%s expects %s, got %s", context, t.Name, describeValue(value))
+		}
+	}
+}
+
+// variableFits reports whether a variable of type varType can be used where
+// ref is expected. The named types must match, lists must line up, and a
+// nullable variable cannot fill a non-null position.
+func variableFits(varType, ref *TypeRef) bool {
+	switch {
+	case ref.Kind == KindNonNull:
+		return varType.Kind == KindNonNull && variableFits(varType.OfType, ref.OfType)
+	case varType.Kind == KindNonNull:
+		return variableFits(varType.OfType, ref)
+	case ref.Kind == KindList:
+		return varType.Kind == KindList && variableFits(varType.OfType, ref.OfType)
+	case varType.Kind == KindList:
+		return false
+	}
+	return varType.Name == ref.Name
+}
+
+// locationType is the type value is checked against to fill def. A position
+// with a default accepts a nullable variable, since an unset variable leaves
+// the default in place; an explicit null literal is still refused.
+func locationType(def *InputValue, value *Value) *TypeRef {
+	if value.Kind == ValueVariable && def.DefaultValue != nil && def.Type.Kind == KindNonNull {
+		return def.Type.OfType
+	}
+	return def.Type
+}
+
+// scalarAccepts checks literals against the built-in scalars. Custom scalars
+// such as DateTime or JSON accept any literal.
+func scalarAccepts(scalar string, kind ValueKind) bool {
+	switch scalar {
+	case "Int":
+		return kind == ValueInt
+	case "Float":
+		return kind == ValueInt || kind == ValueFloat
+	case "String":
+		return kind == ValueString
+	case "Boolean":
+		return kind == ValueBoolean
+	case "ID":
+		return kind == ValueString || kind == ValueInt
+	}
+	return true
+}
+
+func describeValue(value *Value) string {
+	switch value.Kind {
+	case ValueInt:
+		return "integer " + value.Raw
+	case ValueFloat:
+		return "float " + value.Raw
+	case ValueString:
+		return fmt.Sprintf("string %q", value.Raw)
+	case ValueBoolean:
+		return "boolean " + value.Raw
+	case ValueEnum:
+		return "enum value " + value.Raw
+	case ValueList:
+		return "a list"
+	case ValueObject:
+		return "an object"
+	}
+	return "null"
+}
+
+// typeRefFromExpr converts a type written in a document to a TypeRef.
+func typeRefFromExpr(expr *TypeExpr) *TypeRef {
+	var ref *TypeRef
+	if expr.Elem != nil {
+		ref = &TypeRef{Kind: KindList, OfType: typeRefFromExpr(expr.Elem)}
+	} else {
+		ref = &TypeRef{Name: expr.Name}
+	}
+	if expr.NonNull {
+		ref = &TypeRef{Kind: KindNonNull, OfType: ref}
+	}
+	return ref
+}
+
+func findInputValue(defs []*InputValue, name string) *InputValue {
+	for _, def := range defs {
+		if def.Name == name {
+			return def
+		}
+	}
+	return nil
+}
+
+func inputValueNames(defs []*InputValue) []string {
+	names := make([]string, len(defs))
+	for i, def := range defs {
+		names[i] = def.Name
+	}
+	return names
+}
+
+func reason(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" || text == "No longer supported" {
+		return ""
+	}
+	return ": " + text
+}
+
+// suggest returns a "did you mean" hint for the closest candidate, if any is close.
+func suggest(name string, candidates []string) string {
+	best, bestDist := "", 3
+	lower := strings.ToLower(name)
+	for _, candidate := range candidates {
+		d := editDistance(lower, strings.ToLower(candidate))
+		if len(lower) >= 3 && strings.HasPrefix(strings.ToLower(candidate), lower) {
+			// Treat abbreviations such as "prio" as close matches
+			d = 1
+		}
+		if d < bestDist {
+			best, bestDist = candidate, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf("; did you mean %q?", best)
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,161 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func loadTestSchema(t *testing.T) *Schema {
+	t.Helper()
+	s, err := Load("testdata/schema.json")
+	if err != nil {
+		t.Fatalf("failed to load test schema: %v", err)
+	}
+	return s
+}
+
+func TestValidateAcceptsValidDocuments(t *testing.T) {
+	s := loadTestSchema(t)
+
+	docs := []string{
+		`{ viewer { id name } }`,
+		`query Issues($first: Int, $after: String, $filter: IssueFilter) {
+			issues(first: $first, after: $after, filter: $filter, orderBy: updatedAt) {
+				nodes { ...IssueFields assignee { name } }
+				pageInfo { hasNextPage endCursor }
+			}
+		}
+		fragment IssueFields on Issue { id identifier title __typename }`,
+		`mutation UpdateIssue($id: String!, $title: String) {
+			issueUpdate(id: $id, input: { title: $title, priority: 2 }) {
+				success
+				issue { ... on Issue { id state { name } } }
+			}
+		}`,
+		`query($id: String = "ENG-1", $ids: [Float!]!, $skip: Boolean!) {
+			issue(id: $id) { id }
+			issues(filter: { priority: { in: $ids } }) { nodes { id @include(if: $skip) } }
+		}`,
+		`query($skip: Boolean!) {
+			issues(filter: { or: [{ priority: { eq: 1 } }, { priority: { in: [2, 3] } }] }) {
+				nodes { id dueDate @skip(if: $skip) }
+			}
+		}`,
+	}
+	for _, doc := range docs {
+		if diags := s.ValidateSource(doc); len(diags) > 0 {
+			t.Errorf("Expected no diagnostics for:\n%s\ngot: %v", doc, diags)
+		}
+	}
+}
+
+func TestValidateReportsProblems(t *testing.T) {
+	s := loadTestSchema(t)
+
+	cases := []struct {
+		doc  string
+		want string
+	}{
+		{`{ viewer { id nmae } }`, `cannot query field "nmae" on type "User"; did you mean "name"?`},
+		{`{ issue { id } }`, `missing required argument "id" (String!) on Query.issue`},
+		{`{ issue(id: "1", foo: 2) { id } }`, `unknown argument "foo" on Query.issue`},
+		{`{ viewer }`, `must have a selection of subfields`},
+		{`{ viewer { id { x } } }`, `cannot have a selection`},
+		{`{ issues(orderBy: title) { nodes { id } } }`, `"title" is not a value of enum "PaginationOrderBy"`},
+		{`{ issues(first: "ten") { nodes { id } } }`, `expects Int, got string "ten"`},
+		{`{ issues(filter: { prio: 1 }) { nodes { id } } }`, `unknown field "prio" on input type "IssueFilter"; did you mean "priority"?`},
+		{`query($id: String!) { viewer { id } }`, `variable $id is never used`},
+		{`{ issue(id: $id) { id } }`, `variable $id is not declared`},
+		{`query($u: User) { viewer { id } }`, `variable $u cannot be of output type "User"`},
+		{`query($id: Int!) { issue(id: $id) { id } }`, `argument "id" expects String!, got variable $id of type Int!`},
+		{`query($id: String) { issue(id: $id) { id } }`, `argument "id" expects String!, got variable $id of type String`},
+		{`query($p: [Int]) { issues(filter: { priority: { in: $p } }) { nodes { id } } }`, `expects [Float!], got variable $p of type [Int]`},
+		{`query($p: Float) { issues(filter: { priority: { in: $p } }) { nodes { id } } }`, `expects [Float!], got variable $p of type Float`},
+		{`{ viewer { ...Missing } }`, `unknown fragment "Missing"`},
+		{`{ viewer { id } } fragment Unused on User { id }`, `fragment "Unused" is never used`},
+		{`mutation { issueUpdate(id: "1", input: null) { success } }`, `argument "input" cannot be null`},
+		{`subscription { viewer { id } }`, `schema does not support subscription operations`},
+		{`{ viewer { id }`, `syntax error: unterminated selection set`},
+	}
+	for _, tc := range cases {
+		diags := s.ValidateSource(tc.doc)
+		if !HasErrors(diags) {
+			t.Errorf("Expected an error for %s", tc.doc)
+			continue
+		}
+		found := false
+		for _, d := range diags {
+			if strings.Contains(d.Message, tc.want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected %q for %s, got %v", tc.want, tc.doc, diags)
+		}
+	}
+}
+
+func TestValidateUnknownFieldCountsNestedVariables(t *testing.T) {
+	s := loadTestSchema(t)
+
+	diags := s.ValidateSource(`query D($x: String, $skip: Boolean!) {
+		bogus { inner(a: $x) { ...Nested @skip(if: $skip) } }
+	}
+	fragment Nested on Issue { id }`)
+	if len(diags) != 1 || !strings.Contains(diags[0].Message, `cannot query field "bogus"`) {
+		t.Errorf("Expected only the unknown field to be reported, got %v", diags)
+	}
+}
+
+func TestValidateWarnsOnDeprecations(t *testing.T) {
+	s := loadTestSchema(t)
+
+	diags := s.ValidateSource(`{
+		issues(orderBy: priority) { nodes { labelIds } }
+	}`)
+	if HasErrors(diags) {
+		t.Fatalf("Expected only warnings, got %v", diags)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("Expected 2 warnings, got %v", diags)
+	}
+	if got := diags[0].String(); got != "2:19: warning: enum value PaginationOrderBy.priority is deprecated: Sorting by priority is no longer supported." {
+		t.Errorf("Unexpected first warning: %s", got)
+	}
+	if !strings.Contains(diags[1].Message, "Issue.labelIds is deprecated: Use labels instead.") {
+		t.Errorf("Unexpected second warning: %s", diags[1])
+	}
+}
+
+func TestParseDocumentSyntax(t *testing.T) {
+	doc, err := ParseDocument(`
+		# A comment
+		query Q($a: [String!]! = ["x"], $b: Float = -1.5e3) @live {
+			alias: field(arg: """block "quoted" string""", esc: "tab\there é") { id }
+		}
+	`)
+	if err != nil {
+		t.Fatalf("ParseDocument failed: %v", err)
+	}
+
+	op := doc.Operations[0]
+	if op.Name != "Q" || len(op.Variables) != 2 || op.Variables[0].Type.String() != "[String!]!" {
+		t.Errorf("Unexpected operation: %+v", op)
+	}
+	field := op.SelectionSet[0].(*Field)
+	if field.Alias != "alias" || field.Name != "field" || field.Pos != (Position{Line: 4, Column: 4}) {
+		t.Errorf("Unexpected field: %+v", field)
+	}
+	if got := field.Arguments[0].Value.Raw; got != `block "quoted" string` {
+		t.Errorf("Unexpected block string: %q", got)
+	}
+	if got := field.Arguments[1].Value.Raw; got != "tab\there é" {
+		t.Errorf("Unexpected escaped string: %q", got)
+	}
+
+	for _, bad := range []string{"", "query {", `{ a(b: "unterminated) }`, "{ a(b: $) }", "query Q($a: String = $b) { a }"} {
+		if _, err := ParseDocument(bad); err == nil {
+			t.Errorf("Expected syntax error for %q", bad)
+		}
+	}
+}